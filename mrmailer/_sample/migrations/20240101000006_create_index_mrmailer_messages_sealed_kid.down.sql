@@ -0,0 +1,3 @@
+-- --------------------------------------------------------------------------------------------------
+
+DROP INDEX sample_schema.ix_mrmailer_messages_sealed_kid;
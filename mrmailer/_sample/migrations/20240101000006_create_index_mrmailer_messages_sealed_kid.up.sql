@@ -0,0 +1,5 @@
+-- --------------------------------------------------------------------------------------------------
+
+-- OPTIONAL
+-- for reseal task (WithSealer): search of records that are not sealed by the current key
+CREATE INDEX ix_mrmailer_messages_sealed_kid ON sample_schema.mrmailer_messages ((message_data->'_sealed'->>'kid'));
@@ -0,0 +1,6 @@
+-- --------------------------------------------------------------------------------------------------
+
+DROP INDEX sample_schema.ix_mrmailer_messages_redacted_at;
+
+ALTER TABLE sample_schema.mrmailer_messages
+    DROP COLUMN redacted_at;
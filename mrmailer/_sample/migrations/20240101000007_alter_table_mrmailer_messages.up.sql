@@ -0,0 +1,8 @@
+-- --------------------------------------------------------------------------------------------------
+
+ALTER TABLE sample_schema.mrmailer_messages
+    ADD COLUMN redacted_at timestamp with time zone NULL; -- время удаления содержимого сообщения после его доставки
+
+-- OPTIONAL
+-- for remove task (WithContentRedaction): search of messages whose retention after redaction has expired
+CREATE INDEX ix_mrmailer_messages_redacted_at ON sample_schema.mrmailer_messages (redacted_at) WHERE redacted_at IS NOT NULL;
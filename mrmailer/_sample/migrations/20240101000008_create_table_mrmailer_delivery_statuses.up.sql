@@ -0,0 +1,19 @@
+-- --------------------------------------------------------------------------------------------------
+
+-- OPTIONAL
+-- for select, insert, update
+CREATE TABLE sample_schema.mrmailer_delivery_statuses (
+    message_id int8 NOT NULL CONSTRAINT pk_mrmailer_delivery_statuses PRIMARY KEY,
+    message_channel character varying(128) NOT NULL,
+    recipients character varying(320)[] NOT NULL DEFAULT '{}', -- email адреса, ID чатов, номера телефонов или хеши URL веб-хуков
+    delivery_status int2 NOT NULL, -- 1=QUEUED, 2=SENT, 3=FAILED, 4=DROPPED
+    provider_name character varying(64) NOT NULL DEFAULT '',
+    provider_message_id character varying(256) NOT NULL DEFAULT '',
+    attempts int2 NOT NULL DEFAULT 0 CHECK(attempts >= 0),
+    last_error text NOT NULL DEFAULT '',
+    sent_at timestamp with time zone NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ix_mrmailer_delivery_statuses_recipients ON sample_schema.mrmailer_delivery_statuses USING GIN (recipients);
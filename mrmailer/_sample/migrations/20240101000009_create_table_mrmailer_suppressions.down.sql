@@ -0,0 +1,3 @@
+-- --------------------------------------------------------------------------------------------------
+
+DROP TABLE sample_schema.mrmailer_suppressions;
@@ -0,0 +1,12 @@
+-- --------------------------------------------------------------------------------------------------
+
+-- OPTIONAL
+-- for select, insert, update, delete
+CREATE TABLE sample_schema.mrmailer_suppressions (
+    recipient character varying(320) NOT NULL, -- email адрес, ID чата или номер телефона
+    message_channel character varying(128) NOT NULL DEFAULT '', -- пустое значение - все каналы
+    suppress_reason int2 NOT NULL, -- 1=HARD_BOUNCE, 2=COMPLAINT, 3=UNSUBSCRIBE, 4=MANUAL
+    expires_at timestamp with time zone NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT pk_mrmailer_suppressions PRIMARY KEY (recipient, message_channel)
+);
@@ -12,10 +12,20 @@ type (
 	Message struct {
 		Channel       string
 		SendAfter     time.Time
+		SendWindow    *SendWindow // OPTIONAL
 		RetryAttempts int16
 		Data          MessageData
 	}
 
+	// SendWindow - интервал местного времени получателя, в который разрешена отправка сообщения.
+	// Сообщение, время отправки которого не попадает в интервал, откладывается до его ближайшего начала.
+	// Если To меньше From, то интервал переходит через полночь (например, 22:00-06:00).
+	SendWindow struct {
+		From     string // начало интервала в формате 15:04, например 09:00
+		To       string // конец интервала (не включается) в формате 15:04, например 21:00
+		TimeZone string // IANA часовой пояс получателя, например Europe/Moscow (по умолчанию UTC)
+	}
+
 	// MessageData - собирательная структура, которая позволяет
 	// хранить один из нескольких типов сообщений в виде json.
 	MessageData = entity.MessageData
@@ -23,9 +33,21 @@ type (
 	// DataMail - тип сообщения, которое отправляется в виде электронного письма на почтовый сервис.
 	DataMail = entity.DataMail
 
+	// MailAttachment - вложение электронного письма.
+	MailAttachment = entity.MailAttachment
+
 	// DataMessenger - тип сообщения, которое отправляется в виде текста в Messenger сервис.
 	DataMessenger = entity.DataMessenger
 
+	// MessengerButton - кнопка со ссылкой, отображаемая под сообщением мессенджера.
+	MessengerButton = entity.MessengerButton
+
 	// DataSMS - тип сообщения, которое отправляется в виде короткого сообщения на телефон.
 	DataSMS = entity.DataSMS
+
+	// DataWebhook - тип сообщения, которое отправляется в виде HTTP запроса во внешнюю систему.
+	DataWebhook = entity.DataWebhook
+
+	// DataPush - тип сообщения, которое отправляется в виде push уведомления на мобильное устройство.
+	DataPush = entity.DataPush
 )
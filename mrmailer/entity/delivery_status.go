@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/mondegor/go-components/mrmailer/enum/deliverystatus"
+)
+
+const (
+	// ModelNameDeliveryStatus - название сущности.
+	ModelNameDeliveryStatus = "mrmailer.DeliveryStatus"
+)
+
+type (
+	// DeliveryStatus - статус доставки сообщения, который хранится
+	// независимо от сообщения и элемента очереди (после их удаления).
+	DeliveryStatus struct {
+		MessageID         uint64
+		Channel           string
+		Recipients        []string // email адреса (в нижнем регистре), ID чатов, номера телефонов, токены устройств или хеши
+		Status            deliverystatus.Enum
+		Provider          string
+		ProviderMessageID string
+		Attempts          uint16
+		LastError         string
+		SentAt            *time.Time
+		CreatedAt         time.Time
+		UpdatedAt         time.Time
+	}
+)
@@ -1,5 +1,7 @@
 package entity
 
+import "encoding/json"
+
 const (
 	// ModelNameMessage - название сущности.
 	ModelNameMessage = "mrmailer.Message"
@@ -20,23 +22,59 @@ type (
 		Mail      *DataMail         `json:"mail,omitempty"`
 		Messenger *DataMessenger    `json:"messenger,omitempty"`
 		SMS       *DataSMS          `json:"sms,omitempty"`
+		Webhook   *DataWebhook      `json:"webhook,omitempty"`
+		Push      *DataPush         `json:"push,omitempty"`
 	}
 
 	// DataMail - тип сообщения, которое отправляется в виде электронного письма на почтовый сервис.
 	DataMail struct {
 		ContentType string `json:"content_type"`
-		From        string `json:"from"` // name | email | name <email>
-		To          string `json:"to"`
+		From        string `json:"from"`          // name | email | name <email>
+		To          string `json:"to"`            // список адресов через запятую (RFC 5322)
+		Cc          string `json:"cc,omitempty"`  // список адресов через запятую (RFC 5322)
+		Bcc         string `json:"bcc,omitempty"` // список адресов через запятую (RFC 5322), в заголовки письма не попадает
 		ReplyTo     string `json:"reply_to,omitempty"`
 		Subject     string `json:"subject"`
 		Content     string `json:"content"`
+		TextContent string `json:"text_content,omitempty"` // текстовая версия письма, если Content в формате HTML
+
+		// Headers - дополнительные заголовки письма (List-Unsubscribe, In-Reply-To, References, X-* и т.д.).
+		Headers map[string]string `json:"headers,omitempty"`
+
+		Attachments []MailAttachment `json:"attachments,omitempty"`
+	}
+
+	// MailAttachment - вложение электронного письма.
+	// Содержимое вложения указывается либо непосредственно в Content,
+	// либо в виде ссылки StorageRef на файл во внешнем хранилище (загружается при отправке письма).
+	// Если указан ContentID, то вложение встраивается в тело письма (inline)
+	// и на него можно ссылаться из HTML через cid:ContentID.
+	MailAttachment struct {
+		Name        string `json:"name"`
+		ContentType string `json:"content_type"`
+		ContentID   string `json:"content_id,omitempty"`
+		Content     []byte `json:"content,omitempty"`
+		StorageRef  string `json:"storage_ref,omitempty"`
 	}
 
 	// DataMessenger - тип сообщения, которое отправляется в виде текста в Messenger сервис.
+	// ParseMode определяет разметку Content: plain, Markdown, MarkdownV2, HTML
+	// (пустое значение - разметка по умолчанию клиента мессенджера).
 	DataMessenger struct {
-		From    string `json:"from"`
-		ChatID  string `json:"chat_id"`
-		Content string `json:"content"`
+		From               string              `json:"from"`
+		ChatID             string              `json:"chat_id"`
+		ThreadID           string              `json:"thread_id,omitempty"` // ID темы (топика) чата
+		Content            string              `json:"content"`
+		ParseMode          string              `json:"parse_mode,omitempty"`
+		Buttons            [][]MessengerButton `json:"buttons,omitempty"` // ряды кнопок со ссылками под сообщением
+		DisableLinkPreview bool                `json:"disable_link_preview,omitempty"`
+		Silent             bool                `json:"silent,omitempty"` // доставка без звукового оповещения
+	}
+
+	// MessengerButton - кнопка со ссылкой, отображаемая под сообщением мессенджера.
+	MessengerButton struct {
+		Text string `json:"text"`
+		URL  string `json:"url"`
 	}
 
 	// DataSMS - тип сообщения, которое отправляется в виде короткого сообщения на телефон.
@@ -45,6 +83,26 @@ type (
 		Phone   string `json:"phone"`
 		Content string `json:"content"`
 	}
+
+	// DataWebhook - тип сообщения, которое отправляется в виде HTTP запроса во внешнюю систему.
+	// Если Method не указан, то используется POST, тело запроса передаётся как application/json.
+	DataWebhook struct {
+		URL     string            `json:"url"`
+		Method  string            `json:"method,omitempty"`
+		Headers map[string]string `json:"headers,omitempty"`
+		Body    json.RawMessage   `json:"body,omitempty"`
+	}
+
+	// DataPush - тип сообщения, которое отправляется в виде push уведомления на мобильное устройство.
+	// Уведомление без Title и Body является фоновым (передаётся только Data).
+	DataPush struct {
+		DeviceToken string            `json:"device_token"`
+		Title       string            `json:"title,omitempty"`
+		Body        string            `json:"body,omitempty"`
+		Data        map[string]string `json:"data,omitempty"`
+		TTL         uint32            `json:"ttl,omitempty"`          // время жизни уведомления в секундах (0 - по умолчанию шлюза)
+		CollapseKey string            `json:"collapse_key,omitempty"` // уведомления с одним ключом заменяют друг друга
+	}
 )
 
 // MessageID - возвращает идентификатор сообщения (реализация интерфейса элемента очереди).
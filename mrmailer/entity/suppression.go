@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/mondegor/go-components/mrmailer/enum/suppressreason"
+)
+
+const (
+	// ModelNameSuppression - название сущности.
+	ModelNameSuppression = "mrmailer.Suppression"
+)
+
+type (
+	// Suppression - запись списка подавления, запрещающая отправку сообщений получателю.
+	Suppression struct {
+		Recipient string // email адрес (в нижнем регистре), ID чата или номер телефона
+		Channel   string // пустое значение означает все каналы
+		Reason    suppressreason.Enum
+		ExpiresAt *time.Time // nil - запись действует бессрочно
+		CreatedAt time.Time
+	}
+)
@@ -13,4 +13,95 @@ var (
 
 	// ErrInternalProviderClientNotSpecified - there is no provider client to send this message of type (attrs: channel, type).
 	ErrInternalProviderClientNotSpecified = errors.NewInternalProto("there is no provider client to send this message of type")
+
+	// ErrInternalRoutingSenderNotFound - sender specified in routing rules is not found (attr: sender).
+	ErrInternalRoutingSenderNotFound = errors.NewInternalProto("sender specified in routing rules is not found")
+
+	// ErrInternalRoutingPatternInvalid - channel pattern of routing rule is invalid (attr: pattern).
+	ErrInternalRoutingPatternInvalid = errors.NewInternalProto("channel pattern of routing rule is invalid")
+
+	// ErrInternalCheckMessageRecipientsInvalid - mail recipients are invalid (attr: channel).
+	ErrInternalCheckMessageRecipientsInvalid = errors.NewInternalProto("mail recipients are invalid")
+
+	// ErrInternalCheckMessageTooManyRecipients - mail has too many recipients (attrs: channel, count, maxCount).
+	ErrInternalCheckMessageTooManyRecipients = errors.NewInternalProto("mail has too many recipients")
+
+	// ErrInternalCheckMessageHeadersInvalid - mail headers are invalid (attr: channel).
+	ErrInternalCheckMessageHeadersInvalid = errors.NewInternalProto("mail headers are invalid")
+
+	// ErrInternalCheckMessageAttachmentInvalid - mail attachment is invalid (attrs: channel, name, reason).
+	ErrInternalCheckMessageAttachmentInvalid = errors.NewInternalProto("mail attachment is invalid")
+
+	// ErrInternalCheckMessageAttachmentsTooLarge - mail attachments are too large (attrs: channel, name, size, maxSize).
+	ErrInternalCheckMessageAttachmentsTooLarge = errors.NewInternalProto("mail attachments are too large")
+
+	// ErrInternalMailAttachmentLoaderNotSpecified - there is no loader for mail attachment with storage reference (attr: name).
+	ErrInternalMailAttachmentLoaderNotSpecified = errors.NewInternalProto("there is no loader for mail attachment with storage reference")
+
+	// ErrInternalSMSSenderIDInvalid - sms sender ID is invalid (attr: from).
+	ErrInternalSMSSenderIDInvalid = errors.NewInternalProto("sms sender ID is invalid")
+
+	// ErrInternalSMSPhoneInvalid - sms recipient phone is invalid (attr: phone).
+	ErrInternalSMSPhoneInvalid = errors.NewInternalProto("sms recipient phone is invalid")
+
+	// ErrInternalSMSContentInvalidLength - sms content is empty or too long (attrs: encoding, units, segments, maxSegments).
+	ErrInternalSMSContentInvalidLength = errors.NewInternalProto("sms content is empty or too long")
+
+	// ErrInternalCheckMessageSMSInvalid - sms message is invalid (attr: channel).
+	ErrInternalCheckMessageSMSInvalid = errors.NewInternalProto("sms message is invalid")
+
+	// ErrInternalCheckMessageWebhookInvalid - webhook request is invalid (attr: channel).
+	ErrInternalCheckMessageWebhookInvalid = errors.NewInternalProto("webhook request is invalid")
+
+	// ErrInternalWebhookRejected - webhook receiver rejected the request permanently or its address is denied (attrs: url, status, response).
+	ErrInternalWebhookRejected = errors.NewInternalProto("webhook receiver rejected the request")
+
+	// ErrSystemWebhookUnavailable - webhook receiver is temporarily unavailable, sending can be retried (attrs: url, status, response).
+	ErrSystemWebhookUnavailable = errors.NewSystemProto("webhook receiver is temporarily unavailable")
+
+	// ErrInternalCheckMessagePushInvalid - push notification is invalid (attr: channel).
+	ErrInternalCheckMessagePushInvalid = errors.NewInternalProto("push notification is invalid")
+
+	// ErrInternalPushTokenInvalid - device token is invalid or unregistered, sending must not be retried (attrs: status, reason).
+	ErrInternalPushTokenInvalid = errors.NewInternalProto("push device token is invalid or unregistered")
+
+	// ErrInternalPushGatewayRejected - push gateway rejected the notification permanently (attrs: status, response).
+	ErrInternalPushGatewayRejected = errors.NewInternalProto("push gateway rejected the notification")
+
+	// ErrSystemPushGatewayUnavailable - push gateway is temporarily unavailable, sending can be retried (attrs: status, response).
+	ErrSystemPushGatewayUnavailable = errors.NewSystemProto("push gateway is temporarily unavailable")
+
+	// ErrInternalCheckMessageSendWindowInvalid - message send window is invalid (attr: channel).
+	ErrInternalCheckMessageSendWindowInvalid = errors.NewInternalProto("message send window is invalid")
+
+	// ErrInternalMailDKIMNotSupported - mail client does not send the message as is, so it cannot be signed by DKIM (attr: client).
+	ErrInternalMailDKIMNotSupported = errors.NewInternalProto("mail client does not support sending DKIM signed messages")
+
+	// ErrInternalMailPoolClosed - smtp connection pool is closed (attr: addr).
+	ErrInternalMailPoolClosed = errors.NewInternalProto("smtp connection pool is closed")
+
+	// ErrInternalMailServerExtensionRequired - smtp server does not support the required extension (attrs: addr, extension).
+	ErrInternalMailServerExtensionRequired = errors.NewInternalProto("smtp server does not support the required extension")
+
+	// ErrInternalProviderRejected - provider rejected the message permanently, sending must not be retried (attrs: code or status, reply).
+	ErrInternalProviderRejected = errors.NewInternalProto("provider rejected the message")
+
+	// ErrSystemProviderUnavailable - provider is temporarily unavailable, sending can be retried (attrs: code or status, reply, retryAfter).
+	ErrSystemProviderUnavailable = errors.NewSystemProto("provider is temporarily unavailable")
+
+	// ErrInternalCheckMessageMessengerFromUnknown - messenger sender (bot) is unknown (attrs: channel, from).
+	ErrInternalCheckMessageMessengerFromUnknown = errors.NewInternalProto("messenger sender is unknown")
+
+	// ErrInternalMessengerBotNotFound - messenger client (bot) is not found for the sender, sending must not be retried (attrs: messageId, from).
+	ErrInternalMessengerBotNotFound = errors.NewInternalProto("messenger client is not found for the sender")
+
+	// ErrInternalMessengerFormattingNotSupported - messenger client sends only text, so the message with formatting or delivery options
+	// is not sent, sending must not be retried (attrs: messageId, from).
+	ErrInternalMessengerFormattingNotSupported = errors.NewInternalProto("messenger client does not support message formatting")
+
+	// ErrInternalCheckMessageMessengerInvalid - messenger message is invalid (attr: channel).
+	ErrInternalCheckMessageMessengerInvalid = errors.NewInternalProto("messenger message is invalid")
+
+	// ErrInternalRedactRetentionTooShort - retention of redacted messages must exceed the redaction period (attrs: period, retention).
+	ErrInternalRedactRetentionTooShort = errors.NewInternalProto("retention of redacted messages must exceed the redaction period")
 )
@@ -2,33 +2,65 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"github.com/mondegor/go-core/errors"
+	"github.com/mondegor/go-core/mrtrace"
 	tracectx "github.com/mondegor/go-core/mrtrace/context"
 
 	"github.com/mondegor/go-components/mrmailer"
 	"github.com/mondegor/go-components/mrmailer/entity"
 	"github.com/mondegor/go-components/mrmailer/sendmessage"
+	"github.com/mondegor/go-components/mrqueue/tracing"
+)
+
+const (
+	spanNameDequeue = "mrmailer.dequeue"
+	spanNameSend    = "mrmailer.send"
 )
 
 type (
 	// SendMessage - обработчик сообщений с целью их отправки конечному получателю.
 	SendMessage struct {
 		senderProvider sendmessage.SenderProvider
+		tracer         mrtrace.Tracer    // OPTIONAL
+		statusTracker  statusTracker     // OPTIONAL
+		suppression    suppressionFilter // OPTIONAL
+	}
+
+	statusTracker interface {
+		TrackAttempt(ctx context.Context, messageID uint64, receipt *mrmailer.DeliveryReceipt, sendErr error)
+		TrackDropped(ctx context.Context, messageID uint64, cause string)
+	}
+
+	suppressionFilter interface {
+		Apply(ctx context.Context, message entity.Message) (filtered entity.Message, cause string, err error)
 	}
 )
 
 // NewSendMessage - создаёт объект SendMessage.
 func NewSendMessage(
 	senderProvider sendmessage.SenderProvider,
+	opts ...Option,
 ) *SendMessage {
-	return &SendMessage{
-		senderProvider: senderProvider,
+	o := options{
+		handler: &SendMessage{
+			senderProvider: senderProvider,
+		},
 	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.handler
 }
 
 // Execute - подбирает провайдера, для конкретного сообщения
 // и через него отправляет его конечному получателю.
+// Если указан statusTracker, то ему передаётся результат каждой попытки отправки.
+// Если указан фильтр списка подавления, то перед отправкой из сообщения исключаются подавленные получатели,
+// а сообщение, которое некому отправлять, не отправляется и считается обработанным (со статусом DROPPED).
 func (h *SendMessage) Execute(_ context.Context, message entity.Message) (commit func(ctx context.Context) error, err error) {
 	sender, err := h.senderProvider.Sender(message.Data)
 	if err != nil {
@@ -39,11 +71,52 @@ func (h *SendMessage) Execute(_ context.Context, message entity.Message) (commit
 		)
 	}
 
-	return func(ctx context.Context) error {
+	return func(ctx context.Context) (err error) {
 		if id, ok := message.Data.Header[mrmailer.HeaderCorrelationID]; ok && id != "" {
 			ctx = tracectx.WithCorrelationID(ctx, id)
 		}
 
-		return sender.Send(ctx, message)
+		// восстанавливается контекст трассировки, с которым сообщение было размещено в очереди
+		ctx = tracing.Extract(ctx, message.Data.Header, "")
+
+		if enqueuedAt, ok := tracing.EnqueuedAt(message.Data.Header, ""); ok {
+			tracing.Trace(tracing.WithChildSpan(ctx), h.tracer, spanNameDequeue, enqueuedAt, nil, "messageId", message.ID)
+		}
+
+		ctx = tracing.WithChildSpan(ctx)
+		startedAt := time.Now()
+
+		defer func() {
+			tracing.Trace(ctx, h.tracer, spanNameSend, startedAt, err, "messageId", message.ID, "channel", message.Channel)
+		}()
+
+		filtered := message
+
+		if h.suppression != nil {
+			var cause string
+
+			if filtered, cause, err = h.suppression.Apply(ctx, message); err != nil {
+				return err
+			}
+
+			if cause != "" {
+				if h.statusTracker != nil {
+					h.statusTracker.TrackDropped(ctx, message.ID, cause)
+				}
+
+				return nil
+			}
+		}
+
+		if h.statusTracker == nil {
+			return sender.Send(ctx, filtered)
+		}
+
+		ctx, receipt := mrmailer.WithDeliveryReceipt(ctx)
+
+		err = sender.Send(ctx, filtered)
+		h.statusTracker.TrackAttempt(ctx, message.ID, receipt, err)
+
+		return err
 	}, nil
 }
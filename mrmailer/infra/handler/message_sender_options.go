@@ -0,0 +1,35 @@
+package handler
+
+import "github.com/mondegor/go-core/mrtrace"
+
+type (
+	// Option - настройка объекта SendMessage.
+	Option func(o *options)
+
+	options struct {
+		handler *SendMessage
+	}
+)
+
+// WithTracer - устанавливает трейсер, которому передаются спаны ожидания и отправки сообщений.
+func WithTracer(value mrtrace.Tracer) Option {
+	return func(o *options) {
+		o.handler.tracer = value
+	}
+}
+
+// WithStatusTracker - устанавливает объект, обновляющий статусы доставки сообщений
+// по результатам попыток их отправки (например, delivery.StatusTracker).
+func WithStatusTracker(value statusTracker) Option {
+	return func(o *options) {
+		o.handler.statusTracker = value
+	}
+}
+
+// WithSuppressionFilter - устанавливает фильтр, исключающий перед отправкой получателей,
+// находящихся в списке подавления (например, suppress.Filter).
+func WithSuppressionFilter(value suppressionFilter) Option {
+	return func(o *options) {
+		o.handler.suppression = value
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/mondegor/go-components/mrmailer/dto"
 	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrqueue/tracing"
 )
 
 const (
@@ -16,6 +17,18 @@ const (
 
 	// HeaderCorrelationID - название переменной заголовка, содержащего CorrelationID.
 	HeaderCorrelationID = mrtrace.KeyCorrelationID
+
+	// HeaderTraceParent - название переменной заголовка, содержащего W3C traceparent.
+	HeaderTraceParent = tracing.KeyTraceParent
+
+	// HeaderTraceState - название переменной заголовка, содержащего W3C tracestate.
+	HeaderTraceState = tracing.KeyTraceState
+
+	// HeaderEnqueuedAt - название переменной заголовка, содержащего время размещения сообщения в очереди.
+	HeaderEnqueuedAt = tracing.KeyEnqueuedAt
+
+	// HeaderProvider - название переменной заголовка, содержащего имя провайдера, выбранного для отправки сообщения.
+	HeaderProvider = "provider"
 )
 
 type (
@@ -28,4 +41,9 @@ type (
 	MessageSender interface {
 		Send(ctx context.Context, message entity.Message) error
 	}
+
+	// AttachmentLoader - загружает содержимое вложения письма из внешнего хранилища по ссылке.
+	AttachmentLoader interface {
+		LoadAttachment(ctx context.Context, storageRef string) ([]byte, error)
+	}
 )
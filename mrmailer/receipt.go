@@ -0,0 +1,61 @@
+package mrmailer
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	// DeliveryReceipt - сведения о фактической отправке сообщения, которые заполняются
+	// отправителями по ходу отправки (через контекст, т.к. отправители могут быть обёрнуты друг в друга).
+	DeliveryReceipt struct {
+		mu                sync.Mutex
+		provider          string
+		providerMessageID string
+	}
+
+	ctxDeliveryReceiptKey struct{}
+)
+
+// WithDeliveryReceipt - возвращает контекст с новым пустым DeliveryReceipt.
+func WithDeliveryReceipt(ctx context.Context) (context.Context, *DeliveryReceipt) {
+	receipt := &DeliveryReceipt{}
+
+	return context.WithValue(ctx, ctxDeliveryReceiptKey{}, receipt), receipt
+}
+
+// SetDeliveryProvider - записывает имя провайдера, через которого отправляется сообщение
+// (если контекст не содержит DeliveryReceipt, то ничего не делает).
+func SetDeliveryProvider(ctx context.Context, name string) {
+	if r, ok := ctx.Value(ctxDeliveryReceiptKey{}).(*DeliveryReceipt); ok {
+		r.mu.Lock()
+		r.provider = name
+		r.mu.Unlock()
+	}
+}
+
+// SetProviderMessageID - записывает ID, назначенный сообщению провайдером
+// (если контекст не содержит DeliveryReceipt, то ничего не делает).
+func SetProviderMessageID(ctx context.Context, id string) {
+	if r, ok := ctx.Value(ctxDeliveryReceiptKey{}).(*DeliveryReceipt); ok {
+		r.mu.Lock()
+		r.providerMessageID = id
+		r.mu.Unlock()
+	}
+}
+
+// Provider - возвращает имя провайдера, через которого отправлялось сообщение.
+func (r *DeliveryReceipt) Provider() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.provider
+}
+
+// ProviderMessageID - возвращает ID, назначенный сообщению провайдером.
+func (r *DeliveryReceipt) ProviderMessageID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.providerMessageID
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+	"github.com/mondegor/go-core/mrstorage"
+	"github.com/mondegor/go-core/mrstorage/mrsql"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/enum/deliverystatus"
+)
+
+type (
+	// DeliveryStatusPostgres - репозиторий для хранения статусов доставки сообщений.
+	DeliveryStatusPostgres struct {
+		client       mrstorage.DBConnManager
+		table        mrsql.DBTableInfo
+		errorWrapper errors.Wrapper
+	}
+)
+
+// NewDeliveryStatusPostgres - создаёт объект DeliveryStatusPostgres.
+func NewDeliveryStatusPostgres(client mrstorage.DBConnManager, table mrsql.DBTableInfo) *DeliveryStatusPostgres {
+	return &DeliveryStatusPostgres{
+		client:       client,
+		table:        table,
+		errorWrapper: errors.NewInfraStorageWrapper(),
+	}
+}
+
+// FetchOne - возвращает статус доставки указанного сообщения.
+func (re *DeliveryStatusPostgres) FetchOne(ctx context.Context, messageID uint64) (row entity.DeliveryStatus, err error) {
+	sql := `
+		SELECT
+			` + re.table.PrimaryKey + `,
+			message_channel,
+			recipients,
+			delivery_status,
+			provider_name,
+			provider_message_id,
+			attempts,
+			last_error,
+			sent_at,
+			created_at,
+			updated_at
+		FROM
+			` + re.table.Name + `
+		WHERE
+			` + re.table.PrimaryKey + ` = $1
+		LIMIT 1;`
+
+	err = re.client.Conn(ctx).QueryRow(
+		ctx,
+		sql,
+		messageID,
+	).Scan(
+		&row.MessageID,
+		&row.Channel,
+		&row.Recipients,
+		&row.Status,
+		&row.Provider,
+		&row.ProviderMessageID,
+		&row.Attempts,
+		&row.LastError,
+		&row.SentAt,
+		&row.CreatedAt,
+		&row.UpdatedAt,
+	)
+	if err != nil {
+		return entity.DeliveryStatus{}, re.errorWrapper.Wrap(err, "messageId", messageID)
+	}
+
+	return row, nil
+}
+
+// FetchByRecipient - возвращает статусы доставки последних сообщений указанного получателя.
+func (re *DeliveryStatusPostgres) FetchByRecipient(ctx context.Context, recipient string, limit int) ([]entity.DeliveryStatus, error) {
+	sql := `
+		SELECT
+			` + re.table.PrimaryKey + `,
+			message_channel,
+			recipients,
+			delivery_status,
+			provider_name,
+			provider_message_id,
+			attempts,
+			last_error,
+			sent_at,
+			created_at,
+			updated_at
+		FROM
+			` + re.table.Name + `
+		WHERE
+			recipients @> ARRAY[$1::varchar]
+		ORDER BY
+			` + re.table.PrimaryKey + ` DESC
+		LIMIT $2;`
+
+	cursor, err := re.client.Conn(ctx).Query(
+		ctx,
+		sql,
+		recipient,
+		mrstorage.NonZeroLimit(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close()
+
+	rows := make([]entity.DeliveryStatus, 0)
+
+	for cursor.Next() {
+		var row entity.DeliveryStatus
+
+		err = cursor.Scan(
+			&row.MessageID,
+			&row.Channel,
+			&row.Recipients,
+			&row.Status,
+			&row.Provider,
+			&row.ProviderMessageID,
+			&row.Attempts,
+			&row.LastError,
+			&row.SentAt,
+			&row.CreatedAt,
+			&row.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, cursor.Err()
+}
+
+// Insert - вставляет статусы доставки новых сообщений
+// (построчно, т.к. списки получателей сообщений имеют разную длину и не передаются через UNNEST).
+func (re *DeliveryStatusPostgres) Insert(ctx context.Context, rows []entity.DeliveryStatus) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	sql := `
+		INSERT INTO ` + re.table.Name + `
+			(
+				` + re.table.PrimaryKey + `,
+				message_channel,
+				recipients,
+				delivery_status,
+				last_error
+			)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING;`
+
+	for _, row := range rows {
+		err := re.client.Conn(ctx).Exec(
+			ctx,
+			sql,
+			row.MessageID,
+			row.Channel,
+			row.Recipients,
+			row.Status,
+			row.LastError,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateAttempt - фиксирует очередную попытку отправки сообщения:
+// при успешной отправке (sentAt не nil) сообщение переводится в статус SENT,
+// иначе сохраняется ошибка попытки, а статус не меняется.
+func (re *DeliveryStatusPostgres) UpdateAttempt(
+	ctx context.Context,
+	messageID uint64,
+	provider, providerMessageID, lastError string,
+	sentAt *time.Time,
+) error {
+	sql := `
+		UPDATE
+			` + re.table.Name + `
+		SET
+			delivery_status = CASE WHEN $6::timestamptz IS NULL THEN delivery_status ELSE $5 END,
+			provider_name = CASE WHEN $2 = '' THEN provider_name ELSE $2 END,
+			provider_message_id = CASE WHEN $3 = '' THEN provider_message_id ELSE $3 END,
+			attempts = attempts + 1,
+			last_error = $4,
+			sent_at = COALESCE($6::timestamptz, sent_at),
+			updated_at = NOW()
+		WHERE
+			` + re.table.PrimaryKey + ` = $1;`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+		messageID,
+		provider,
+		providerMessageID,
+		lastError,
+		deliverystatus.Sent,
+		sentAt,
+	)
+}
+
+// UpdateStatus - переводит сообщения, ожидающие отправки (QUEUED), в указанный статус
+// и, если указана, сохраняет причину перехода.
+func (re *DeliveryStatusPostgres) UpdateStatus(ctx context.Context, messageIDs []uint64, status deliverystatus.Enum, cause string) error {
+	sql := `
+		UPDATE
+			` + re.table.Name + `
+		SET
+			delivery_status = $3,
+			last_error = CASE WHEN $4 = '' THEN last_error ELSE $4 END,
+			updated_at = NOW()
+		WHERE
+			` + re.table.PrimaryKey + ` = ANY($1) AND delivery_status = $2;`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+		messageIDs,
+		deliverystatus.Queued,
+		status,
+		cause,
+	)
+}
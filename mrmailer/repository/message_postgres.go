@@ -2,28 +2,49 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/mondegor/go-core/errors"
 	"github.com/mondegor/go-core/mrstorage"
 	"github.com/mondegor/go-core/mrstorage/mrsql"
 
-	"github.com/mondegor/go-components/mrmailer/dto"
 	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrqueue/sealdata"
 )
 
 type (
 	// MessagePostgres - репозиторий для хранения сообщений подготовленных для отправки различным получателям.
+	// Если установлен sealdata.Sealer, то данные сообщения хранятся в запечатанном виде,
+	// в открытом виде остаётся только заголовок сообщения (см. UpdateHeader).
 	MessagePostgres struct {
-		client mrstorage.DBConnManager
-		table  mrsql.DBTableInfo
+		client         mrstorage.DBConnManager
+		table          mrsql.DBTableInfo
+		completedTable mrsql.DBTableInfo // OPTIONAL: успешно обработанные элементы очереди (см. FetchForRedact)
+		sealer         *sealdata.Sealer
+	}
+
+	// storedMessageData - данные сообщения в том виде, в котором они хранятся в БД:
+	// либо все данные в открытом виде, либо заголовок и запечатанные остальные данные.
+	storedMessageData struct {
+		entity.MessageData
+		Sealed *sealdata.Envelope `json:"_sealed,omitempty"`
 	}
 )
 
 // NewMessagePostgres - создаёт объект MessagePostgres.
-func NewMessagePostgres(client mrstorage.DBConnManager, table mrsql.DBTableInfo) *MessagePostgres {
-	return &MessagePostgres{
-		client: client,
-		table:  table,
+func NewMessagePostgres(client mrstorage.DBConnManager, table mrsql.DBTableInfo, opts ...MessageOption) *MessagePostgres {
+	o := messageOptions{
+		repository: &MessagePostgres{
+			client: client,
+			table:  table,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
 	}
+
+	return o.repository
 }
 
 // FetchByIDs - возвращает список сообщений по их указанным ID.
@@ -38,35 +59,68 @@ func (re *MessagePostgres) FetchByIDs(ctx context.Context, rowsIDs []uint64) ([]
 		WHERE
 			` + re.table.PrimaryKey + ` = ANY($1);`
 
-	cursor, err := re.client.Conn(ctx).Query(
-		ctx,
-		sql,
-		rowsIDs,
-	)
+	return re.fetch(ctx, sql, len(rowsIDs), rowsIDs)
+}
+
+// FetchForReseal - возвращает и блокирует до конца транзакции сообщения, данные которых
+// не запечатаны текущим ключом (в том числе хранимые в открытом виде).
+// Если sealdata.Sealer не установлен, то возвращается пустой список.
+func (re *MessagePostgres) FetchForReseal(ctx context.Context, limit int) ([]entity.Message, error) {
+	if re.sealer == nil {
+		return nil, nil
+	}
+
+	currentKeyID, err := re.sealer.CurrentKeyID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer cursor.Close()
-
-	rows := make([]entity.Message, 0, len(rowsIDs))
-
-	for cursor.Next() {
-		var row entity.Message
+	// условие записано через сравнения, а не IS DISTINCT FROM, чтобы использовался индекс по ID ключа
+	sql := `
+		SELECT
+			` + re.table.PrimaryKey + `,
+			message_channel,
+			message_data
+		FROM
+			` + re.table.Name + `
+		WHERE
+			message_data->'` + sealdata.FieldName + `'->>'kid' IS NULL OR
+			message_data->'` + sealdata.FieldName + `'->>'kid' < $1 OR
+			message_data->'` + sealdata.FieldName + `'->>'kid' > $1
+		ORDER BY
+			` + re.table.PrimaryKey + ` ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED;`
 
-		err = cursor.Scan(
-			&row.ID,
-			&row.Channel,
-			&row.Data,
-		)
-		if err != nil {
-			return nil, err
-		}
+	return re.fetch(ctx, sql, limit, currentKeyID, limit)
+}
 
-		rows = append(rows, row)
+// FetchForRedact - возвращает и блокирует до конца транзакции сообщения с неудалённым содержимым,
+// элементы очереди которых успешно обработаны ранее указанного периода.
+func (re *MessagePostgres) FetchForRedact(ctx context.Context, period time.Duration, limit int) ([]entity.Message, error) {
+	if re.completedTable.Name == "" {
+		return nil, errors.ErrInternalIncorrectInputData.WithDetails("completed queue table is not specified")
 	}
 
-	return rows, cursor.Err()
+	sql := `
+		SELECT
+			m.` + re.table.PrimaryKey + `,
+			m.message_channel,
+			m.message_data
+		FROM
+			` + re.table.Name + ` m
+		JOIN
+			` + re.completedTable.Name + ` c
+		ON
+			c.` + re.completedTable.PrimaryKey + ` = m.` + re.table.PrimaryKey + `
+		WHERE
+			m.redacted_at IS NULL AND c.updated_at <= NOW() - INTERVAL '1 second' * $1
+		ORDER BY
+			c.updated_at ASC
+		LIMIT $2
+		FOR UPDATE OF m SKIP LOCKED;`
+
+	return re.fetch(ctx, sql, limit, uint32(period.Seconds()), limit)
 }
 
 // Insert - вставляет новое сообщение.
@@ -77,12 +131,17 @@ func (re *MessagePostgres) Insert(ctx context.Context, rows []entity.Message) er
 
 	ids := make([]uint64, 0, len(rows))
 	channels := make([]string, 0, len(rows))
-	datas := make([]dto.MessageData, 0, len(rows))
+	datas := make([]storedMessageData, 0, len(rows))
 
 	for _, row := range rows {
+		data, err := re.storedData(ctx, row)
+		if err != nil {
+			return err
+		}
+
 		ids = append(ids, row.ID)
 		channels = append(channels, row.Channel)
-		datas = append(datas, row.Data)
+		datas = append(datas, data)
 	}
 
 	sql := `
@@ -106,6 +165,89 @@ func (re *MessagePostgres) Insert(ctx context.Context, rows []entity.Message) er
 	)
 }
 
+// UpdateSealed - запечатывает заново текущим ключом данные указанных сообщений.
+func (re *MessagePostgres) UpdateSealed(ctx context.Context, rows []entity.Message) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ids, datas, err := re.storedDataList(ctx, rows)
+	if err != nil {
+		return err
+	}
+
+	sql := `
+		UPDATE
+			` + re.table.Name + ` t
+		SET
+			message_data = d.message_data
+		FROM
+			UNNEST($1::int8[], $2::jsonb[]) as d(id, message_data)
+		WHERE
+			t.` + re.table.PrimaryKey + ` = d.id;`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+		ids,
+		datas,
+	)
+}
+
+// UpdateRedacted - сохраняет данные указанных сообщений, из которых удалено содержимое,
+// и отмечает время удаления содержимого.
+func (re *MessagePostgres) UpdateRedacted(ctx context.Context, rows []entity.Message) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ids, datas, err := re.storedDataList(ctx, rows)
+	if err != nil {
+		return err
+	}
+
+	sql := `
+		UPDATE
+			` + re.table.Name + ` t
+		SET
+			message_data = d.message_data,
+			redacted_at = NOW()
+		FROM
+			UNNEST($1::int8[], $2::jsonb[]) as d(id, message_data)
+		WHERE
+			t.` + re.table.PrimaryKey + ` = d.id;`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+		ids,
+		datas,
+	)
+}
+
+// UpdateHeader - устанавливает значение указанной переменной заголовка сообщения.
+func (re *MessagePostgres) UpdateHeader(ctx context.Context, rowID uint64, name, value string) error {
+	sql := `
+		UPDATE
+			` + re.table.Name + `
+		SET
+			message_data = jsonb_set(
+				message_data,
+				'{header}',
+				COALESCE(message_data->'header', '{}'::jsonb) || jsonb_build_object($2::text, $3::text)
+			)
+		WHERE
+			` + re.table.PrimaryKey + ` = $1;`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+		rowID,
+		name,
+		value,
+	)
+}
+
 // DeleteByIDs - удаляет сообщения по их указанным ID.
 func (re *MessagePostgres) DeleteByIDs(ctx context.Context, rowsIDs []uint64) error {
 	sql := `
@@ -125,3 +267,155 @@ func (re *MessagePostgres) DeleteByIDs(ctx context.Context, rowsIDs []uint64) er
 
 	return nil
 }
+
+// DeleteNotRedactedByIDs - удаляет сообщения по их указанным ID, кроме сообщений, содержимое которых
+// было удалено: такие сообщения хранятся до истечения своего срока хранения (см. DeleteRedacted).
+func (re *MessagePostgres) DeleteNotRedactedByIDs(ctx context.Context, rowsIDs []uint64) error {
+	sql := `
+		DELETE FROM
+			` + re.table.Name + `
+		WHERE
+			` + re.table.PrimaryKey + ` = ANY($1) AND redacted_at IS NULL;`
+
+	_, err := re.client.Conn(ctx).ExecAffected(
+		ctx,
+		sql,
+		rowsIDs,
+	)
+	if err != nil {
+		return err // TODO: errorWrapper
+	}
+
+	return nil
+}
+
+// DeleteRedacted - удаляет не более limit сообщений, содержимое которых было удалено ранее
+// указанного срока хранения, и возвращает кол-во удалённых сообщений.
+func (re *MessagePostgres) DeleteRedacted(ctx context.Context, retention time.Duration, limit int) (count int, err error) {
+	sql := `
+		DELETE FROM
+			` + re.table.Name + `
+		WHERE
+			` + re.table.PrimaryKey + ` IN (
+				SELECT
+					` + re.table.PrimaryKey + `
+				FROM
+					` + re.table.Name + `
+				WHERE
+					redacted_at <= NOW() - INTERVAL '1 second' * $1
+				ORDER BY
+					redacted_at ASC
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			);`
+
+	count, err = re.client.Conn(ctx).ExecAffected(
+		ctx,
+		sql,
+		uint32(retention.Seconds()),
+		limit,
+	)
+	if err != nil {
+		return 0, err // TODO: errorWrapper
+	}
+
+	return count, nil
+}
+
+func (re *MessagePostgres) fetch(ctx context.Context, sql string, capacity int, args ...any) ([]entity.Message, error) {
+	cursor, err := re.client.Conn(ctx).Query(
+		ctx,
+		sql,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close()
+
+	rows := make([]entity.Message, 0, capacity)
+
+	for cursor.Next() {
+		var (
+			row  entity.Message
+			data storedMessageData
+		)
+
+		err = cursor.Scan(
+			&row.ID,
+			&row.Channel,
+			&data,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if row.Data, err = re.openData(ctx, row.ID, data); err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, cursor.Err()
+}
+
+// storedDataList - возвращает ID и данные указанных сообщений в том виде, в котором они хранятся в БД.
+func (re *MessagePostgres) storedDataList(ctx context.Context, rows []entity.Message) ([]uint64, []storedMessageData, error) {
+	ids := make([]uint64, 0, len(rows))
+	datas := make([]storedMessageData, 0, len(rows))
+
+	for _, row := range rows {
+		data, err := re.storedData(ctx, row)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ids = append(ids, row.ID)
+		datas = append(datas, data)
+	}
+
+	return ids, datas, nil
+}
+
+// storedData - возвращает данные сообщения в том виде, в котором они хранятся в БД.
+func (re *MessagePostgres) storedData(ctx context.Context, row entity.Message) (storedMessageData, error) {
+	if re.sealer == nil {
+		return storedMessageData{MessageData: row.Data}, nil
+	}
+
+	header := row.Data.Header
+	row.Data.Header = nil
+
+	envelope, err := re.sealer.SealJSON(ctx, row.Data, sealdata.RowAAD(row.ID))
+	if err != nil {
+		return storedMessageData{}, err
+	}
+
+	return storedMessageData{
+		MessageData: entity.MessageData{Header: header},
+		Sealed:      envelope,
+	}, nil
+}
+
+// openData - возвращает данные сообщения из хранимых в БД данных.
+func (re *MessagePostgres) openData(ctx context.Context, rowID uint64, data storedMessageData) (entity.MessageData, error) {
+	if data.Sealed == nil {
+		return data.MessageData, nil
+	}
+
+	if re.sealer == nil {
+		return entity.MessageData{}, sealdata.ErrInternalSealerNotSpecified.New()
+	}
+
+	var opened entity.MessageData
+
+	if err := re.sealer.OpenJSON(ctx, data.Sealed, sealdata.RowAAD(rowID), &opened); err != nil {
+		return entity.MessageData{}, err
+	}
+
+	opened.Header = data.Header
+
+	return opened, nil
+}
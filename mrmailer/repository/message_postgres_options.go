@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"github.com/mondegor/go-core/mrstorage/mrsql"
+
+	"github.com/mondegor/go-components/mrqueue/sealdata"
+)
+
+type (
+	// MessageOption - настройка объекта MessagePostgres.
+	MessageOption func(o *messageOptions)
+
+	messageOptions struct {
+		repository *MessagePostgres
+	}
+)
+
+// WithMessageSealer - устанавливает опцию sealer (запечатывание данных сообщений при хранении) для MessagePostgres.
+func WithMessageSealer(value *sealdata.Sealer) MessageOption {
+	return func(o *messageOptions) {
+		o.repository.sealer = value
+	}
+}
+
+// WithMessageCompletedTable - устанавливает опцию completedTable (таблица успешно обработанных элементов
+// очереди сообщений, по которой определяется время доставки сообщений) для MessagePostgres.
+func WithMessageCompletedTable(value mrsql.DBTableInfo) MessageOption {
+	return func(o *messageOptions) {
+		o.repository.completedTable = value
+	}
+}
@@ -1,8 +1,10 @@
 package repository_test
 
 import (
+	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/mondegor/go-core/mrstorage/mrsql"
 	"github.com/mondegor/go-storage/mrtests/infra"
@@ -11,6 +13,8 @@ import (
 	"github.com/mondegor/go-components/mrmailer"
 	"github.com/mondegor/go-components/mrmailer/entity"
 	"github.com/mondegor/go-components/mrmailer/repository"
+	"github.com/mondegor/go-components/mrmailer/service/redact"
+	"github.com/mondegor/go-components/mrqueue/sealdata"
 	"github.com/mondegor/go-components/tests"
 )
 
@@ -33,12 +37,28 @@ func (ts *RepositoryTestSuite) SetupSuite() {
 	ts.pgt = infra.NewPostgresTester(ts.T(), tests.DBSchemas(), tests.ExcludedDBTables())
 	ts.pgt.ApplyMigrations(tests.AppWorkDir() + "/mrmailer/_sample/migrations")
 
+	// таблица успешно обработанных элементов очереди сообщений (см. mrqueue/_sample/migrations)
+	err := ts.pgt.ConnManager().Conn(ts.ctx).Exec(
+		ts.ctx,
+		`CREATE TABLE sample_schema.mrmailer_queue_completed (
+			item_id int8 NOT NULL CONSTRAINT pk_mrmailer_queue_completed PRIMARY KEY,
+			updated_at timestamp with time zone NOT NULL DEFAULT NOW()
+		);`,
+	)
+	ts.Require().NoError(err)
+
 	ts.repo = repository.NewMessagePostgres(
 		ts.pgt.ConnManager(),
 		mrsql.DBTableInfo{
 			Name:       "sample_schema.mrmailer_messages",
 			PrimaryKey: "message_id",
 		},
+		repository.WithMessageCompletedTable(
+			mrsql.DBTableInfo{
+				Name:       "sample_schema.mrmailer_queue_completed",
+				PrimaryKey: "item_id",
+			},
+		),
 	)
 }
 
@@ -109,3 +129,146 @@ func (ts *RepositoryTestSuite) Test_Insert() {
 	ts.Require().NoError(err)
 	ts.Equal(expected, got[0])
 }
+
+func (ts *RepositoryTestSuite) Test_InsertSealedAndReseal() {
+	ts.pgt.ApplyFixtures("testdata/Insert")
+
+	expected := entity.Message{
+		ID:      2,
+		Channel: "mail",
+		Data: entity.MessageData{
+			Header: map[string]string{
+				mrmailer.HeaderCorrelationID: "56a8ee4a-7fcf-44c5-849e-e9f6a453e380",
+			},
+			Mail: &entity.DataMail{
+				ContentType: "text/plain",
+				From:        "Ivan Ivanov",
+				To:          "Ivan Ivanov <ivan.ivanov@localhost>",
+				Subject:     "Test Subject",
+				Content:     "Test Content",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	keys := map[string][]byte{
+		"2026-01": bytes.Repeat([]byte{1}, sealdata.KeySize),
+		"2026-10": bytes.Repeat([]byte{2}, sealdata.KeySize),
+	}
+
+	oldRepo := ts.newSealedRepo("2026-01", keys)
+	newRepo := ts.newSealedRepo("2026-10", keys)
+
+	ts.Require().NoError(oldRepo.Insert(ctx, []entity.Message{expected}))
+
+	// запечатанные данные не читаются без ключей
+	_, err := ts.repo.FetchByIDs(ctx, []uint64{expected.ID})
+	ts.Require().Error(err)
+
+	// заголовок хранится в открытом виде и изменяется без открытия данных
+	ts.Require().NoError(oldRepo.UpdateHeader(ctx, expected.ID, mrmailer.HeaderProvider, "smtp-primary"))
+	expected.Data.Header[mrmailer.HeaderProvider] = "smtp-primary"
+
+	// после ротации ключа на новый ключ переводятся запечатанные прежним ключом и открытые записи
+	rows, err := newRepo.FetchForReseal(ctx, 10)
+	ts.Require().NoError(err)
+	ts.Require().Len(rows, 2)
+	ts.Require().NoError(newRepo.UpdateSealed(ctx, rows))
+
+	rows, err = newRepo.FetchForReseal(ctx, 10)
+	ts.Require().NoError(err)
+	ts.Empty(rows)
+
+	got, err := newRepo.FetchByIDs(ctx, []uint64{expected.ID})
+	ts.Require().NoError(err)
+	ts.Equal(expected, got[0])
+}
+
+func (ts *RepositoryTestSuite) Test_UpdateHeader() {
+	ts.pgt.ApplyFixtures("testdata/UpdateHeader")
+
+	ctx := context.Background()
+
+	ts.Require().NoError(ts.repo.UpdateHeader(ctx, 2, mrmailer.HeaderProvider, "smtp-primary"))
+	ts.Require().NoError(ts.repo.UpdateHeader(ctx, 3, mrmailer.HeaderProvider, "smtp-secondary"))
+
+	got, err := ts.repo.FetchByIDs(ctx, []uint64{2, 3})
+	ts.Require().NoError(err)
+	ts.Require().Len(got, 2)
+
+	headers := map[uint64]map[string]string{
+		got[0].ID: got[0].Data.Header,
+		got[1].ID: got[1].Data.Header,
+	}
+
+	ts.Equal(
+		map[uint64]map[string]string{
+			2: {
+				mrmailer.HeaderCorrelationID: "56a8ee4a-7fcf-44c5-849e-e9f6a453e380",
+				mrmailer.HeaderProvider:      "smtp-primary",
+			},
+			3: {
+				mrmailer.HeaderProvider: "smtp-secondary",
+			},
+		},
+		headers,
+	)
+}
+
+func (ts *RepositoryTestSuite) Test_RedactAndDelete() {
+	ts.pgt.ApplyFixtures("testdata/Redact")
+
+	ctx := context.Background()
+
+	// содержимое удаляется только у доставленных ранее периода сообщений, которые ещё не обработаны
+	rows, err := ts.repo.FetchForRedact(ctx, time.Hour, 10)
+	ts.Require().NoError(err)
+	ts.Require().Len(rows, 1)
+	ts.Equal(uint64(2), rows[0].ID)
+
+	rows[0].Data = redact.MessageData(rows[0].Data, nil)
+	ts.Require().NoError(ts.repo.UpdateRedacted(ctx, rows))
+
+	rows, err = ts.repo.FetchForRedact(ctx, time.Hour, 10)
+	ts.Require().NoError(err)
+	ts.Empty(rows)
+
+	got, err := ts.repo.FetchByIDs(ctx, []uint64{2})
+	ts.Require().NoError(err)
+	ts.Require().Len(got, 1)
+	ts.Equal(
+		&entity.DataMail{
+			ContentType: "text/plain",
+			From:        "Ivan Ivanov",
+			To:          redact.HashRecipient(nil, "ivan.ivanov@localhost"),
+		},
+		got[0].Data.Mail,
+	)
+
+	// удаляется только сообщение, срок хранения которого после удаления содержимого истёк
+	count, err := ts.repo.DeleteRedacted(ctx, time.Hour, 10)
+	ts.Require().NoError(err)
+	ts.Equal(1, count)
+
+	// сообщения с удалённым содержимым не удаляются вместе с элементами очереди
+	ts.Require().NoError(ts.repo.DeleteNotRedactedByIDs(ctx, []uint64{2, 3, 4}))
+
+	got, err = ts.repo.FetchByIDs(ctx, []uint64{2, 3, 4, 5})
+	ts.Require().NoError(err)
+	ts.Require().Len(got, 1)
+	ts.Equal(uint64(2), got[0].ID)
+}
+
+func (ts *RepositoryTestSuite) newSealedRepo(currentKeyID string, keys map[string][]byte) *repository.MessagePostgres {
+	keyRing, err := sealdata.NewKeyRing(currentKeyID, keys)
+	ts.Require().NoError(err)
+
+	return repository.NewMessagePostgres(
+		ts.pgt.ConnManager(),
+		mrsql.DBTableInfo{
+			Name:       "sample_schema.mrmailer_messages",
+			PrimaryKey: "message_id",
+		},
+		repository.WithMessageSealer(sealdata.NewSealer(keyRing)),
+	)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mondegor/go-core/mrstorage"
+	"github.com/mondegor/go-core/mrstorage/mrsql"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+type (
+	// SuppressionPostgres - репозиторий для хранения списка подавления отправки сообщений.
+	// Записи однозначно определяются получателем и каналом (table.PrimaryKey не используется).
+	SuppressionPostgres struct {
+		client mrstorage.DBConnManager
+		table  mrsql.DBTableInfo
+	}
+)
+
+// NewSuppressionPostgres - создаёт объект SuppressionPostgres.
+func NewSuppressionPostgres(client mrstorage.DBConnManager, table mrsql.DBTableInfo) *SuppressionPostgres {
+	return &SuppressionPostgres{
+		client: client,
+		table:  table,
+	}
+}
+
+// FetchActive - возвращает действующие записи списка подавления указанных получателей по всем каналам.
+func (re *SuppressionPostgres) FetchActive(ctx context.Context, recipients []string) ([]entity.Suppression, error) {
+	sql := `
+		SELECT
+			recipient,
+			message_channel,
+			suppress_reason,
+			expires_at,
+			created_at
+		FROM
+			` + re.table.Name + `
+		WHERE
+			recipient = ANY($1) AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY
+			recipient ASC, message_channel ASC;`
+
+	return re.fetch(ctx, sql, recipients)
+}
+
+// FetchByRecipient - возвращает все записи списка подавления указанного получателя
+// (в том числе с истёкшим сроком действия).
+func (re *SuppressionPostgres) FetchByRecipient(ctx context.Context, recipient string) ([]entity.Suppression, error) {
+	sql := `
+		SELECT
+			recipient,
+			message_channel,
+			suppress_reason,
+			expires_at,
+			created_at
+		FROM
+			` + re.table.Name + `
+		WHERE
+			recipient = $1
+		ORDER BY
+			message_channel ASC;`
+
+	return re.fetch(ctx, sql, recipient)
+}
+
+// Save - добавляет запись в список подавления или обновляет причину и срок действия существующей записи.
+func (re *SuppressionPostgres) Save(ctx context.Context, row entity.Suppression) error {
+	sql := `
+		INSERT INTO ` + re.table.Name + `
+			(
+				recipient,
+				message_channel,
+				suppress_reason,
+				expires_at
+			)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (recipient, message_channel) DO UPDATE
+		SET
+			suppress_reason = EXCLUDED.suppress_reason,
+			expires_at = EXCLUDED.expires_at;`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+		row.Recipient,
+		row.Channel,
+		row.Reason,
+		row.ExpiresAt,
+	)
+}
+
+// Delete - удаляет запись списка подавления указанного получателя и канала.
+func (re *SuppressionPostgres) Delete(ctx context.Context, recipient, channel string) error {
+	sql := `
+		DELETE FROM
+			` + re.table.Name + `
+		WHERE
+			recipient = $1 AND message_channel = $2;`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+		recipient,
+		channel,
+	)
+}
+
+func (re *SuppressionPostgres) fetch(ctx context.Context, sql string, args ...any) ([]entity.Suppression, error) {
+	cursor, err := re.client.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close()
+
+	rows := make([]entity.Suppression, 0)
+
+	for cursor.Next() {
+		var row entity.Suppression
+
+		err = cursor.Scan(
+			&row.Recipient,
+			&row.Channel,
+			&row.Reason,
+			&row.ExpiresAt,
+			&row.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, cursor.Err()
+}
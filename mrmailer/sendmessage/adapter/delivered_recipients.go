@@ -0,0 +1,81 @@
+package adapter
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultDeliveredRecipientsTTL = 24 * time.Hour
+)
+
+type (
+	// deliveredRecipients - получатели писем, которым письмо уже было передано почтовым клиентом
+	// при предыдущих попытках отправки сообщения (в пределах жизни процесса).
+	// Используется, если почтовый клиент не может передать письмо всем получателям в одной транзакции.
+	// Сведения хранятся только в памяти процесса, поэтому если повторная попытка выполняется
+	// после перезапуска или другим экземпляром обработчика, то письмо передаётся всем получателям
+	// ещё раз (доставка выполняется по принципу at-least-once).
+	deliveredRecipients struct {
+		ttl     time.Duration
+		nowFunc func() time.Time
+
+		mu    sync.Mutex
+		items map[uint64]deliveredItem
+	}
+
+	deliveredItem struct {
+		recipients map[string]struct{}
+		updatedAt  time.Time
+	}
+)
+
+func newDeliveredRecipients() *deliveredRecipients {
+	return &deliveredRecipients{
+		ttl:     defaultDeliveredRecipientsTTL,
+		nowFunc: time.Now,
+		items:   make(map[uint64]deliveredItem),
+	}
+}
+
+// Contains - сообщает, было ли письмо сообщения уже передано указанному получателю.
+func (d *deliveredRecipients) Contains(messageID uint64, rcpt string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, ok := d.items[messageID].recipients[rcpt]
+
+	return ok
+}
+
+// Add - запоминает, что письмо сообщения передано указанному получателю.
+// При добавлении нового сообщения удаляются сведения о сообщениях, которые не обновлялись дольше ttl.
+func (d *deliveredRecipients) Add(messageID uint64, rcpt string) {
+	now := d.nowFunc()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	item, ok := d.items[messageID]
+	if !ok {
+		for id, expired := range d.items {
+			if now.Sub(expired.updatedAt) > d.ttl {
+				delete(d.items, id)
+			}
+		}
+
+		item.recipients = make(map[string]struct{}, 1)
+	}
+
+	item.recipients[rcpt] = struct{}{}
+	item.updatedAt = now
+	d.items[messageID] = item
+}
+
+// Remove - удаляет сведения о получателях сообщения, после того как письмо передано всем получателям.
+func (d *deliveredRecipients) Remove(messageID uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.items, messageID)
+}
@@ -0,0 +1,116 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailaddr"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailbody"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailgate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailheader"
+)
+
+type (
+	// mailAPISender - провайдер для отправки электронных писем через почтовый сервис с HTTP API.
+	mailAPISender struct {
+		clientAPI mailgate.Gateway
+		base      *mailSender
+	}
+)
+
+// NewMailAPISender - создаёт объект mailAPISender.
+// В переменной defaultFromEmail обязателен для заполнения
+// и в ней должен находиться электронный адрес отправителя, в том числе и расширенный.
+// Используются те же опции, что и в NewMailSender, при этом опция WithDKIMSigner недопустима
+// (возвращается ошибка), так как письмо формирует и подписывает сам почтовый сервис.
+func NewMailAPISender(
+	clientAPI mailgate.Gateway,
+	defaultFromEmail string,
+	opts ...MailOption,
+) (mrmailer.MessageSender, error) {
+	base, err := newMailSender(defaultFromEmail, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if base.signer != nil {
+		return nil, mrmailer.ErrInternalMailDKIMNotSupported.New("client", fmt.Sprintf("%T", clientAPI))
+	}
+
+	return &mailAPISender{
+		clientAPI: clientAPI,
+		base:      base,
+	}, nil
+}
+
+// Send - отправляет указанное сообщение.
+// Письму назначается стабильный Message-ID на основе ID сообщения (передаётся в заголовках письма),
+// а письмо передаётся почтовому сервису один раз для всех получателей To, Cc, Bcc.
+func (s *mailAPISender) Send(ctx context.Context, message entity.Message) error {
+	data := message.Data.Mail
+
+	if data == nil {
+		return errors.ErrInternalIncorrectInputData.WithDetails("message.Data.Mail is nil")
+	}
+
+	recipients, err := mailaddr.ParseRecipients(data.To, data.Cc, data.Bcc)
+	if err != nil {
+		return mrmailer.ErrInternalCheckMessageRecipientsInvalid.Wrap(err, "channel", message.Channel)
+	}
+
+	headers, err := mailheader.Normalize(data.Headers)
+	if err != nil {
+		return mrmailer.ErrInternalCheckMessageHeadersInvalid.Wrap(err, "channel", message.Channel)
+	}
+
+	attachments, err := s.base.loadAttachments(ctx, message.Channel, data.Attachments)
+	if err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(s.base.makeFromAddress(data.From))
+	if err != nil {
+		return errors.WrapInternalError(err, "parsing mail from failed", "messageId", message.ID)
+	}
+
+	var replyTo *mail.Address
+
+	if data.ReplyTo != "" {
+		if replyTo, err = mail.ParseAddress(s.base.makeFromAddress(data.ReplyTo)); err != nil {
+			return errors.WrapInternalError(err, "parsing mail reply to failed", "messageId", message.ID)
+		}
+	}
+
+	if s.base.messageIDDomain != "" {
+		if headers == nil {
+			headers = make(map[string]string, 1)
+		}
+
+		headers[mailheader.MessageID] = mailheader.NewMessageID(message.ID, s.base.messageIDDomain)
+	}
+
+	apiMail := mailgate.Mail{
+		From:        from,
+		ReplyTo:     replyTo,
+		To:          recipients.To,
+		Cc:          recipients.Cc,
+		Bcc:         recipients.Bcc,
+		Subject:     data.Subject,
+		Headers:     headers,
+		Attachments: attachments,
+	}
+
+	if mailbody.IsHTML(data.ContentType) {
+		apiMail.HTML = data.Content
+		apiMail.Text = s.base.makeTextContent(data)
+	} else {
+		apiMail.Text = data.Content
+	}
+
+	return s.clientAPI.SendMail(ctx, apiMail)
+}
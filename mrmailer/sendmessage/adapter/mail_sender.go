@@ -2,6 +2,7 @@ package adapter
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/mondegor/go-core/errors"
@@ -10,24 +11,84 @@ import (
 
 	"github.com/mondegor/go-components/mrmailer"
 	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/dkim"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailaddr"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailbody"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailheader"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
+)
+
+const (
+	headerTo = "To"
+	headerCc = "Cc"
+
+	defaultMaxAttachmentSize  = 10 * 1024 * 1024 // 10 MiB
+	defaultMaxAttachmentsSize = 20 * 1024 * 1024 // 20 MiB
 )
 
 type (
-	// Sender - провайдер для отправки сообщений через заданный мессенджер.
+	// mailSender - провайдер для отправки электронных писем через почтовый сервис.
 	mailSender struct {
 		clientAPI        mrclient.MailSender
+		rawClientAPI     RawMailSender             // передаёт письмо всем получателям в одной транзакции
+		delivered        *deliveredRecipients      // используется, если rawClientAPI не задан
+		attachmentLoader mrmailer.AttachmentLoader // OPTIONAL
+		signer           mailSigner                // OPTIONAL
 		defaultFrom      string
 		defaultFromEmail string
+		messageIDDomain  string
+		textFromHTML     bool
+
+		maxAttachmentSize  int
+		maxAttachmentsSize int
+	}
+
+	// RawMailSender - почтовый клиент, который передаёт сформированное письмо (RFC 5322)
+	// всем получателям конверта в одной транзакции (например, smtppool.Pool).
+	RawMailSender interface {
+		SendRawMail(ctx context.Context, from string, to []string, message []byte) error
+	}
+
+	mailSigner interface {
+		Sign(message []byte) (string, error)
 	}
 )
 
 // NewMailSender - создаёт объект mailSender.
 // В переменной defaultFromEmail обязателен для заполнения
 // и в ней должен находиться электронный адрес отправителя, в том числе и расширенный.
+// Для массовой отправки в качестве clientAPI рекомендуется использовать smtppool.Pool,
+// который не открывает новую SMTP сессию для каждого письма
+// и передаёт письмо всем получателям в одной транзакции (RawMailSender).
+// Опция WithDKIMSigner допустима только для клиентов, реализующих RawMailSender,
+// так как подпись формируется по письму, которое клиент передаёт серверу без изменений.
 func NewMailSender(
 	clientAPI mrclient.MailSender,
 	defaultFromEmail string,
+	opts ...MailOption,
 ) (mrmailer.MessageSender, error) {
+	s, err := newMailSender(defaultFromEmail, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.clientAPI = clientAPI
+
+	if rawClientAPI, ok := clientAPI.(RawMailSender); ok {
+		s.rawClientAPI = rawClientAPI
+	} else {
+		// клиент формирует письмо самостоятельно, поэтому подпись может не соответствовать отправленному письму
+		if s.signer != nil {
+			return nil, mrmailer.ErrInternalMailDKIMNotSupported.New("client", fmt.Sprintf("%T", clientAPI))
+		}
+
+		s.delivered = newDeliveredRecipients()
+	}
+
+	return s, nil
+}
+
+func newMailSender(defaultFromEmail string, opts ...MailOption) (*mailSender, error) {
 	addr, err := mail.ParseAddress(defaultFromEmail)
 	if err != nil {
 		return nil, errors.WrapInternalError(
@@ -37,23 +98,68 @@ func NewMailSender(
 		)
 	}
 
-	return &mailSender{
-		clientAPI:        clientAPI,
-		defaultFrom:      addr.String(),
-		defaultFromEmail: addr.Address,
-	}, nil
+	o := mailOptions{
+		sender: &mailSender{
+			defaultFrom:        addr.String(),
+			defaultFromEmail:   addr.Address,
+			messageIDDomain:    addr.Address[strings.LastIndexByte(addr.Address, '@')+1:],
+			maxAttachmentSize:  defaultMaxAttachmentSize,
+			maxAttachmentsSize: defaultMaxAttachmentsSize,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.sender, nil
 }
 
 // Send - отправляет указанное сообщение.
+// Письму назначается стабильный Message-ID на основе ID сообщения,
+// поэтому при повторных попытках отправки он не меняется.
+// В заголовки письма попадают получатели To и Cc, а получатели Bcc указываются только в конверте.
+// Если почтовый клиент реализует RawMailSender, то письмо передаётся всем получателям конверта
+// в одной транзакции, иначе письмо передаётся отдельно для каждого получателя, а получатели,
+// которым оно уже было передано, запоминаются в памяти процесса и при повторной отправке сообщения
+// этим же процессом пропускаются (иначе некоторые получатели могут получить письмо повторно).
+// Ошибки почтового клиента классифицируются по кодам ответа SMTP сервера (см. senderror.Classify).
 func (s *mailSender) Send(ctx context.Context, message entity.Message) error {
 	if message.Data.Mail == nil {
 		return errors.ErrInternalIncorrectInputData.WithDetails("message.Data.Mail is nil")
 	}
 
+	recipients, err := mailaddr.ParseRecipients(message.Data.Mail.To, message.Data.Mail.Cc, message.Data.Mail.Bcc)
+	if err != nil {
+		return mrmailer.ErrInternalCheckMessageRecipientsInvalid.Wrap(err, "channel", message.Channel)
+	}
+
+	customHeaders, err := mailheader.Normalize(message.Data.Mail.Headers)
+	if err != nil {
+		return mrmailer.ErrInternalCheckMessageHeadersInvalid.Wrap(err, "channel", message.Channel)
+	}
+
+	attachments, err := s.loadAttachments(ctx, message.Channel, message.Data.Mail.Attachments)
+	if err != nil {
+		return err
+	}
+
+	body, err := mailbody.Build(
+		mailbody.Source{
+			ContentType: message.Data.Mail.ContentType,
+			Content:     message.Data.Mail.Content,
+			TextContent: s.makeTextContent(message.Data.Mail),
+			Attachments: attachments,
+		},
+	)
+	if err != nil {
+		return errors.WrapInternalError(err, "building mail body failed", "messageId", message.ID)
+	}
+
 	smtpMessage, err := mail.NewMessage(
 		s.makeFromAddress(message.Data.Mail.From),
-		message.Data.Mail.To,
-		mail.WithContentType(message.Data.Mail.ContentType),
+		recipients.To[0].String(),
+		mail.WithContentType(body.ContentType),
 		mail.WithSubject(message.Data.Mail.Subject),
 		mail.WithReplyTo(s.makeFromAddress(message.Data.Mail.ReplyTo)),
 	)
@@ -61,20 +167,150 @@ func (s *mailSender) Send(ctx context.Context, message entity.Message) error {
 		return err
 	}
 
-	err = s.clientAPI.SendMail(
-		ctx,
-		smtpMessage.From(),
-		smtpMessage.To(),
-		smtpMessage.Header(),
-		message.Data.Mail.Content,
-	)
-	if err != nil {
+	header := smtpMessage.Header()
+	header[headerTo] = mailaddr.FormatList(recipients.To)
+
+	if len(recipients.Cc) > 0 {
+		header[headerCc] = mailaddr.FormatList(recipients.Cc)
+	}
+
+	for name, value := range customHeaders {
+		header[name] = value
+	}
+
+	if s.messageIDDomain != "" {
+		header[mailheader.MessageID] = mailheader.NewMessageID(message.ID, s.messageIDDomain)
+	}
+
+	envelope := recipients.Envelope()
+
+	if s.rawClientAPI != nil {
+		rawMessage, err := s.composeMessage(header, body.Content)
+		if err != nil {
+			return errors.WrapInternalError(err, "signing mail failed", "messageId", message.ID)
+		}
+
+		if err = s.rawClientAPI.SendRawMail(ctx, smtpMessage.From(), envelope, rawMessage); err != nil {
+			return senderror.Classify(err, "messageId", message.ID, "to", strings.Join(envelope, ", "))
+		}
+	} else if err = s.sendPerRecipient(ctx, message.ID, smtpMessage.From(), envelope, header, body.Content); err != nil {
 		return err
 	}
 
+	if messageID := header[mailheader.MessageID]; messageID != "" {
+		mrmailer.SetProviderMessageID(ctx, messageID)
+	}
+
+	return nil
+}
+
+// composeMessage - формирует письмо, которое передаётся почтовому клиенту без изменений.
+// Подпись DKIM формируется по уже сформированному письму и добавляется в его начало,
+// поэтому она соответствует именно тем байтам, которые получит почтовый сервер.
+func (s *mailSender) composeMessage(header map[string]string, body string) ([]byte, error) {
+	message := mailbody.Compose(header, body)
+
+	if s.signer == nil {
+		return message, nil
+	}
+
+	signature, err := s.signer.Sign(message)
+	if err != nil || signature == "" {
+		return message, err
+	}
+
+	return append([]byte(dkim.HeaderName+": "+signature+"\r\n"), message...), nil
+}
+
+// sendPerRecipient - передаёт письмо почтовому клиенту отдельно для каждого получателя конверта,
+// пропуская получателей, которым письмо было передано при предыдущих попытках отправки сообщения.
+func (s *mailSender) sendPerRecipient(
+	ctx context.Context,
+	messageID uint64,
+	from string,
+	envelope []string,
+	header map[string]string,
+	body string,
+) error {
+	for _, rcpt := range envelope {
+		if s.delivered.Contains(messageID, rcpt) {
+			continue
+		}
+
+		if err := s.clientAPI.SendMail(ctx, from, rcpt, header, body); err != nil {
+			return senderror.Classify(err, "messageId", messageID, "to", rcpt)
+		}
+
+		s.delivered.Add(messageID, rcpt)
+	}
+
+	s.delivered.Remove(messageID)
+
 	return nil
 }
 
+// loadAttachments - возвращает вложения письма, у которых загружено содержимое,
+// указанное в виде ссылки на файл во внешнем хранилище.
+// Размер вложений проверяется после их загрузки, так как размер вложений
+// из внешнего хранилища при размещении сообщения в очереди неизвестен.
+func (s *mailSender) loadAttachments(ctx context.Context, channel string, attachments []entity.MailAttachment) ([]entity.MailAttachment, error) {
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	var totalSize int
+
+	loaded := make([]entity.MailAttachment, len(attachments))
+
+	for i, attachment := range attachments {
+		loaded[i] = attachment
+
+		if attachment.StorageRef != "" && len(attachment.Content) == 0 {
+			if s.attachmentLoader == nil {
+				return nil, mrmailer.ErrInternalMailAttachmentLoaderNotSpecified.New("name", attachment.Name)
+			}
+
+			content, err := s.attachmentLoader.LoadAttachment(ctx, attachment.StorageRef)
+			if err != nil {
+				return nil, err
+			}
+
+			loaded[i].Content = content
+		}
+
+		if size := len(loaded[i].Content); size > s.maxAttachmentSize {
+			return nil, mrmailer.ErrInternalCheckMessageAttachmentsTooLarge.New(
+				"channel", channel,
+				"name", attachment.Name,
+				"size", size,
+				"maxSize", s.maxAttachmentSize,
+			)
+		}
+
+		totalSize += len(loaded[i].Content)
+	}
+
+	if totalSize > s.maxAttachmentsSize {
+		return nil, mrmailer.ErrInternalCheckMessageAttachmentsTooLarge.New(
+			"channel", channel,
+			"size", totalSize,
+			"maxSize", s.maxAttachmentsSize,
+		)
+	}
+
+	return loaded, nil
+}
+
+// makeTextContent - возвращает текстовую версию письма, если она указана,
+// или формирует её из HTML, если это разрешено настройками.
+func (s *mailSender) makeTextContent(data *entity.DataMail) string {
+	if data.TextContent != "" || !s.textFromHTML || !mailbody.IsHTML(data.ContentType) {
+		return data.TextContent
+	}
+
+	return mailbody.HTMLToText(data.Content)
+}
+
 func (s *mailSender) makeFromAddress(value string) string {
 	if value == "" {
 		return s.defaultFrom
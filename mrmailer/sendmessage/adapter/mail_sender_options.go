@@ -0,0 +1,66 @@
+package adapter
+
+import (
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/dkim"
+)
+
+type (
+	// MailOption - настройка объекта mailSender.
+	MailOption func(o *mailOptions)
+
+	mailOptions struct {
+		sender *mailSender
+	}
+)
+
+// WithAttachmentLoader - устанавливает загрузчик вложений, указанных в виде ссылки на файл во внешнем хранилище.
+func WithAttachmentLoader(value mrmailer.AttachmentLoader) MailOption {
+	return func(o *mailOptions) {
+		o.sender.attachmentLoader = value
+	}
+}
+
+// WithMaxAttachmentSize - устанавливает максимальный размер одного вложения письма (в байтах),
+// который проверяется при отправке после загрузки вложений из внешнего хранилища.
+func WithMaxAttachmentSize(value int) MailOption {
+	return func(o *mailOptions) {
+		o.sender.maxAttachmentSize = value
+	}
+}
+
+// WithMaxAttachmentsSize - устанавливает максимальный суммарный размер всех вложений письма (в байтах),
+// который проверяется при отправке после загрузки вложений из внешнего хранилища.
+func WithMaxAttachmentsSize(value int) MailOption {
+	return func(o *mailOptions) {
+		o.sender.maxAttachmentsSize = value
+	}
+}
+
+// WithTextFromHTML - включает автоматическое формирование текстовой версии письма
+// из HTML, если текстовая версия не была указана явно (письмо отправляется как multipart/alternative).
+func WithTextFromHTML() MailOption {
+	return func(o *mailOptions) {
+		o.sender.textFromHTML = true
+	}
+}
+
+// WithMessageIDDomain - устанавливает домен, используемый в заголовке Message-ID письма
+// (по умолчанию используется домен адреса отправителя defaultFromEmail,
+// при пустом значении заголовок Message-ID не формируется).
+func WithMessageIDDomain(value string) MailOption {
+	return func(o *mailOptions) {
+		o.sender.messageIDDomain = value
+	}
+}
+
+// WithDKIMSigner - устанавливает подпись писем DKIM, ключ выбирается по домену отправителя (From).
+// Письма доменов, для которых ключ не задан, отправляются без подписи.
+// Почтовый клиент должен реализовывать RawMailSender, иначе NewMailSender вернёт ошибку.
+func WithDKIMSigner(value *dkim.Signer) MailOption {
+	return func(o *mailOptions) {
+		if value != nil {
+			o.sender.signer = value
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package adapter_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/adapter"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/dkim"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailgate"
+)
+
+type (
+	fakeMailClient struct {
+		recipients []string
+		headers    []map[string]string
+		failures   map[string]int // кол-во ошибок, которые вернутся при отправке указанному получателю
+	}
+
+	fakeRawMailClient struct {
+		fakeMailClient
+		envelopes [][]string
+		messages  []string
+	}
+
+	fakeAttachmentLoader map[string][]byte
+
+	fakeMailGateway struct{}
+)
+
+func (c *fakeMailClient) SendMail(_ context.Context, _, to string, header map[string]string, _ string) error {
+	if c.failures[to] > 0 {
+		c.failures[to]--
+
+		return errors.New("421 4.4.2 connection timed out")
+	}
+
+	c.recipients = append(c.recipients, to)
+	c.headers = append(c.headers, header)
+
+	return nil
+}
+
+func (c *fakeRawMailClient) SendRawMail(_ context.Context, _ string, to []string, message []byte) error {
+	c.envelopes = append(c.envelopes, to)
+	c.messages = append(c.messages, string(message))
+
+	return nil
+}
+
+func (l fakeAttachmentLoader) LoadAttachment(_ context.Context, storageRef string) ([]byte, error) {
+	return l[storageRef], nil
+}
+
+func (g *fakeMailGateway) SendMail(_ context.Context, _ mailgate.Mail) error {
+	return nil
+}
+
+func newMailWithAttachments(attachments ...entity.MailAttachment) entity.Message {
+	return entity.Message{
+		ID:      1,
+		Channel: "mail",
+		Data: entity.MessageData{
+			Mail: &entity.DataMail{
+				ContentType: "text/plain",
+				To:          "john@localhost",
+				Subject:     "Report",
+				Content:     "See attachment",
+				Attachments: attachments,
+			},
+		},
+	}
+}
+
+func TestMailSender_Send_LoadedAttachmentsSize(t *testing.T) {
+	t.Parallel()
+
+	loader := fakeAttachmentLoader{
+		"s3://reports/small.pdf": []byte(strings.Repeat("a", 8)),
+		"s3://reports/large.pdf": []byte(strings.Repeat("a", 11)),
+	}
+
+	tests := []struct {
+		name        string
+		attachments []entity.MailAttachment
+		wantErr     bool
+	}{
+		{
+			name:        "loaded attachment fits",
+			attachments: []entity.MailAttachment{{Name: "small.pdf", StorageRef: "s3://reports/small.pdf"}},
+		},
+		{
+			name:        "loaded attachment is too large",
+			attachments: []entity.MailAttachment{{Name: "large.pdf", StorageRef: "s3://reports/large.pdf"}},
+			wantErr:     true,
+		},
+		{
+			name: "loaded attachments are too large in total",
+			attachments: []entity.MailAttachment{
+				{Name: "small.pdf", StorageRef: "s3://reports/small.pdf"},
+				{Name: "inline.pdf", Content: []byte(strings.Repeat("a", 8))},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeMailClient{}
+
+			sender, err := adapter.NewMailSender(
+				client,
+				"noreply@localhost",
+				adapter.WithAttachmentLoader(loader),
+				adapter.WithMaxAttachmentSize(10),
+				adapter.WithMaxAttachmentsSize(15),
+			)
+			require.NoError(t, err)
+
+			err = sender.Send(context.Background(), newMailWithAttachments(tt.attachments...))
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, mrmailer.ErrInternalCheckMessageAttachmentsTooLarge)
+				assert.Empty(t, client.recipients)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"john@localhost"}, client.recipients)
+		})
+	}
+}
+
+func TestMailSender_Send_AttachmentLoaderNotSpecified(t *testing.T) {
+	t.Parallel()
+
+	sender, err := adapter.NewMailSender(&fakeMailClient{}, "noreply@localhost")
+	require.NoError(t, err)
+
+	err = sender.Send(
+		context.Background(),
+		newMailWithAttachments(entity.MailAttachment{Name: "report.pdf", StorageRef: "s3://reports/1.pdf"}),
+	)
+	require.ErrorIs(t, err, mrmailer.ErrInternalMailAttachmentLoaderNotSpecified)
+}
+
+func TestMailSender_Send_SingleTransaction(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeRawMailClient{}
+
+	sender, err := adapter.NewMailSender(client, "noreply@localhost")
+	require.NoError(t, err)
+
+	message := newMailWithAttachments()
+	message.Data.Mail.To = "John <john@localhost>, jane@localhost"
+	message.Data.Mail.Cc = "copy@localhost"
+	message.Data.Mail.Bcc = "hidden@localhost"
+
+	require.NoError(t, sender.Send(context.Background(), message))
+
+	assert.Empty(t, client.recipients)
+	assert.Equal(t, [][]string{{"john@localhost", "jane@localhost", "copy@localhost", "hidden@localhost"}}, client.envelopes)
+	require.Len(t, client.messages, 1)
+	assert.True(t, strings.HasPrefix(client.messages[0], "Cc: <copy@localhost>\r\n"))
+	assert.NotContains(t, client.messages[0], "hidden@localhost")
+	assert.True(t, strings.HasSuffix(client.messages[0], "\r\n\r\nSee attachment"))
+}
+
+func TestMailSender_Send_SkipsDeliveredRecipients(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeMailClient{failures: map[string]int{"copy@localhost": 1}}
+
+	sender, err := adapter.NewMailSender(client, "noreply@localhost")
+	require.NoError(t, err)
+
+	message := newMailWithAttachments()
+	message.Data.Mail.Cc = "copy@localhost"
+	message.Data.Mail.Bcc = "hidden@localhost"
+
+	require.Error(t, sender.Send(context.Background(), message))
+	assert.Equal(t, []string{"john@localhost"}, client.recipients)
+
+	// при повторной отправке письмо не передаётся получателю, которому оно уже было передано
+	require.NoError(t, sender.Send(context.Background(), message))
+	assert.Equal(t, []string{"john@localhost", "copy@localhost", "hidden@localhost"}, client.recipients)
+
+	// после успешной отправки сведения о получателях сообщения не сохраняются
+	require.NoError(t, sender.Send(context.Background(), message))
+	assert.Equal(t, []string{"john@localhost", "copy@localhost", "hidden@localhost", "john@localhost", "copy@localhost", "hidden@localhost"}, client.recipients)
+}
+
+func TestMailSender_Send_BccNotInHeaders(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeMailClient{}
+
+	sender, err := adapter.NewMailSender(client, "noreply@localhost")
+	require.NoError(t, err)
+
+	message := newMailWithAttachments()
+	message.Data.Mail.To = "John <john@localhost>, jane@localhost"
+	message.Data.Mail.Cc = "copy@localhost"
+	message.Data.Mail.Bcc = "Hidden <hidden@localhost>, secret@localhost"
+
+	require.NoError(t, sender.Send(context.Background(), message))
+	assert.Equal(t, []string{"john@localhost", "jane@localhost", "copy@localhost", "hidden@localhost", "secret@localhost"}, client.recipients)
+	require.Len(t, client.headers, len(client.recipients))
+
+	for i, header := range client.headers {
+		for name, value := range header {
+			assert.NotContains(t, value, "hidden@localhost", "recipient %s, header %s", client.recipients[i], name)
+			assert.NotContains(t, value, "secret@localhost", "recipient %s, header %s", client.recipients[i], name)
+			assert.NotContains(t, value, "Hidden", "recipient %s, header %s", client.recipients[i], name)
+		}
+
+		assert.NotContains(t, header, "Bcc")
+	}
+}
+
+func TestMailSender_Send_DKIMSignature(t *testing.T) {
+	t.Parallel()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := dkim.New([]dkim.Key{{Domain: "localhost", Selector: "s1", PrivateKey: privateKey}})
+	require.NoError(t, err)
+
+	// клиент, который формирует письмо самостоятельно, не может отправлять подписанные письма
+	_, err = adapter.NewMailSender(&fakeMailClient{}, "noreply@localhost", adapter.WithDKIMSigner(signer))
+	require.ErrorIs(t, err, mrmailer.ErrInternalMailDKIMNotSupported)
+
+	// письмо, отправляемое через почтовый сервис с HTTP API, формирует и подписывает сам сервис
+	_, err = adapter.NewMailAPISender(&fakeMailGateway{}, "noreply@localhost", adapter.WithDKIMSigner(signer))
+	require.ErrorIs(t, err, mrmailer.ErrInternalMailDKIMNotSupported)
+
+	client := &fakeRawMailClient{}
+
+	sender, err := adapter.NewMailSender(client, "noreply@localhost", adapter.WithDKIMSigner(signer))
+	require.NoError(t, err)
+
+	require.NoError(t, sender.Send(context.Background(), newMailWithAttachments()))
+	require.Len(t, client.messages, 1)
+
+	signature, message, _ := strings.Cut(client.messages[0], "\r\n")
+	assert.True(t, strings.HasPrefix(signature, dkim.HeaderName+": v=1; a=ed25519-sha256;"))
+
+	// подпись сформирована по письму, которое передано клиенту
+	expected, err := signer.Sign([]byte(message))
+	require.NoError(t, err)
+	assert.Equal(t, parseTag(expected, "bh"), parseTag(signature, "bh"))
+	assert.Equal(t, parseTag(expected, "h"), parseTag(signature, "h"))
+}
+
+func parseTag(value, name string) string {
+	for _, item := range strings.Split(value, ";") {
+		if tagName, tagValue, ok := strings.Cut(strings.TrimSpace(item), "="); ok && tagName == name {
+			return tagValue
+		}
+	}
+
+	return ""
+}
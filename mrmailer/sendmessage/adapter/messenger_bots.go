@@ -0,0 +1,47 @@
+package adapter
+
+import (
+	"maps"
+
+	"github.com/mondegor/go-webcore/mrclient"
+)
+
+type (
+	// MessengerBots - реестр клиентов мессенджера (ботов), выбираемых по имени отправителя DataMessenger.From.
+	// Сообщения без отправителя отправляются через клиента по умолчанию. Если именованные боты
+	// не зарегистрированы, то отправитель не учитывается и используется клиент по умолчанию.
+	MessengerBots struct {
+		defaultClient mrclient.MessengerSender // OPTIONAL
+		bots          map[string]mrclient.MessengerSender
+	}
+)
+
+// NewMessengerBots - создаёт объект MessengerBots, где bots - клиенты мессенджера по именам отправителей,
+// defaultClient - клиент для сообщений без отправителя (если nil, то отправитель обязателен).
+func NewMessengerBots(defaultClient mrclient.MessengerSender, bots map[string]mrclient.MessengerSender) *MessengerBots {
+	return &MessengerBots{
+		defaultClient: defaultClient,
+		bots:          maps.Clone(bots),
+	}
+}
+
+// Client - возвращает клиента мессенджера для указанного отправителя или false, если он не зарегистрирован.
+func (b *MessengerBots) Client(from string) (mrclient.MessengerSender, bool) {
+	if client, ok := b.bots[from]; ok {
+		return client, true
+	}
+
+	if (from == "" || len(b.bots) == 0) && b.defaultClient != nil {
+		return b.defaultClient, true
+	}
+
+	return nil, false
+}
+
+// HasSender - сообщает, зарегистрирован ли клиент мессенджера для указанного отправителя
+// (используется при проверке сообщений перед размещением их в очереди).
+func (b *MessengerBots) HasSender(from string) bool {
+	_, ok := b.Client(from)
+
+	return ok
+}
@@ -0,0 +1,97 @@
+package adapter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mondegor/go-webcore/mrclient"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/adapter"
+)
+
+type fakeMessengerBot struct {
+	name string
+}
+
+func (b *fakeMessengerBot) SendToChat(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func TestMessengerBots_Client(t *testing.T) {
+	t.Parallel()
+
+	defaultBot := &fakeMessengerBot{name: "default"}
+	shopBot := &fakeMessengerBot{name: "shop"}
+
+	tests := []struct {
+		name          string
+		defaultClient mrclient.MessengerSender
+		bots          map[string]mrclient.MessengerSender
+		from          string
+		want          mrclient.MessengerSender
+		wantOK        bool
+	}{
+		{
+			name:          "registered sender",
+			defaultClient: defaultBot,
+			bots:          map[string]mrclient.MessengerSender{"shop": shopBot},
+			from:          "shop",
+			want:          shopBot,
+			wantOK:        true,
+		},
+		{
+			name:          "empty from uses default client",
+			defaultClient: defaultBot,
+			bots:          map[string]mrclient.MessengerSender{"shop": shopBot},
+			from:          "",
+			want:          defaultBot,
+			wantOK:        true,
+		},
+		{
+			name:          "unknown sender",
+			defaultClient: defaultBot,
+			bots:          map[string]mrclient.MessengerSender{"shop": shopBot},
+			from:          "support",
+			wantOK:        false,
+		},
+		{
+			name:          "empty registry uses default client for any sender",
+			defaultClient: defaultBot,
+			from:          "support",
+			want:          defaultBot,
+			wantOK:        true,
+		},
+		{
+			name:   "empty registry without default client",
+			from:   "",
+			wantOK: false,
+		},
+		{
+			name:   "empty from without default client",
+			bots:   map[string]mrclient.MessengerSender{"shop": shopBot},
+			from:   "",
+			wantOK: false,
+		},
+		{
+			name:   "registered sender without default client",
+			bots:   map[string]mrclient.MessengerSender{"shop": shopBot},
+			from:   "shop",
+			want:   shopBot,
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bots := adapter.NewMessengerBots(tt.defaultClient, tt.bots)
+
+			got, ok := bots.Client(tt.from)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, bots.HasSender(tt.from))
+		})
+	}
+}
@@ -8,29 +8,61 @@ import (
 
 	"github.com/mondegor/go-components/mrmailer"
 	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/messengergate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
 )
 
 type (
 	// messengerSender - провайдер для отправки сообщений через заданный мессенджер.
 	messengerSender struct {
-		clientAPI mrclient.MessengerSender
+		bots *MessengerBots
 	}
 )
 
-// NewMessengerSender - создаёт объект messengerSender.
+// NewMessengerSender - создаёт объект messengerSender, отправляющий все сообщения через указанного клиента.
 func NewMessengerSender(
 	clientAPI mrclient.MessengerSender,
 ) mrmailer.MessageSender {
+	return NewMessengerBotsSender(NewMessengerBots(clientAPI, nil))
+}
+
+// NewMessengerBotsSender - создаёт объект messengerSender, отправляющий сообщения через клиента мессенджера (бота),
+// выбранного по отправителю сообщения (см. MessengerBots). Тот же реестр следует передать
+// в produce.WithMessengerSenders, чтобы сообщения неизвестных отправителей отклонялись при размещении в очереди.
+func NewMessengerBotsSender(bots *MessengerBots) mrmailer.MessageSender {
 	return &messengerSender{
-		clientAPI: clientAPI,
+		bots: bots,
 	}
 }
 
-// Send - отправляет указанное сообщение.
+// Send - отправляет указанное сообщение через клиента мессенджера, выбранного по отправителю сообщения.
+// Если клиент реализует messengergate.Gateway (например, telegram.Client), то сообщение отправляется
+// с разметкой, кнопками и параметрами доставки, иначе через SendToChat передаётся только текст,
+// поэтому такому клиенту передаются только сообщения без оформления (см. messengergate.IsPlain).
+// Ошибки клиента мессенджера классифицируются по кодам статуса ответа сервиса (см. senderror.Classify).
 func (s *messengerSender) Send(ctx context.Context, message entity.Message) error {
 	if message.Data.Messenger == nil {
 		return errors.ErrInternalIncorrectInputData.WithDetails("message.Data.Messenger is nil")
 	}
 
-	return s.clientAPI.SendToChat(ctx, message.Data.Messenger.ChatID, message.Data.Messenger.Content)
+	clientAPI, ok := s.bots.Client(message.Data.Messenger.From)
+	if !ok {
+		return mrmailer.ErrInternalMessengerBotNotFound.New("messageId", message.ID, "from", message.Data.Messenger.From)
+	}
+
+	if gateway, ok := clientAPI.(messengergate.Gateway); ok {
+		err := gateway.SendMessage(ctx, messengergate.NewMessage(message.Data.Messenger))
+
+		return senderror.Classify(err, "messageId", message.ID)
+	}
+
+	// клиент передаёт только текст, поэтому сообщение с оформлением не отправляется,
+	// чтобы разметка, кнопки и параметры доставки не были молча отброшены
+	if !messengergate.IsPlain(message.Data.Messenger) {
+		return mrmailer.ErrInternalMessengerFormattingNotSupported.New("messageId", message.ID, "from", message.Data.Messenger.From)
+	}
+
+	err := clientAPI.SendToChat(ctx, message.Data.Messenger.ChatID, message.Data.Messenger.Content)
+
+	return senderror.Classify(err, "messageId", message.ID)
 }
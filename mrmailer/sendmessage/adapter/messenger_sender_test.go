@@ -0,0 +1,83 @@
+package adapter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/adapter"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/messengergate"
+)
+
+type fakeTextMessenger struct {
+	chatID string
+	text   string
+	calls  int
+}
+
+func (m *fakeTextMessenger) SendToChat(_ context.Context, chatID, message string) error {
+	m.calls++
+	m.chatID, m.text = chatID, message
+
+	return nil
+}
+
+func TestMessengerSender_Send_TextClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    entity.DataMessenger
+		wantErr bool
+	}{
+		{
+			name: "plain message",
+			data: entity.DataMessenger{ChatID: "100500", Content: "text", ParseMode: messengergate.ParseModePlain},
+		},
+		{
+			name:    "message with markup",
+			data:    entity.DataMessenger{ChatID: "100500", Content: "*text*", ParseMode: messengergate.ParseModeMarkdown},
+			wantErr: true,
+		},
+		{
+			name:    "message with thread",
+			data:    entity.DataMessenger{ChatID: "100500", ThreadID: "7", Content: "text"},
+			wantErr: true,
+		},
+		{
+			name: "message with buttons",
+			data: entity.DataMessenger{
+				ChatID:  "100500",
+				Content: "text",
+				Buttons: [][]entity.MessengerButton{{{Text: "Open", URL: "https://localhost"}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeTextMessenger{}
+			message := entity.Message{ID: 1, Channel: "messenger", Data: entity.MessageData{Messenger: &tt.data}}
+
+			err := adapter.NewMessengerSender(client).Send(context.Background(), message)
+			if tt.wantErr {
+				require.ErrorIs(t, err, mrmailer.ErrInternalMessengerFormattingNotSupported)
+				assert.Equal(t, 0, client.calls)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, client.calls)
+			assert.Equal(t, "100500", client.chatID)
+			assert.Equal(t, "text", client.text)
+		})
+	}
+}
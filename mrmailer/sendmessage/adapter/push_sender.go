@@ -0,0 +1,42 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/pushgate"
+)
+
+type (
+	// pushSender - провайдер для отправки push уведомлений на мобильные устройства через шлюз.
+	pushSender struct {
+		clientAPI pushgate.Gateway
+	}
+)
+
+// NewPushSender - создаёт объект pushSender.
+func NewPushSender(
+	clientAPI pushgate.Gateway,
+) mrmailer.MessageSender {
+	return &pushSender{
+		clientAPI: clientAPI,
+	}
+}
+
+// Send - отправляет указанное сообщение.
+// Если шлюз сообщил, что токен устройства недействителен, то возвращается
+// окончательная ошибка mrmailer.ErrInternalPushTokenInvalid (повторять отправку не имеет смысла).
+func (s *pushSender) Send(ctx context.Context, message entity.Message) error {
+	if message.Data.Push == nil {
+		return errors.ErrInternalIncorrectInputData.WithDetails("message.Data.Push is nil")
+	}
+
+	if err := pushgate.Validate(message.Data.Push); err != nil {
+		return mrmailer.ErrInternalCheckMessagePushInvalid.Wrap(err, "channel", message.Channel)
+	}
+
+	return s.clientAPI.SendPush(ctx, pushgate.NewNotification(message.Data.Push))
+}
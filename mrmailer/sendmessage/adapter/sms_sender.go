@@ -0,0 +1,85 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/smsgate"
+)
+
+const (
+	defaultSMSMaxSegments = 6
+)
+
+type (
+	// smsSender - провайдер для отправки коротких сообщений на телефон через SMS шлюз.
+	smsSender struct {
+		clientAPI   smsgate.Gateway
+		defaultFrom string
+		maxSegments int
+	}
+)
+
+// NewSMSSender - создаёт объект smsSender.
+// В переменной defaultFrom указывается имя отправителя (sender ID), которое
+// используется, если оно не указано в сообщении (может быть пустым, если его подставляет сам шлюз).
+// В maxSegments указывается максимальное кол-во частей одного сообщения (если 0, то 6).
+func NewSMSSender(
+	clientAPI smsgate.Gateway,
+	defaultFrom string,
+	maxSegments int,
+) (mrmailer.MessageSender, error) {
+	if defaultFrom != "" && !smsgate.IsValidSenderID(defaultFrom) {
+		return nil, mrmailer.ErrInternalSMSSenderIDInvalid.New("from", defaultFrom)
+	}
+
+	if maxSegments < 1 {
+		maxSegments = defaultSMSMaxSegments
+	}
+
+	return &smsSender{
+		clientAPI:   clientAPI,
+		defaultFrom: defaultFrom,
+		maxSegments: maxSegments,
+	}, nil
+}
+
+// Send - отправляет указанное сообщение.
+// Ошибки SMS шлюза классифицируются по кодам статуса ответа шлюза (см. senderror.Classify).
+func (s *smsSender) Send(ctx context.Context, message entity.Message) error {
+	if message.Data.SMS == nil {
+		return errors.ErrInternalIncorrectInputData.WithDetails("message.Data.SMS is nil")
+	}
+
+	from := s.defaultFrom
+
+	if message.Data.SMS.From != "" {
+		if !smsgate.IsValidSenderID(message.Data.SMS.From) {
+			return mrmailer.ErrInternalSMSSenderIDInvalid.New("from", message.Data.SMS.From)
+		}
+
+		from = message.Data.SMS.From
+	}
+
+	phone, ok := smsgate.NormalizePhone(message.Data.SMS.Phone)
+	if !ok {
+		return mrmailer.ErrInternalSMSPhoneInvalid.New("phone", message.Data.SMS.Phone)
+	}
+
+	if segments := smsgate.CalcSegments(message.Data.SMS.Content); segments.Count < 1 || segments.Count > s.maxSegments {
+		return mrmailer.ErrInternalSMSContentInvalidLength.New(
+			"encoding", segments.Encoding.String(),
+			"units", segments.Units,
+			"segments", segments.Count,
+			"maxSegments", s.maxSegments,
+		)
+	}
+
+	err := s.clientAPI.SendSMS(ctx, from, phone, message.Data.SMS.Content)
+
+	return senderror.Classify(err, "messageId", message.ID)
+}
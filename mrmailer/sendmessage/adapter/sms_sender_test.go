@@ -0,0 +1,166 @@
+package adapter_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mondegor/go-core/errors/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/adapter"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
+)
+
+type fakeSMSGateway struct {
+	err   error
+	from  string
+	phone string
+	text  string
+	calls int
+}
+
+func (g *fakeSMSGateway) SendSMS(_ context.Context, from, phone, text string) error {
+	g.calls++
+	g.from, g.phone, g.text = from, phone, text
+
+	return g.err
+}
+
+func newSMSMessage(from, phone, content string) entity.Message {
+	return entity.Message{
+		ID:      1,
+		Channel: "sms",
+		Data: entity.MessageData{
+			SMS: &entity.DataSMS{From: from, Phone: phone, Content: content},
+		},
+	}
+}
+
+func TestNewSMSSender_InvalidDefaultFrom(t *testing.T) {
+	t.Parallel()
+
+	_, err := adapter.NewSMSSender(&fakeSMSGateway{}, "Too long sender name", 0)
+	require.ErrorIs(t, err, mrmailer.ErrInternalSMSSenderIDInvalid)
+}
+
+func TestSMSSender_Send(t *testing.T) {
+	t.Parallel()
+
+	gateway := &fakeSMSGateway{}
+
+	sender, err := adapter.NewSMSSender(gateway, "Shop", 0)
+	require.NoError(t, err)
+
+	require.NoError(t, sender.Send(context.Background(), newSMSMessage("", "+7 (900) 123-45-67", "Code: 1234")))
+	assert.Equal(t, "Shop", gateway.from)
+	assert.Equal(t, "+79001234567", gateway.phone)
+	assert.Equal(t, "Code: 1234", gateway.text)
+
+	require.NoError(t, sender.Send(context.Background(), newSMSMessage("Promo", "79001234567", "Sale")))
+	assert.Equal(t, "Promo", gateway.from)
+}
+
+func TestSMSSender_Send_InvalidMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message entity.Message
+		wantErr error
+	}{
+		{
+			name:    "nil data",
+			message: entity.Message{ID: 1, Channel: "sms"},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid sender",
+			message: newSMSMessage("Sender name is too long", "+79001234567", "text"),
+			wantErr: mrmailer.ErrInternalSMSSenderIDInvalid,
+		},
+		{
+			name:    "invalid phone",
+			message: newSMSMessage("", "12345", "text"),
+			wantErr: mrmailer.ErrInternalSMSPhoneInvalid,
+		},
+		{
+			name:    "empty content",
+			message: newSMSMessage("", "+79001234567", ""),
+			wantErr: mrmailer.ErrInternalSMSContentInvalidLength,
+		},
+		{
+			name:    "too many segments",
+			message: newSMSMessage("", "+79001234567", strings.Repeat("a", 161)),
+			wantErr: mrmailer.ErrInternalSMSContentInvalidLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gateway := &fakeSMSGateway{}
+
+			sender, err := adapter.NewSMSSender(gateway, "", 1)
+			require.NoError(t, err)
+
+			err = sender.Send(context.Background(), tt.message)
+			require.Error(t, err)
+			assert.NotEqual(t, kind.System, kind.Extract(err))
+			assert.Zero(t, gateway.calls)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSMSSender_Send_ErrorClassification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		err       error
+		temporary bool
+	}{
+		{name: "bad request", err: &senderror.StatusError{Status: http.StatusBadRequest}},
+		{name: "too many requests", err: &senderror.StatusError{Status: http.StatusTooManyRequests}, temporary: true},
+		{name: "forbidden with retry after", err: &senderror.StatusError{Status: http.StatusForbidden, RetryAfter: time.Minute}, temporary: true},
+		{name: "server error", err: &senderror.StatusError{Status: http.StatusBadGateway}, temporary: true},
+		{name: "network timeout", err: context.DeadlineExceeded, temporary: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sender, err := adapter.NewSMSSender(&fakeSMSGateway{err: tt.err}, "", 0)
+			require.NoError(t, err)
+
+			err = sender.Send(context.Background(), newSMSMessage("", "+79001234567", "text"))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.temporary, kind.Extract(err) == kind.System)
+
+			if tt.temporary {
+				assert.ErrorIs(t, err, mrmailer.ErrSystemProviderUnavailable)
+			} else {
+				assert.ErrorIs(t, err, mrmailer.ErrInternalProviderRejected)
+			}
+		})
+	}
+
+	unknownErr := errors.New("unknown")
+
+	sender, err := adapter.NewSMSSender(&fakeSMSGateway{err: unknownErr}, "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, unknownErr, sender.Send(context.Background(), newSMSMessage("", "+79001234567", "text")))
+}
@@ -0,0 +1,123 @@
+package capture
+
+import (
+	"bytes"
+	"mime"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailaddr"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailbody"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailheader"
+)
+
+const (
+	defaultMessageIDDomain = "capture.localhost"
+	defaultCharset         = "utf-8"
+)
+
+// RenderEML - формирует письмо в формате RFC 5322 (содержимое .eml файла).
+// В отличие от реальной отправки, получатели Bcc указываются в заголовке письма,
+// а вложения, заданные только ссылкой на внешнее хранилище, в письмо не включаются.
+func RenderEML(message entity.Message, date time.Time) ([]byte, error) {
+	data := message.Data.Mail
+	if data == nil {
+		return nil, errors.ErrInternalIncorrectInputData.WithDetails("message.Data.Mail is nil")
+	}
+
+	recipients, err := mailaddr.ParseRecipients(data.To, data.Cc, data.Bcc)
+	if err != nil {
+		return nil, err
+	}
+
+	customHeaders, err := mailheader.Normalize(data.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := mailbody.Build(
+		mailbody.Source{
+			ContentType: data.ContentType,
+			Content:     data.Content,
+			TextContent: data.TextContent,
+			Attachments: loadedAttachments(data.Attachments),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	writeHeader := func(name, value string) {
+		if value != "" {
+			buf.WriteString(name + ": " + value + "\r\n")
+		}
+	}
+
+	writeHeader("Date", date.Format(time.RFC1123Z))
+	writeHeader("From", formatAddress(data.From))
+	writeHeader("To", mailaddr.FormatList(recipients.To))
+	writeHeader("Cc", mailaddr.FormatList(recipients.Cc))
+	writeHeader("Bcc", mailaddr.FormatList(recipients.Bcc))
+	writeHeader("Reply-To", formatAddress(data.ReplyTo))
+	writeHeader("Subject", mime.QEncoding.Encode(defaultCharset, data.Subject))
+	writeHeader(mailheader.MessageID, mailheader.NewMessageID(message.ID, defaultMessageIDDomain))
+
+	names := make([]string, 0, len(customHeaders))
+
+	for name := range customHeaders {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		writeHeader(name, customHeaders[name])
+	}
+
+	writeHeader("MIME-Version", "1.0")
+	writeHeader("Content-Type", formatContentType(body.ContentType))
+
+	if !strings.HasPrefix(body.ContentType, "multipart/") {
+		writeHeader("Content-Transfer-Encoding", "8bit")
+	}
+
+	buf.WriteString("\r\n")
+	buf.WriteString(body.Content)
+
+	return buf.Bytes(), nil
+}
+
+func loadedAttachments(attachments []entity.MailAttachment) []entity.MailAttachment {
+	loaded := make([]entity.MailAttachment, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		if len(attachment.Content) > 0 {
+			loaded = append(loaded, attachment)
+		}
+	}
+
+	return loaded
+}
+
+func formatAddress(value string) string {
+	if addr, err := mail.ParseAddress(value); err == nil {
+		return addr.String()
+	}
+
+	return mime.QEncoding.Encode(defaultCharset, value)
+}
+
+func formatContentType(contentType string) string {
+	if strings.HasPrefix(contentType, "multipart/") || strings.Contains(strings.ToLower(contentType), "charset=") {
+		return contentType
+	}
+
+	return contentType + "; charset=" + defaultCharset
+}
@@ -0,0 +1,103 @@
+package capture
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	dirPermissions  = 0o755
+	filePermissions = 0o644
+
+	extEML  = ".eml"
+	extJSON = ".json"
+)
+
+type (
+	// FileSink - отправитель сообщений, который вместо отправки записывает их в файлы указанной директории:
+	// письма - в формате RFC 5322 (.eml), остальные сообщения - в формате JSON (.json).
+	// Имя файла формируется из времени записи и ID сообщения: 20260102T150405.000000000-42.eml.
+	FileSink struct {
+		dir     string
+		nowFunc func() time.Time
+	}
+)
+
+// NewFileSink - создаёт объект FileSink (директория создаётся при записи первого сообщения).
+func NewFileSink(dir string) *FileSink {
+	return &FileSink{
+		dir:     dir,
+		nowFunc: time.Now,
+	}
+}
+
+// Send - записывает сообщение в файл вместо его отправки.
+func (s *FileSink) Send(_ context.Context, message entity.Message) error {
+	now := s.nowFunc().UTC()
+
+	content, ext, err := s.render(message, now)
+	if err != nil {
+		return errors.WrapInternalError(err, "rendering captured message failed", "messageId", message.ID)
+	}
+
+	if err = os.MkdirAll(s.dir, dirPermissions); err != nil {
+		return errors.WrapInternalError(err, "creating capture dir failed", "dir", s.dir)
+	}
+
+	name := now.Format("20060102T150405.000000000") + "-" + strconv.FormatUint(message.ID, 10) + ext
+
+	if err = s.writeFile(name, content); err != nil {
+		return errors.WrapInternalError(err, "writing captured message failed", "dir", s.dir, "name", name)
+	}
+
+	return nil
+}
+
+func (s *FileSink) render(message entity.Message, now time.Time) (content []byte, ext string, err error) {
+	if message.Data.Mail != nil {
+		content, err = RenderEML(message, now)
+
+		return content, extEML, err
+	}
+
+	content, err = json.MarshalIndent(message, "", "  ")
+
+	return content, extJSON, err
+}
+
+// writeFile - записывает файл через временный файл, чтобы читатели директории
+// не увидели частично записанное сообщение.
+func (s *FileSink) writeFile(name string, content []byte) error {
+	tmp, err := os.CreateTemp(s.dir, "."+name+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err = tmp.Write(content); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Chmod(filePermissions); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, name))
+}
@@ -0,0 +1,64 @@
+package capture_test
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/capture"
+)
+
+func TestFileSink_Send(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "outbox")
+	sink := capture.NewFileSink(dir)
+	ctx := context.Background()
+
+	message := newMail(42, "email/SignUp", "Ivan <ivan@localhost>", "Добро пожаловать")
+	message.Data.Mail.Bcc = "boss@localhost"
+
+	require.NoError(t, sink.Send(ctx, message))
+	require.NoError(t, sink.Send(ctx, entity.Message{ID: 43, Channel: "sms/code", Data: entity.MessageData{SMS: &entity.DataSMS{Phone: "+79001234567", Content: "1234"}}}))
+
+	emlFiles, err := filepath.Glob(filepath.Join(dir, "*-42.eml"))
+	require.NoError(t, err)
+	require.Len(t, emlFiles, 1)
+
+	file, err := os.Open(emlFiles[0])
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	parsed, err := mail.ReadMessage(file)
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Добро пожаловать", subject)
+	assert.Equal(t, `"Ivan" <ivan@localhost>`, parsed.Header.Get("To"))
+	assert.Equal(t, "<boss@localhost>", parsed.Header.Get("Bcc"))
+	assert.True(t, strings.HasPrefix(parsed.Header.Get("Content-Type"), "text/plain; charset=utf-8"))
+
+	jsonFiles, err := filepath.Glob(filepath.Join(dir, "*-43.json"))
+	require.NoError(t, err)
+	require.Len(t, jsonFiles, 1)
+
+	content, err := os.ReadFile(jsonFiles[0])
+	require.NoError(t, err)
+
+	var captured entity.Message
+
+	require.NoError(t, json.Unmarshal(content, &captured))
+	assert.Equal(t, "+79001234567", captured.Data.SMS.Phone)
+}
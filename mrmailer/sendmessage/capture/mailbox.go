@@ -0,0 +1,137 @@
+package capture
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/service/delivery"
+)
+
+type (
+	// Mailbox - отправитель сообщений, который вместо отправки сохраняет их в памяти
+	// (используется при разработке и в тестах для проверки того, что было бы отправлено).
+	Mailbox struct {
+		mu       sync.Mutex
+		messages []entity.Message
+		notify   chan struct{}
+	}
+
+	// Filter - условия отбора сохранённых сообщений, пустые поля не учитываются.
+	Filter struct {
+		Recipient string // email (без учёта регистра), ID чата, номер телефона, URL веб-хука или токен устройства
+		Channel   string
+		Subject   string // тема письма (только для писем)
+	}
+)
+
+// NewMailbox - создаёт объект Mailbox.
+func NewMailbox() *Mailbox {
+	return &Mailbox{
+		notify: make(chan struct{}),
+	}
+}
+
+// Send - сохраняет сообщение вместо его отправки.
+func (m *Mailbox) Send(_ context.Context, message entity.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, message)
+
+	// оповещаются все, кто ожидает появления сообщений в WaitFor
+	close(m.notify)
+	m.notify = make(chan struct{})
+
+	return nil
+}
+
+// Messages - возвращает все сохранённые сообщения в порядке их отправки.
+func (m *Mailbox) Messages() []entity.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.messages)
+}
+
+// Find - возвращает сохранённые сообщения, удовлетворяющие условиям фильтра, в порядке их отправки.
+func (m *Mailbox) Find(filter Filter) []entity.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.find(filter)
+}
+
+// Count - возвращает кол-во сохранённых сообщений, удовлетворяющих условиям фильтра.
+func (m *Mailbox) Count(filter Filter) int {
+	return len(m.Find(filter))
+}
+
+// Last - возвращает последнее сохранённое сообщение, удовлетворяющее условиям фильтра.
+func (m *Mailbox) Last(filter Filter) (entity.Message, bool) {
+	found := m.Find(filter)
+	if len(found) == 0 {
+		return entity.Message{}, false
+	}
+
+	return found[len(found)-1], true
+}
+
+// WaitFor - ожидает появления сообщения, удовлетворяющего условиям фильтра, и возвращает первое из них
+// (используется в интеграционных тестах, где сообщения отправляются асинхронно).
+// Если сообщение не появилось до отмены контекста, то возвращается ошибка контекста.
+func (m *Mailbox) WaitFor(ctx context.Context, filter Filter) (entity.Message, error) {
+	for {
+		m.mu.Lock()
+		found := m.find(filter)
+		notify := m.notify
+		m.mu.Unlock()
+
+		if len(found) > 0 {
+			return found[0], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return entity.Message{}, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// Reset - удаляет все сохранённые сообщения.
+func (m *Mailbox) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+}
+
+func (m *Mailbox) find(filter Filter) []entity.Message {
+	found := make([]entity.Message, 0)
+
+	for _, message := range m.messages {
+		if filter.match(message) {
+			found = append(found, message)
+		}
+	}
+
+	return found
+}
+
+func (f Filter) match(message entity.Message) bool {
+	if f.Channel != "" && f.Channel != message.Channel {
+		return false
+	}
+
+	if f.Subject != "" && (message.Data.Mail == nil || message.Data.Mail.Subject != f.Subject) {
+		return false
+	}
+
+	if f.Recipient != "" {
+		return slices.Contains(delivery.Recipients(message.Data), delivery.NormalizeRecipient(f.Recipient))
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+package capture_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/capture"
+)
+
+func newMail(id uint64, channel, to, subject string) entity.Message {
+	return entity.Message{
+		ID:      id,
+		Channel: channel,
+		Data: entity.MessageData{
+			Mail: &entity.DataMail{
+				From:    "Shop <shop@localhost>",
+				To:      to,
+				Subject: subject,
+				Content: "Hello",
+			},
+		},
+	}
+}
+
+func TestMailbox_Find(t *testing.T) {
+	t.Parallel()
+
+	mailbox := capture.NewMailbox()
+	ctx := context.Background()
+
+	require.NoError(t, mailbox.Send(ctx, newMail(1, "email/SignUp", "Ivan@Localhost", "Welcome")))
+	require.NoError(t, mailbox.Send(ctx, newMail(2, "email/Order", "ivan@localhost, petr@localhost", "Order paid")))
+	require.NoError(t, mailbox.Send(ctx, entity.Message{ID: 3, Channel: "sms/code", Data: entity.MessageData{SMS: &entity.DataSMS{Phone: "+79001234567"}}}))
+
+	assert.Len(t, mailbox.Messages(), 3)
+	assert.Equal(t, 2, mailbox.Count(capture.Filter{Recipient: "IVAN@localhost"}))
+	assert.Equal(t, 1, mailbox.Count(capture.Filter{Recipient: "petr@localhost", Subject: "Order paid"}))
+	assert.Equal(t, 0, mailbox.Count(capture.Filter{Recipient: "petr@localhost", Channel: "email/SignUp"}))
+	assert.Equal(t, 1, mailbox.Count(capture.Filter{Recipient: "+79001234567"}))
+
+	last, ok := mailbox.Last(capture.Filter{Recipient: "ivan@localhost"})
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), last.ID)
+
+	mailbox.Reset()
+	assert.Empty(t, mailbox.Messages())
+}
+
+func TestMailbox_WaitFor(t *testing.T) {
+	t.Parallel()
+
+	mailbox := capture.NewMailbox()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = mailbox.Send(context.Background(), newMail(1, "email/SignUp", "ivan@localhost", "Welcome"))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	message, err := mailbox.WaitFor(ctx, capture.Filter{Subject: "Welcome"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), message.ID)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = mailbox.WaitFor(ctx, capture.Filter{Subject: "Unknown"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
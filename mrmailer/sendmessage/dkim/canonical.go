@@ -0,0 +1,84 @@
+package dkim
+
+import (
+	"strings"
+)
+
+// CanonicalHeader - возвращает заголовок письма, приведённый к каноническому виду
+// по алгоритму relaxed (RFC 6376, 3.4.2): имя в нижнем регистре, значение без переносов строк,
+// последовательности пробельных символов заменены одним пробелом, пробелы по краям удалены.
+// Результат возвращается без завершающего CRLF.
+func CanonicalHeader(name, value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "")
+	value = strings.ReplaceAll(value, "\n", "")
+
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strings.TrimSpace(compressSpaces(value))
+}
+
+// CanonicalBody - возвращает тело письма, приведённое к каноническому виду
+// по алгоритму relaxed (RFC 6376, 3.4.4): строки завершаются CRLF, последовательности
+// пробельных символов заменены одним пробелом, пробелы в конце строк и пустые строки
+// в конце тела удалены. Пустое тело остаётся пустым.
+func CanonicalBody(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	lines := strings.Split(body, "\n")
+
+	var buf strings.Builder
+
+	buf.Grow(len(body) + len(lines))
+
+	emptyLines := 0
+
+	for _, line := range lines {
+		line = strings.TrimRight(compressSpaces(line), " ")
+
+		if line == "" {
+			emptyLines++
+
+			continue
+		}
+
+		// пустые строки внутри тела сохраняются, в конце тела - отбрасываются
+		for ; emptyLines > 0; emptyLines-- {
+			buf.WriteString("\r\n")
+		}
+
+		buf.WriteString(line)
+		buf.WriteString("\r\n")
+	}
+
+	return buf.String()
+}
+
+func compressSpaces(value string) string {
+	if !strings.ContainsAny(value, "\t") && !strings.Contains(value, "  ") {
+		return value
+	}
+
+	var buf strings.Builder
+
+	buf.Grow(len(value))
+
+	isSpace := false
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == ' ' || value[i] == '\t' {
+			isSpace = true
+
+			continue
+		}
+
+		if isSpace {
+			buf.WriteByte(' ')
+			isSpace = false
+		}
+
+		buf.WriteByte(value[i])
+	}
+
+	if isSpace {
+		buf.WriteByte(' ')
+	}
+
+	return buf.String()
+}
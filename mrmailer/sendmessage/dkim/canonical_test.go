@@ -0,0 +1,25 @@
+package dkim_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/dkim"
+)
+
+// Примеры канонизации relaxed из RFC 6376, 3.4.5.
+func TestCanonicalHeader(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "a:X", dkim.CanonicalHeader("A", " X"))
+	assert.Equal(t, "b:Y Z", dkim.CanonicalHeader("B ", " Y\t\r\n\tZ  "))
+}
+
+func TestCanonicalBody(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, " C\r\nD E\r\n", dkim.CanonicalBody(" C \r\nD \t E\r\n\r\n\r\n"))
+	assert.Equal(t, "\r\nA\r\n\r\nB\r\n", dkim.CanonicalBody("\nA\n\nB"))
+	assert.Equal(t, "", dkim.CanonicalBody("\r\n\r\n"))
+}
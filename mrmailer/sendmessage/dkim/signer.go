@@ -0,0 +1,316 @@
+package dkim
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderName - название заголовка с подписью DKIM.
+	HeaderName = "DKIM-Signature"
+
+	algorithmRSA     = "rsa-sha256"
+	algorithmEd25519 = "ed25519-sha256"
+	canonicalization = "relaxed/relaxed"
+)
+
+var (
+	// ErrKeyInvalid - ключ подписи DKIM некорректен.
+	ErrKeyInvalid = errors.New("dkim key is invalid")
+
+	// ErrFromHeaderInvalid - в письме отсутствует, повторяется или некорректен заголовок From.
+	ErrFromHeaderInvalid = errors.New("dkim: header From is missing or invalid")
+
+	// ErrMessageInvalid - письмо не соответствует формату RFC 5322.
+	ErrMessageInvalid = errors.New("dkim: message is invalid")
+)
+
+//nolint:gochecknoglobals
+var (
+	// defaultSignedHeaders - заголовки письма, которые подписываются, если они присутствуют в письме.
+	defaultSignedHeaders = []string{
+		"From", "Reply-To", "Subject", "Date", "To", "Cc",
+		"Message-ID", "In-Reply-To", "References",
+		"MIME-Version", "Content-Type", "Content-Transfer-Encoding",
+		"List-Id", "List-Unsubscribe", "List-Unsubscribe-Post",
+	}
+)
+
+type (
+	// Key - ключ подписи DKIM домена отправителя.
+	// Публичная часть ключа должна быть опубликована в DNS записи <Selector>._domainkey.<Domain>.
+	Key struct {
+		Domain     string
+		Selector   string
+		PrivateKey crypto.Signer // *rsa.PrivateKey или ed25519.PrivateKey
+	}
+
+	// Signer - формирует подпись DKIM письма (RFC 6376, RFC 8463) с канонизацией relaxed/relaxed.
+	// Ключ подписи выбирается по домену из заголовка From (или по ближайшему родительскому домену).
+	// Заголовок From подписывается с запасом (oversigning): его имя указывается в теге h= на один раз больше,
+	// чем он встречается в письме, поэтому добавление в письмо ещё одного заголовка From нарушает подпись.
+	Signer struct {
+		keys          map[string]Key
+		signedHeaders []string
+	}
+
+	headerField struct {
+		name  string
+		value string // значение в исходном виде, вместе с переносами строк
+	}
+)
+
+// New - создаёт объект Signer.
+// Поддерживаются ключи RSA (не менее 1024 бит, алгоритм rsa-sha256) и Ed25519 (алгоритм ed25519-sha256).
+func New(keys []Key, opts ...Option) (*Signer, error) {
+	o := options{
+		signer: &Signer{
+			keys:          make(map[string]Key, len(keys)),
+			signedHeaders: defaultSignedHeaders,
+		},
+	}
+
+	for _, key := range keys {
+		domain := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(key.Domain), "."))
+
+		if domain == "" || key.Selector == "" {
+			return nil, fmt.Errorf("%w: domain or selector is empty", ErrKeyInvalid)
+		}
+
+		if _, err := algorithm(key.PrivateKey); err != nil {
+			return nil, fmt.Errorf("%w: domain %s", err, domain)
+		}
+
+		key.Domain = domain
+		o.signer.keys[domain] = key
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.signer, nil
+}
+
+// ParsePrivateKey - разбирает закрытый ключ DKIM в формате PEM (PKCS#1 для RSA, PKCS#8 для RSA и Ed25519).
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: PEM block is not found", ErrKeyInvalid)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyInvalid, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported key type %T", ErrKeyInvalid, key)
+	}
+
+	return signer, nil
+}
+
+// Sign - возвращает значение заголовка DKIM-Signature для письма, сформированного в формате RFC 5322
+// (заголовки, пустая строка, тело), которое передаётся почтовому серверу без изменений.
+// Заголовок подписи добавляется в начало письма, если для домена отправителя ключ не задан,
+// то возвращается пустая строка (письмо не подписывается).
+// Подписываются только присутствующие в письме заголовки из списка signedHeaders,
+// поэтому заголовки, добавляемые почтовым сервером позже, на подпись не влияют.
+func (s *Signer) Sign(message []byte) (string, error) {
+	fields, body, err := parseMessage(string(message))
+	if err != nil {
+		return "", err
+	}
+
+	from, err := lookupFrom(fields)
+	if err != nil {
+		return "", err
+	}
+
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrFromHeaderInvalid, err)
+	}
+
+	key, ok := s.lookupKey(addr.Address[strings.LastIndexByte(addr.Address, '@')+1:])
+	if !ok {
+		return "", nil
+	}
+
+	return s.sign(key, fields, body, time.Now())
+}
+
+func (s *Signer) sign(key Key, fields []headerField, body string, now time.Time) (string, error) {
+	alg, err := algorithm(key.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		names []string
+		data  strings.Builder
+	)
+
+	for _, name := range s.signedHeaders {
+		// повторяющиеся заголовки подписываются снизу вверх (RFC 6376, 5.4.2)
+		for i := len(fields) - 1; i >= 0; i-- {
+			if !strings.EqualFold(fields[i].name, name) {
+				continue
+			}
+
+			names = append(names, strings.ToLower(name))
+			data.WriteString(CanonicalHeader(fields[i].name, fields[i].value))
+			data.WriteString("\r\n")
+		}
+	}
+
+	// отсутствующий экземпляр заголовка From в подписываемые данные ничего не добавляет
+	names = append(names, "from")
+
+	bodyHash := sha256.Sum256([]byte(CanonicalBody(body)))
+
+	value := "v=1; a=" + alg +
+		"; c=" + canonicalization +
+		"; d=" + key.Domain +
+		"; s=" + key.Selector +
+		"; t=" + strconv.FormatInt(now.Unix(), 10) +
+		"; h=" + strings.Join(names, ":") +
+		"; bh=" + base64.StdEncoding.EncodeToString(bodyHash[:]) +
+		"; b="
+
+	// сам заголовок подписи участвует в подписи с пустым значением тега b= и без завершающего CRLF
+	data.WriteString(CanonicalHeader(HeaderName, value))
+
+	signature, err := signData(key.PrivateKey, []byte(data.String()))
+	if err != nil {
+		return "", err
+	}
+
+	return value + base64.StdEncoding.EncodeToString(signature), nil
+}
+
+func (s *Signer) lookupKey(domain string) (Key, bool) {
+	domain = strings.ToLower(domain)
+
+	for domain != "" {
+		if key, ok := s.keys[domain]; ok {
+			return key, true
+		}
+
+		pos := strings.IndexByte(domain, '.')
+		if pos < 0 {
+			break
+		}
+
+		domain = domain[pos+1:]
+	}
+
+	return Key{}, false
+}
+
+func algorithm(key crypto.Signer) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 1024 {
+			return "", fmt.Errorf("%w: RSA key must be at least 1024 bits", ErrKeyInvalid)
+		}
+
+		return algorithmRSA, nil
+	case ed25519.PrivateKey:
+		if len(k) != ed25519.PrivateKeySize {
+			return "", fmt.Errorf("%w: Ed25519 key size is invalid", ErrKeyInvalid)
+		}
+
+		return algorithmEd25519, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported key type %T", ErrKeyInvalid, key)
+	}
+}
+
+// signData - подписывает SHA-256 хэш данных: для RSA по схеме PKCS#1 v1.5,
+// для Ed25519 (RFC 8463) хэш подписывается как сообщение (PureEdDSA).
+func signData(key crypto.Signer, data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+
+	if k, ok := key.(ed25519.PrivateKey); ok {
+		return ed25519.Sign(k, hash[:]), nil
+	}
+
+	return key.Sign(rand.Reader, hash[:], crypto.SHA256)
+}
+
+// parseMessage - разбирает письмо на заголовки (с учётом их переноса на несколько строк) и тело.
+func parseMessage(message string) (fields []headerField, body string, err error) {
+	headerPart, body, ok := strings.Cut(message, "\r\n\r\n")
+	if !ok {
+		if !strings.HasPrefix(message, "\r\n") {
+			return nil, "", fmt.Errorf("%w: header and body separator is not found", ErrMessageInvalid)
+		}
+
+		headerPart, body = "", message[2:]
+	}
+
+	if headerPart == "" {
+		return nil, body, nil
+	}
+
+	for _, line := range strings.Split(headerPart, "\r\n") {
+		if line != "" && (line[0] == ' ' || line[0] == '\t') {
+			if len(fields) == 0 {
+				return nil, "", fmt.Errorf("%w: header starts with continuation line", ErrMessageInvalid)
+			}
+
+			fields[len(fields)-1].value += "\r\n" + line
+
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || name == "" {
+			return nil, "", fmt.Errorf("%w: header line %q", ErrMessageInvalid, line)
+		}
+
+		fields = append(fields, headerField{name: name, value: value})
+	}
+
+	return fields, body, nil
+}
+
+// lookupFrom - возвращает значение заголовка From, который должен присутствовать в письме ровно один раз.
+func lookupFrom(fields []headerField) (string, error) {
+	var (
+		value string
+		count int
+	)
+
+	for _, field := range fields {
+		if strings.EqualFold(field.name, "From") {
+			value = field.value
+			count++
+		}
+	}
+
+	if count != 1 {
+		return "", fmt.Errorf("%w: found %d headers", ErrFromHeaderInvalid, count)
+	}
+
+	return strings.TrimSpace(strings.ReplaceAll(value, "\r\n", "")), nil
+}
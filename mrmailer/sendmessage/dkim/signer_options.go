@@ -0,0 +1,29 @@
+package dkim
+
+import "strings"
+
+type (
+	// Option - настройка объекта Signer.
+	Option func(o *options)
+
+	options struct {
+		signer *Signer
+	}
+)
+
+// WithSignedHeaders - устанавливает список заголовков, которые подписываются, если они присутствуют в письме.
+// Заголовок From подписывается всегда (в том числе с запасом, см. Signer).
+func WithSignedHeaders(value ...string) Option {
+	return func(o *options) {
+		headers := make([]string, 0, len(value)+1)
+		headers = append(headers, "From")
+
+		for _, name := range value {
+			if !strings.EqualFold(name, "From") {
+				headers = append(headers, name)
+			}
+		}
+
+		o.signer.signedHeaders = headers
+	}
+}
@@ -0,0 +1,244 @@
+package dkim_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/dkim"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailbody"
+)
+
+// Тестовый пример из RFC 8463, Appendix A.
+const (
+	rfc8463Seed      = "nWGxne/9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A="
+	rfc8463PublicKey = "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="
+	rfc8463BodyHash  = "2jUSOH9NhtVGCQWNr9BrIAPreKQjO6Sn7XIkfJVOzv8="
+	rfc8463Body      = "Hi.\r\n\r\nWe lost the game.  Are you hungry yet?\r\n\r\nJoe.\r\n"
+	rfc8463Signature = "v=1; a=ed25519-sha256; c=relaxed/relaxed;\r\n" +
+		" d=football.example.com; i=@football.example.com;\r\n" +
+		" q=dns/txt; s=brisbane; t=1528637909; h=from : to :\r\n" +
+		" subject : date : message-id : from : subject : date;\r\n" +
+		" bh=2jUSOH9NhtVGCQWNr9BrIAPreKQjO6Sn7XIkfJVOzv8=;\r\n" +
+		" b=/gCrinpcQOoIfuHNQIbq4pgh9kyIK3AQUdt9OdqQehSwhEIug4D11Bus\r\n" +
+		" Fa3bT3FY5OsU7ZbnKELq+eXdp1Q1Dw=="
+)
+
+//nolint:gochecknoglobals
+var rfc8463Header = map[string]string{
+	"From":       "Joe SixPack <joe@football.example.com>",
+	"To":         "Suzie Q <suzie@shopping.example.net>",
+	"Subject":    "Is dinner ready?",
+	"Date":       "Fri, 11 Jul 2003 21:00:37 -0700 (PDT)",
+	"Message-ID": "<20030712040037.46341.5F8J@football.example.com>",
+}
+
+func TestCanonicalization_RFC8463Vector(t *testing.T) {
+	t.Parallel()
+
+	publicKey := rfc8463Key(t).Public().(ed25519.PublicKey)
+	assert.Equal(t, rfc8463PublicKey, base64.StdEncoding.EncodeToString(publicKey))
+
+	bodyHash := sha256.Sum256([]byte(dkim.CanonicalBody(rfc8463Body)))
+	assert.Equal(t, rfc8463BodyHash, base64.StdEncoding.EncodeToString(bodyHash[:]))
+
+	tags := parseTags(rfc8463Signature)
+	hash := signedDataHash(rfc8463Header, rfc8463Signature, tags["h"])
+	assert.True(t, ed25519.Verify(publicKey, hash, decodeBase64(t, tags["b"])))
+}
+
+func TestSigner_SignEd25519(t *testing.T) {
+	t.Parallel()
+
+	signer, err := dkim.New(
+		[]dkim.Key{
+			{Domain: "Example.COM", Selector: "brisbane", PrivateKey: rfc8463Key(t)},
+		},
+	)
+	require.NoError(t, err)
+
+	value, err := signer.Sign(mailbody.Compose(rfc8463Header, strings.ReplaceAll(rfc8463Body, "\r\n", "\n")+"\n\n"))
+	require.NoError(t, err)
+
+	tags := parseTags(value)
+	assert.Equal(t, "ed25519-sha256", tags["a"])
+	assert.Equal(t, "relaxed/relaxed", tags["c"])
+	assert.Equal(t, "example.com", tags["d"]) // ключ выбран по родительскому домену
+	assert.Equal(t, "brisbane", tags["s"])
+	assert.Equal(t, "from:subject:date:to:message-id:from", tags["h"]) // заголовок From подписан с запасом
+	assert.Equal(t, rfc8463BodyHash, tags["bh"])
+
+	hash := signedDataHash(rfc8463Header, value, tags["h"])
+	assert.True(t, ed25519.Verify(rfc8463Key(t).Public().(ed25519.PublicKey), hash, decodeBase64(t, tags["b"])))
+}
+
+func TestSigner_SignRSA(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+
+	privateKey, err := dkim.ParsePrivateKey(pemKey)
+	require.NoError(t, err)
+
+	signer, err := dkim.New([]dkim.Key{{Domain: "football.example.com", Selector: "test", PrivateKey: privateKey}})
+	require.NoError(t, err)
+
+	value, err := signer.Sign(mailbody.Compose(rfc8463Header, rfc8463Body))
+	require.NoError(t, err)
+
+	tags := parseTags(value)
+	assert.Equal(t, "rsa-sha256", tags["a"])
+	assert.Equal(t, "football.example.com", tags["d"])
+	assert.Equal(t, rfc8463BodyHash, tags["bh"])
+
+	hash := signedDataHash(rfc8463Header, value, tags["h"])
+	require.NoError(t, rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, hash, decodeBase64(t, tags["b"])))
+}
+
+func TestSigner_SignFoldedHeader(t *testing.T) {
+	t.Parallel()
+
+	signer, err := dkim.New([]dkim.Key{{Domain: "football.example.com", Selector: "brisbane", PrivateKey: rfc8463Key(t)}})
+	require.NoError(t, err)
+
+	message := "From: Joe SixPack <joe@football.example.com>\r\n" +
+		"Subject: Is dinner\r\n\tready?\r\n" +
+		"\r\n" + rfc8463Body
+
+	value, err := signer.Sign([]byte(message))
+	require.NoError(t, err)
+
+	tags := parseTags(value)
+	assert.Equal(t, "from:subject:from", tags["h"])
+
+	header := map[string]string{
+		"From":    "Joe SixPack <joe@football.example.com>",
+		"Subject": "Is dinner ready?",
+	}
+
+	hash := signedDataHash(header, value, tags["h"])
+	assert.True(t, ed25519.Verify(rfc8463Key(t).Public().(ed25519.PublicKey), hash, decodeBase64(t, tags["b"])))
+}
+
+func TestSigner_SignInvalidMessage(t *testing.T) {
+	t.Parallel()
+
+	signer, err := dkim.New([]dkim.Key{{Domain: "other.example.com", Selector: "s", PrivateKey: rfc8463Key(t)}})
+	require.NoError(t, err)
+
+	value, err := signer.Sign(mailbody.Compose(rfc8463Header, rfc8463Body))
+	require.NoError(t, err)
+	assert.Empty(t, value) // ключ для домена отправителя не задан
+
+	tests := []struct {
+		name    string
+		message string
+		wantErr error
+	}{
+		{
+			name:    "from is missing",
+			message: "To: suzie@shopping.example.net\r\n\r\n" + rfc8463Body,
+			wantErr: dkim.ErrFromHeaderInvalid,
+		},
+		{
+			name:    "from is repeated",
+			message: "From: joe@other.example.com\r\nFrom: joe@football.example.com\r\n\r\n" + rfc8463Body,
+			wantErr: dkim.ErrFromHeaderInvalid,
+		},
+		{
+			name:    "separator is missing",
+			message: "From: joe@other.example.com\r\n",
+			wantErr: dkim.ErrMessageInvalid,
+		},
+		{
+			name:    "header line is invalid",
+			message: "From: joe@other.example.com\r\nSubject\r\n\r\n" + rfc8463Body,
+			wantErr: dkim.ErrMessageInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := signer.Sign([]byte(tt.message))
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNew_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := dkim.New([]dkim.Key{{Domain: "example.com", PrivateKey: rfc8463Key(t)}})
+	require.ErrorIs(t, err, dkim.ErrKeyInvalid)
+
+	_, err = dkim.New([]dkim.Key{{Domain: "example.com", Selector: "s"}})
+	require.ErrorIs(t, err, dkim.ErrKeyInvalid)
+}
+
+func rfc8463Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	return ed25519.NewKeyFromSeed(decodeBase64(t, rfc8463Seed))
+}
+
+// signedDataHash - формирует хэш подписываемых данных так, как это делает проверяющая сторона.
+func signedDataHash(header map[string]string, signature, signedNames string) []byte {
+	var data strings.Builder
+
+	canonical := make(map[string]string, len(header))
+
+	for name, value := range header {
+		canonical[strings.ToLower(name)] = dkim.CanonicalHeader(name, value)
+	}
+
+	for _, name := range strings.Split(signedNames, ":") {
+		if value, ok := canonical[strings.TrimSpace(name)]; ok {
+			data.WriteString(value + "\r\n")
+			delete(canonical, strings.TrimSpace(name)) // повторно указанный заголовок отсутствует в письме
+		}
+	}
+
+	// значение тега b= удаляется, остальная часть заголовка подписи сохраняется как есть
+	pos := strings.LastIndex(signature, "b=")
+	data.WriteString(dkim.CanonicalHeader(dkim.HeaderName, signature[:pos+2]))
+
+	hash := sha256.Sum256([]byte(data.String()))
+
+	return hash[:]
+}
+
+func parseTags(value string) map[string]string {
+	tags := make(map[string]string)
+
+	for _, item := range strings.Split(value, ";") {
+		name, val, _ := strings.Cut(item, "=")
+		val = strings.NewReplacer("\r\n", "", " ", "", "\t", "").Replace(val)
+		tags[strings.TrimSpace(name)] = val
+	}
+
+	return tags
+}
+
+func decodeBase64(t *testing.T, value string) []byte {
+	t.Helper()
+
+	data, err := base64.StdEncoding.DecodeString(value)
+	require.NoError(t, err)
+
+	return data
+}
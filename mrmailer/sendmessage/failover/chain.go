@@ -0,0 +1,206 @@
+package failover
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+	"github.com/mondegor/go-core/errors/kind"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	defaultFailureThreshold = 3
+	defaultCooldown         = 30 * time.Second
+)
+
+type (
+	// Provider - именованный провайдер отправки сообщений, входящий в цепочку.
+	Provider struct {
+		Name   string
+		Sender mrmailer.MessageSender
+	}
+
+	// Chain - упорядоченная цепочка провайдеров отправки сообщений одного типа.
+	// Сообщение отправляется первым доступным провайдером, а при временной ошибке
+	// в рамках той же попытки передаётся следующему провайдеру цепочки.
+	// Провайдер, несколько раз подряд вернувший временную ошибку, считается недоступным
+	// на время cooldown и используется только после всех доступных провайдеров.
+	// Имя провайдера, выбранного для отправки, записывается в mrmailer.DeliveryReceipt контекста
+	// (если он был в него помещён), а имя провайдера, успешно отправившего сообщение, - также
+	// в заголовок mrmailer.HeaderProvider (если указано хранилище HeaderStorage).
+	Chain struct {
+		members          []*member
+		headerStorage    HeaderStorage // OPTIONAL
+		isTemporary      func(err error) bool
+		errorFunc        func(ctx context.Context, err error)
+		nowFunc          func() time.Time
+		failureThreshold int
+		cooldown         time.Duration
+	}
+
+	// HeaderStorage - хранилище заголовков сообщений (например, repository.MessagePostgres).
+	HeaderStorage interface {
+		UpdateHeader(ctx context.Context, rowID uint64, name, value string) error
+	}
+
+	// member - провайдер цепочки и состояние его доступности.
+	member struct {
+		provider Provider
+
+		mu             sync.Mutex
+		failures       int
+		unhealthyUntil time.Time
+	}
+)
+
+// New - создаёт объект Chain.
+func New(providers []Provider, opts ...Option) *Chain {
+	o := options{
+		chain: &Chain{
+			members:          make([]*member, 0, len(providers)),
+			isTemporary:      IsTemporary,
+			errorFunc:        func(_ context.Context, _ error) {},
+			nowFunc:          time.Now,
+			failureThreshold: defaultFailureThreshold,
+			cooldown:         defaultCooldown,
+		},
+	}
+
+	for _, provider := range providers {
+		if provider.Sender != nil {
+			o.chain.members = append(o.chain.members, &member{provider: provider})
+		}
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.chain.failureThreshold < 1 {
+		o.chain.failureThreshold = 1
+	}
+
+	return o.chain
+}
+
+// IsTemporary - сообщает, является ли ошибка отправки временной (при которой
+// имеет смысл передать сообщение следующему провайдеру): системные ошибки и истечение таймаута.
+// Остальные ошибки считаются окончательными, т.к. связаны с самим сообщением.
+func IsTemporary(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || kind.Extract(err) == kind.System
+}
+
+// Send - отправляет сообщение через провайдеров цепочки.
+// При окончательной ошибке отправка прекращается, при временной ошибке сообщение
+// передаётся следующему провайдеру, если все провайдеры вернули временную ошибку,
+// то возвращается ошибка последнего из них.
+// Имя провайдера сохраняется в заголовке сообщения только после успешной отправки, в рамках
+// транзакции фиксации результата обработки сообщения, в которой вызывается Send (см. consume.MessageConsumer),
+// поэтому при ошибке отправки изменение заголовка откатывается вместе с ней.
+func (c *Chain) Send(ctx context.Context, message entity.Message) error {
+	if len(c.members) == 0 {
+		return mrmailer.ErrInternalProviderClientNotSpecified.New("type", "failover")
+	}
+
+	var lastErr error
+
+	for _, m := range c.orderedMembers() {
+		// при отмене запроса переход к следующему провайдеру не имеет смысла
+		if lastErr != nil && ctx.Err() != nil {
+			break
+		}
+
+		mrmailer.SetDeliveryProvider(ctx, m.provider.Name)
+
+		err := m.provider.Sender.Send(ctx, c.withProvider(message, m.provider.Name))
+		if err == nil {
+			m.markSuccess()
+			c.recordProvider(ctx, message.ID, m.provider.Name)
+
+			return nil
+		}
+
+		lastErr = err
+
+		if !c.isTemporary(err) {
+			break
+		}
+
+		m.markFailure(c.nowFunc(), c.failureThreshold, c.cooldown)
+	}
+
+	return lastErr
+}
+
+// orderedMembers - возвращает провайдеров в порядке их использования:
+// сначала доступные, затем недоступные (в исходном порядке внутри каждой группы).
+func (c *Chain) orderedMembers() []*member {
+	now := c.nowFunc()
+	ordered := make([]*member, 0, len(c.members))
+
+	var unhealthy []*member
+
+	for _, m := range c.members {
+		if m.isHealthy(now) {
+			ordered = append(ordered, m)
+		} else {
+			unhealthy = append(unhealthy, m)
+		}
+	}
+
+	return append(ordered, unhealthy...)
+}
+
+func (c *Chain) withProvider(message entity.Message, name string) entity.Message {
+	header := make(map[string]string, len(message.Data.Header)+1)
+	maps.Copy(header, message.Data.Header)
+	header[mrmailer.HeaderProvider] = name
+
+	message.Data.Header = header
+
+	return message
+}
+
+// recordProvider - сохраняет имя провайдера, успешно отправившего сообщение, в заголовках сообщения,
+// ошибка сохранения не влияет на результат отправки и передаётся в errorFunc.
+func (c *Chain) recordProvider(ctx context.Context, messageID uint64, name string) {
+	if c.headerStorage == nil || messageID == 0 {
+		return
+	}
+
+	if err := c.headerStorage.UpdateHeader(ctx, messageID, mrmailer.HeaderProvider, name); err != nil {
+		c.errorFunc(ctx, errors.WrapInternalError(err, "recording message provider failed", "messageId", messageID, "provider", name))
+	}
+}
+
+func (m *member) isHealthy(now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return !now.Before(m.unhealthyUntil)
+}
+
+func (m *member) markSuccess() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failures = 0
+	m.unhealthyUntil = time.Time{}
+}
+
+func (m *member) markFailure(now time.Time, threshold int, cooldown time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failures++
+
+	if m.failures >= threshold {
+		m.failures = 0
+		m.unhealthyUntil = now.Add(cooldown)
+	}
+}
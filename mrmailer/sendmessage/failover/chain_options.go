@@ -0,0 +1,58 @@
+package failover
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Option - настройка объекта Chain.
+	Option func(o *options)
+
+	options struct {
+		chain *Chain
+	}
+)
+
+// WithFailureThreshold - устанавливает кол-во временных ошибок подряд,
+// после которого провайдер считается недоступным.
+func WithFailureThreshold(value int) Option {
+	return func(o *options) {
+		o.chain.failureThreshold = value
+	}
+}
+
+// WithCooldown - устанавливает время, в течение которого недоступный провайдер
+// используется только после всех доступных провайдеров цепочки.
+func WithCooldown(value time.Duration) Option {
+	return func(o *options) {
+		o.chain.cooldown = value
+	}
+}
+
+// WithErrorClassifier - устанавливает функцию, определяющую является ли ошибка отправки временной
+// (по умолчанию используется IsTemporary).
+func WithErrorClassifier(value func(err error) bool) Option {
+	return func(o *options) {
+		if value != nil {
+			o.chain.isTemporary = value
+		}
+	}
+}
+
+// WithHeaderStorage - устанавливает хранилище, в котором в заголовках сообщения
+// сохраняется имя провайдера, успешно отправившего сообщение (например, repository.MessagePostgres).
+func WithHeaderStorage(value HeaderStorage) Option {
+	return func(o *options) {
+		o.chain.headerStorage = value
+	}
+}
+
+// WithErrorFunc - устанавливает функцию обработки ошибок сохранения имени провайдера.
+func WithErrorFunc(value func(ctx context.Context, err error)) Option {
+	return func(o *options) {
+		if value != nil {
+			o.chain.errorFunc = value
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package failover_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/failover"
+)
+
+var (
+	errTemporary = errors.NewSystemProto("temporary error").New()
+	errPermanent = errors.NewInternalProto("permanent error").New()
+)
+
+type (
+	fakeSender struct {
+		errs      []error
+		calls     int
+		providers []string
+	}
+
+	fakeHeaderStorage struct {
+		values map[uint64]string
+	}
+)
+
+func (s *fakeSender) Send(_ context.Context, message entity.Message) error {
+	s.calls++
+	s.providers = append(s.providers, message.Data.Header[mrmailer.HeaderProvider])
+
+	if len(s.errs) == 0 {
+		return nil
+	}
+
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+
+	return err
+}
+
+func (s *fakeHeaderStorage) UpdateHeader(_ context.Context, rowID uint64, name, value string) error {
+	if name == mrmailer.HeaderProvider {
+		s.values[rowID] = value
+	}
+
+	return nil
+}
+
+func TestChain_FailoverOnTemporaryError(t *testing.T) {
+	t.Parallel()
+
+	primary := &fakeSender{errs: []error{errTemporary}}
+	secondary := &fakeSender{}
+	storage := &fakeHeaderStorage{values: make(map[uint64]string)}
+
+	chain := failover.New(
+		[]failover.Provider{
+			{Name: "primary", Sender: primary},
+			{Name: "secondary", Sender: secondary},
+		},
+		failover.WithHeaderStorage(storage),
+	)
+
+	message := entity.Message{ID: 7, Data: entity.MessageData{Header: map[string]string{"lang": "en"}}}
+
+	require.NoError(t, chain.Send(context.Background(), message))
+	assert.Equal(t, []string{"primary"}, primary.providers)
+	assert.Equal(t, []string{"secondary"}, secondary.providers)
+	assert.Equal(t, map[uint64]string{7: "secondary"}, storage.values)
+	assert.Equal(t, map[string]string{"lang": "en"}, message.Data.Header) // исходный заголовок не меняется
+}
+
+func TestChain_StopOnPermanentError(t *testing.T) {
+	t.Parallel()
+
+	primary := &fakeSender{errs: []error{errPermanent}}
+	secondary := &fakeSender{}
+
+	chain := failover.New(
+		[]failover.Provider{
+			{Name: "primary", Sender: primary},
+			{Name: "secondary", Sender: secondary},
+		},
+	)
+
+	err := chain.Send(context.Background(), entity.Message{ID: 1})
+	require.ErrorIs(t, err, errPermanent)
+	assert.Equal(t, 0, secondary.calls)
+}
+
+func TestChain_AllProvidersFailed(t *testing.T) {
+	t.Parallel()
+
+	primary := &fakeSender{errs: []error{errTemporary}}
+	secondary := &fakeSender{errs: []error{context.DeadlineExceeded}}
+
+	chain := failover.New(
+		[]failover.Provider{
+			{Name: "primary", Sender: primary},
+			{Name: "secondary", Sender: secondary},
+		},
+	)
+
+	err := chain.Send(context.Background(), entity.Message{ID: 1})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, primary.calls)
+	assert.Equal(t, 1, secondary.calls)
+}
+
+func TestChain_ProviderIsRecordedOnlyOnSuccess(t *testing.T) {
+	t.Parallel()
+
+	storage := &fakeHeaderStorage{values: make(map[uint64]string)}
+
+	chain := failover.New(
+		[]failover.Provider{
+			{Name: "primary", Sender: &fakeSender{errs: []error{errTemporary}}},
+			{Name: "secondary", Sender: &fakeSender{errs: []error{errPermanent}}},
+		},
+		failover.WithHeaderStorage(storage),
+	)
+
+	ctx, receipt := mrmailer.WithDeliveryReceipt(context.Background())
+
+	require.ErrorIs(t, chain.Send(ctx, entity.Message{ID: 1}), errPermanent)
+	assert.Empty(t, storage.values)
+	assert.Equal(t, "secondary", receipt.Provider())
+}
+
+func TestChain_UnhealthyProviderIsUsedLast(t *testing.T) {
+	t.Parallel()
+
+	primary := &fakeSender{errs: []error{errTemporary, errTemporary}}
+	secondary := &fakeSender{}
+
+	chain := failover.New(
+		[]failover.Provider{
+			{Name: "primary", Sender: primary},
+			{Name: "secondary", Sender: secondary},
+		},
+		failover.WithFailureThreshold(2),
+		failover.WithCooldown(time.Hour),
+	)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, chain.Send(context.Background(), entity.Message{ID: uint64(i + 1)}))
+	}
+
+	// после двух ошибок подряд основной провайдер пропускается до истечения cooldown
+	assert.Equal(t, 2, primary.calls)
+	assert.Equal(t, 3, secondary.calls)
+}
+
+func TestChain_WithoutProviders(t *testing.T) {
+	t.Parallel()
+
+	err := failover.New(nil).Send(context.Background(), entity.Message{ID: 1})
+	require.ErrorIs(t, err, mrmailer.ErrInternalProviderClientNotSpecified)
+}
@@ -0,0 +1,97 @@
+package mailaddr
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+)
+
+var (
+	// ErrRecipientsToIsEmpty - не указан ни один основной получатель письма.
+	ErrRecipientsToIsEmpty = errors.New("recipients 'to' is empty")
+)
+
+type (
+	// Recipients - получатели письма, разобранные из списков адресов To, Cc, Bcc.
+	Recipients struct {
+		To  []*mail.Address
+		Cc  []*mail.Address
+		Bcc []*mail.Address
+	}
+)
+
+// ParseRecipients - разбирает списки адресов получателей письма.
+// Каждый список указывается в формате RFC 5322: адреса через запятую,
+// где каждый адрес может быть как в виде email, так и в виде name <email>.
+// Список to обязателен для заполнения, списки cc и bcc могут быть пустыми.
+func ParseRecipients(to, cc, bcc string) (Recipients, error) {
+	var (
+		r   Recipients
+		err error
+	)
+
+	if r.To, err = ParseList(to); err != nil {
+		return Recipients{}, err
+	}
+
+	if len(r.To) == 0 {
+		return Recipients{}, ErrRecipientsToIsEmpty
+	}
+
+	if r.Cc, err = ParseList(cc); err != nil {
+		return Recipients{}, err
+	}
+
+	if r.Bcc, err = ParseList(bcc); err != nil {
+		return Recipients{}, err
+	}
+
+	return r, nil
+}
+
+// ParseList - разбирает список адресов в формате RFC 5322, пустой список не является ошибкой.
+func ParseList(value string) ([]*mail.Address, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	return mail.ParseAddressList(value)
+}
+
+// FormatList - формирует значение заголовка письма из списка адресов.
+func FormatList(list []*mail.Address) string {
+	values := make([]string, len(list))
+
+	for i, addr := range list {
+		values[i] = addr.String()
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// Count - возвращает общее кол-во получателей письма (без учёта повторов).
+func (r Recipients) Count() int {
+	return len(r.Envelope())
+}
+
+// Envelope - возвращает адреса получателей письма для SMTP конверта (RCPT TO)
+// в порядке To, Cc, Bcc без повторов (адреса сравниваются без учёта регистра).
+func (r Recipients) Envelope() []string {
+	envelope := make([]string, 0, len(r.To)+len(r.Cc)+len(r.Bcc))
+	exists := make(map[string]struct{}, cap(envelope))
+
+	for _, list := range [][]*mail.Address{r.To, r.Cc, r.Bcc} {
+		for _, addr := range list {
+			key := strings.ToLower(addr.Address)
+
+			if _, ok := exists[key]; ok {
+				continue
+			}
+
+			exists[key] = struct{}{}
+			envelope = append(envelope, addr.Address)
+		}
+	}
+
+	return envelope
+}
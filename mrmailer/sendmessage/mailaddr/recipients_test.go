@@ -0,0 +1,39 @@
+package mailaddr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailaddr"
+)
+
+func TestParseRecipients(t *testing.T) {
+	t.Parallel()
+
+	r, err := mailaddr.ParseRecipients(
+		"Ivan Ivanov <ivan@localhost>, petr@localhost",
+		"IVAN@localhost, anna@localhost",
+		"boss@localhost",
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, `"Ivan Ivanov" <ivan@localhost>, <petr@localhost>`, mailaddr.FormatList(r.To))
+	assert.Equal(t, "<IVAN@localhost>, <anna@localhost>", mailaddr.FormatList(r.Cc))
+	assert.Equal(t, []string{"ivan@localhost", "petr@localhost", "anna@localhost", "boss@localhost"}, r.Envelope())
+	assert.Equal(t, 4, r.Count())
+}
+
+func TestParseRecipients_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := mailaddr.ParseRecipients(" ", "cc@localhost", "")
+	require.ErrorIs(t, err, mailaddr.ErrRecipientsToIsEmpty)
+
+	_, err = mailaddr.ParseRecipients("to@localhost", "not-an-address", "")
+	require.Error(t, err)
+
+	_, err = mailaddr.ParseRecipients("to@localhost", "", "bcc@")
+	require.Error(t, err)
+}
@@ -0,0 +1,275 @@
+package mailbody
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	defaultContentType = "text/plain"
+	defaultCharset     = "utf-8"
+	base64LineLen      = 76
+)
+
+type (
+	// Source - исходные данные для формирования тела письма.
+	Source struct {
+		ContentType string
+		Content     string
+		TextContent string // текстовая версия письма, используется только если Content в формате HTML
+		Attachments []entity.MailAttachment
+	}
+
+	// Body - сформированное тело письма и его тип содержимого (для заголовка Content-Type).
+	Body struct {
+		ContentType string
+		Content     string
+	}
+
+	// part - часть письма в формате MIME.
+	part struct {
+		header  textproto.MIMEHeader
+		content []byte
+	}
+)
+
+// Build - формирует тело письма с учётом текстовой версии и вложений:
+//   - без текстовой версии и вложений тело возвращается без изменений;
+//   - HTML тело и его текстовая версия объединяются в multipart/alternative;
+//   - встроенные вложения (с ContentID) объединяются с HTML телом в multipart/related;
+//   - обычные вложения добавляются к телу в multipart/mixed.
+//
+// Содержимое всех вложений к этому моменту должно быть загружено в Content.
+func Build(src Source) (Body, error) {
+	contentType := src.ContentType
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	textContent := src.TextContent
+	if !IsHTML(contentType) {
+		textContent = ""
+	}
+
+	if textContent == "" && len(src.Attachments) == 0 {
+		return Body{
+			ContentType: contentType,
+			Content:     src.Content,
+		}, nil
+	}
+
+	inline, regular := splitAttachments(src.Attachments)
+
+	root, err := buildText(contentType, src.Content)
+	if err != nil {
+		return Body{}, err
+	}
+
+	if len(inline) > 0 {
+		if root, err = buildMultipart("related", root, attachmentParts(inline)...); err != nil {
+			return Body{}, err
+		}
+	}
+
+	if textContent != "" {
+		text, err := buildText(defaultContentType, textContent)
+		if err != nil {
+			return Body{}, err
+		}
+
+		// согласно RFC 2046 наиболее предпочтительная версия указывается последней
+		if root, err = buildMultipart("alternative", text, root); err != nil {
+			return Body{}, err
+		}
+	}
+
+	if len(regular) > 0 {
+		if root, err = buildMultipart("mixed", root, attachmentParts(regular)...); err != nil {
+			return Body{}, err
+		}
+	}
+
+	// у корневой части используется только заголовок Content-Type,
+	// т.к. она является multipart и не требует указания Content-Transfer-Encoding
+	return Body{
+		ContentType: root.header.Get("Content-Type"),
+		Content:     string(root.content),
+	}, nil
+}
+
+// IsHTML - сообщает, является ли указанный тип содержимого HTML.
+func IsHTML(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "text/html"
+}
+
+// buildText - формирует текстовую часть письма в кодировке quoted-printable.
+func buildText(contentType, content string) (part, error) {
+	if !strings.Contains(strings.ToLower(contentType), "charset=") {
+		contentType += "; charset=" + defaultCharset
+	}
+
+	var buf bytes.Buffer
+
+	w := quotedprintable.NewWriter(&buf)
+
+	if _, err := io.WriteString(w, content); err != nil {
+		return part{}, err
+	}
+
+	if err := w.Close(); err != nil {
+		return part{}, err
+	}
+
+	return part{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		content: buf.Bytes(),
+	}, nil
+}
+
+// buildMultipart - объединяет указанные части письма в multipart указанного подтипа.
+func buildMultipart(subtype string, first part, others ...part) (part, error) {
+	var buf bytes.Buffer
+
+	w := multipart.NewWriter(&buf)
+
+	if err := writePart(w, first); err != nil {
+		return part{}, err
+	}
+
+	for _, p := range others {
+		if err := writePart(w, p); err != nil {
+			return part{}, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return part{}, err
+	}
+
+	params := map[string]string{
+		"boundary": w.Boundary(),
+	}
+
+	// для multipart/related указывается тип корневой части (RFC 2387)
+	if subtype == "related" {
+		if mediaType, _, err := mime.ParseMediaType(first.header.Get("Content-Type")); err == nil {
+			params["type"] = mediaType
+		}
+	}
+
+	return part{
+		header: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType("multipart/"+subtype, params)},
+		},
+		content: buf.Bytes(),
+	}, nil
+}
+
+func attachmentParts(attachments []entity.MailAttachment) []part {
+	parts := make([]part, len(attachments))
+
+	for i, attachment := range attachments {
+		parts[i] = attachmentPart(attachment)
+	}
+
+	return parts
+}
+
+func attachmentPart(attachment entity.MailAttachment) part {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	disposition := "attachment"
+
+	header := textproto.MIMEHeader{
+		"Content-Transfer-Encoding": {"base64"},
+	}
+
+	if attachment.ContentID != "" {
+		disposition = "inline"
+		header.Set("Content-ID", "<"+strings.Trim(attachment.ContentID, "<>")+">")
+	}
+
+	if attachment.Name != "" {
+		header.Set("Content-Type", formatMediaType(contentType, "name", attachment.Name))
+		header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Name}))
+	} else {
+		header.Set("Content-Type", contentType)
+		header.Set("Content-Disposition", disposition)
+	}
+
+	return part{
+		header:  header,
+		content: encodeBase64(attachment.Content),
+	}
+}
+
+// formatMediaType - добавляет параметр к типу содержимого, если это возможно.
+func formatMediaType(contentType, key, value string) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+
+	params[key] = value
+
+	if formatted := mime.FormatMediaType(mediaType, params); formatted != "" {
+		return formatted
+	}
+
+	return contentType
+}
+
+func encodeBase64(data []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	buf := make([]byte, 0, len(encoded)+len(encoded)/base64LineLen*2+2)
+
+	for len(encoded) > base64LineLen {
+		buf = append(buf, encoded[:base64LineLen]...)
+		buf = append(buf, '\r', '\n')
+		encoded = encoded[base64LineLen:]
+	}
+
+	return append(buf, encoded...)
+}
+
+func writePart(w *multipart.Writer, p part) error {
+	pw, err := w.CreatePart(p.header)
+	if err != nil {
+		return err
+	}
+
+	_, err = pw.Write(p.content)
+
+	return err
+}
+
+func splitAttachments(attachments []entity.MailAttachment) (inline, regular []entity.MailAttachment) {
+	for _, attachment := range attachments {
+		if attachment.ContentID != "" {
+			inline = append(inline, attachment)
+		} else {
+			regular = append(regular, attachment)
+		}
+	}
+
+	return inline, regular
+}
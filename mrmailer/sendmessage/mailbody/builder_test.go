@@ -0,0 +1,157 @@
+package mailbody_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailbody"
+)
+
+type testPart struct {
+	header  textproto.MIMEHeader
+	content string
+}
+
+func TestBuild_WithoutAttachments(t *testing.T) {
+	t.Parallel()
+
+	body, err := mailbody.Build(mailbody.Source{ContentType: "text/html", Content: "<p>text</p>"})
+	require.NoError(t, err)
+
+	assert.Equal(t, mailbody.Body{ContentType: "text/html", Content: "<p>text</p>"}, body)
+}
+
+func TestBuild_TextContentIgnoredForPlainText(t *testing.T) {
+	t.Parallel()
+
+	body, err := mailbody.Build(mailbody.Source{ContentType: "text/plain", Content: "text", TextContent: "other"})
+	require.NoError(t, err)
+
+	assert.Equal(t, mailbody.Body{ContentType: "text/plain", Content: "text"}, body)
+}
+
+func TestBuild_Alternative(t *testing.T) {
+	t.Parallel()
+
+	body, err := mailbody.Build(
+		mailbody.Source{
+			ContentType: "text/html",
+			Content:     "<p>Привет</p>",
+			TextContent: "Привет",
+		},
+	)
+	require.NoError(t, err)
+
+	alternative := readMultipart(t, body.ContentType, body.Content, "multipart/alternative")
+	require.Len(t, alternative, 2)
+
+	assert.Equal(t, "text/plain; charset=utf-8", alternative[0].header.Get("Content-Type"))
+	assert.Equal(t, "Привет", alternative[0].content)
+	assert.Equal(t, "text/html; charset=utf-8", alternative[1].header.Get("Content-Type"))
+	assert.Equal(t, "<p>Привет</p>", alternative[1].content)
+}
+
+func TestBuild_MixedWithAlternativeAndRelated(t *testing.T) {
+	t.Parallel()
+
+	body, err := mailbody.Build(
+		mailbody.Source{
+			ContentType: "text/html",
+			Content:     `<img src="cid:logo">`,
+			TextContent: "logo",
+			Attachments: []entity.MailAttachment{
+				{Name: "logo.png", ContentType: "image/png", ContentID: "logo", Content: []byte("PNG")},
+				{Name: "doc.txt", ContentType: "text/plain", Content: []byte("doc")},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	mixed := readMultipart(t, body.ContentType, body.Content, "multipart/mixed")
+	require.Len(t, mixed, 2)
+
+	alternative := readMultipart(t, mixed[0].header.Get("Content-Type"), mixed[0].content, "multipart/alternative")
+	require.Len(t, alternative, 2)
+	assert.Equal(t, "logo", alternative[0].content)
+
+	related := readMultipart(t, alternative[1].header.Get("Content-Type"), alternative[1].content, "multipart/related")
+	require.Len(t, related, 2)
+	assert.Equal(t, `<img src="cid:logo">`, related[0].content)
+	assert.Equal(t, "<logo>", related[1].header.Get("Content-Id"))
+}
+
+func TestBuild_MixedWithRelated(t *testing.T) {
+	t.Parallel()
+
+	body, err := mailbody.Build(
+		mailbody.Source{
+			ContentType: "text/html",
+			Content:     `<p>Счёт <img src="cid:logo"></p>`,
+			Attachments: []entity.MailAttachment{
+				{Name: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+				{Name: "logo.png", ContentType: "image/png", ContentID: "logo", Content: []byte("PNG")},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	mixed := readMultipart(t, body.ContentType, body.Content, "multipart/mixed")
+	require.Len(t, mixed, 2)
+
+	assert.Equal(t, "attachment; filename=invoice.pdf", mixed[1].header.Get("Content-Disposition"))
+	assert.Equal(t, "%PDF-1.4", decodeBase64(t, mixed[1].content))
+
+	related := readMultipart(t, mixed[0].header.Get("Content-Type"), mixed[0].content, "multipart/related")
+	require.Len(t, related, 2)
+
+	// quoted-printable декодируется автоматически при чтении части
+	assert.Equal(t, `<p>Счёт <img src="cid:logo"></p>`, related[0].content)
+	assert.Equal(t, "<logo>", related[1].header.Get("Content-Id"))
+	assert.Equal(t, "inline; filename=logo.png", related[1].header.Get("Content-Disposition"))
+	assert.Equal(t, "PNG", decodeBase64(t, related[1].content))
+}
+
+func readMultipart(t *testing.T, contentType, content, expectedType string) []testPart {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	require.Equal(t, expectedType, mediaType)
+
+	reader := multipart.NewReader(strings.NewReader(content), params["boundary"])
+
+	var parts []testPart
+
+	for {
+		p, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return parts
+		}
+
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(p)
+		require.NoError(t, err)
+
+		parts = append(parts, testPart{header: p.Header, content: string(data)})
+	}
+}
+
+func decodeBase64(t *testing.T, value string) string {
+	t.Helper()
+
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(value, "\r\n", ""))
+	require.NoError(t, err)
+
+	return string(data)
+}
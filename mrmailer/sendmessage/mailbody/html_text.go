@@ -0,0 +1,274 @@
+package mailbody
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	// preLineMarker - отмечает строки содержимого тега <pre>, пробелы в которых сохраняются как есть.
+	preLineMarker = "\x00"
+	htmlSpaces    = " \t\r\n"
+)
+
+//nolint:gochecknoglobals
+var (
+	// htmlBlockTags - теги, которые отделяются от соседнего текста пустой строкой.
+	htmlBlockTags = map[string]struct{}{
+		"p": {}, "div": {}, "table": {}, "tr": {}, "ul": {}, "ol": {}, "blockquote": {}, "pre": {}, "hr": {},
+		"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {}, "section": {}, "article": {}, "header": {}, "footer": {},
+	}
+
+	// htmlSkippedTags - теги, содержимое которых в текстовую версию не попадает.
+	htmlSkippedTags = map[string]struct{}{
+		"head": {}, "script": {}, "style": {}, "title": {}, "noscript": {},
+	}
+)
+
+// HTMLToText - формирует упрощённую текстовую версию письма из HTML:
+// удаляет теги, разбивает текст на абзацы по блочным тегам,
+// добавляет адреса ссылок после их текста и декодирует HTML сущности.
+// Пробелы и переводы строк внутри тега <pre> сохраняются.
+func HTMLToText(value string) string {
+	var (
+		buf      strings.Builder
+		href     string
+		linkText strings.Builder
+		inLink   bool
+		preDepth int
+	)
+
+	write := func(s string) {
+		s = html.UnescapeString(s)
+
+		if preDepth > 0 {
+			s = preLineMarker + strings.ReplaceAll(s, "\n", "\n"+preLineMarker)
+		} else {
+			s = normalizeSpaces(s)
+		}
+
+		buf.WriteString(s)
+
+		if inLink {
+			linkText.WriteString(s)
+		}
+	}
+
+	for len(value) > 0 {
+		pos := strings.IndexByte(value, '<')
+		if pos < 0 {
+			write(value)
+
+			break
+		}
+
+		if pos > 0 {
+			write(value[:pos])
+		}
+
+		value = value[pos:]
+
+		// комментарии пропускаются целиком
+		if strings.HasPrefix(value, "<!--") {
+			if end := strings.Index(value, "-->"); end >= 0 {
+				value = value[end+3:]
+			} else {
+				value = ""
+			}
+
+			continue
+		}
+
+		end := strings.IndexByte(value, '>')
+		if end < 0 {
+			break
+		}
+
+		tag := value[1:end]
+		value = value[end+1:]
+
+		name, isClosing := parseTagName(tag)
+
+		if _, ok := htmlSkippedTags[name]; ok && !isClosing {
+			if closeTag := strings.Index(strings.ToLower(value), "</"+name); closeTag >= 0 {
+				value = value[closeTag:]
+			}
+
+			continue
+		}
+
+		if name == "pre" {
+			if !isClosing {
+				preDepth++
+			} else if preDepth > 0 {
+				preDepth--
+			}
+		}
+
+		switch {
+		case name == "br":
+			buf.WriteString("\n")
+		case name == "li" && !isClosing:
+			buf.WriteString("\n- ")
+		case name == "td" || name == "th":
+			if isClosing {
+				buf.WriteString(" ")
+			}
+		case name == "a" && !isClosing:
+			href = parseTagAttr(tag, "href")
+			inLink = true
+			linkText.Reset()
+		case name == "a" && isClosing:
+			text := strings.TrimSpace(strings.ReplaceAll(linkText.String(), preLineMarker, ""))
+			if href != "" && !strings.HasPrefix(href, "#") && href != text && "mailto:"+text != href {
+				buf.WriteString(" (" + href + ")")
+			}
+
+			href = ""
+			inLink = false
+		default:
+			if _, ok := htmlBlockTags[name]; ok {
+				buf.WriteString("\n\n")
+			}
+		}
+	}
+
+	return normalizeLines(buf.String())
+}
+
+func parseTagName(tag string) (name string, isClosing bool) {
+	tag = strings.TrimSpace(tag)
+
+	if strings.HasPrefix(tag, "/") {
+		isClosing = true
+		tag = tag[1:]
+	}
+
+	if pos := strings.IndexAny(tag, " \t\r\n/"); pos >= 0 {
+		tag = tag[:pos]
+	}
+
+	return strings.ToLower(tag), isClosing
+}
+
+// parseTagAttr - возвращает значение указанного атрибута тега.
+// Атрибуты разбираются последовательно, поэтому совпадение с частью имени
+// другого атрибута (например, data-href) или с его значением не учитывается.
+func parseTagAttr(tag, attr string) string {
+	// пропускается имя тега
+	if pos := strings.IndexAny(tag, htmlSpaces); pos >= 0 {
+		tag = tag[pos:]
+	} else {
+		return ""
+	}
+
+	for {
+		tag = strings.TrimLeft(tag, htmlSpaces+"/")
+		if tag == "" {
+			return ""
+		}
+
+		end := strings.IndexAny(tag, htmlSpaces+"=/")
+		if end < 0 {
+			end = len(tag)
+		}
+
+		name := strings.ToLower(tag[:end])
+		tag = strings.TrimLeft(tag[end:], htmlSpaces)
+
+		var value string
+
+		if strings.HasPrefix(tag, "=") {
+			value, tag = parseTagAttrValue(strings.TrimLeft(tag[1:], htmlSpaces))
+		}
+
+		if name == attr {
+			return html.UnescapeString(value)
+		}
+	}
+}
+
+// parseTagAttrValue - возвращает значение атрибута (в кавычках или без них) и оставшуюся часть тега.
+func parseTagAttrValue(tag string) (value, rest string) {
+	if tag == "" {
+		return "", ""
+	}
+
+	if quote := tag[0]; quote == '"' || quote == '\'' {
+		if end := strings.IndexByte(tag[1:], quote); end >= 0 {
+			return tag[1 : end+1], tag[end+2:]
+		}
+
+		return "", ""
+	}
+
+	if end := strings.IndexAny(tag, htmlSpaces); end >= 0 {
+		return tag[:end], tag[end:]
+	}
+
+	return tag, ""
+}
+
+// normalizeSpaces - заменяет последовательности пробельных символов
+// (включая переводы строк и неразрывные пробелы) одним пробелом.
+func normalizeSpaces(value string) string {
+	var buf strings.Builder
+
+	isSpace := false
+
+	for _, r := range value {
+		if unicode.IsSpace(r) {
+			isSpace = true
+
+			continue
+		}
+
+		if isSpace {
+			buf.WriteByte(' ')
+			isSpace = false
+		}
+
+		buf.WriteRune(r)
+	}
+
+	if isSpace {
+		buf.WriteByte(' ')
+	}
+
+	return buf.String()
+}
+
+// normalizeLines - удаляет лишние пробелы в строках и оставляет не более одной пустой строки подряд.
+// Строки содержимого тега <pre> остаются без изменений (кроме пробелов в конце строки).
+func normalizeLines(value string) string {
+	lines := strings.Split(value, "\n")
+	result := make([]string, 0, len(lines))
+	emptyCount := 0
+
+	for _, line := range lines {
+		if strings.Contains(line, preLineMarker) {
+			line = strings.TrimRightFunc(strings.ReplaceAll(line, preLineMarker, ""), unicode.IsSpace)
+			result = append(result, line)
+			emptyCount = 0
+
+			continue
+		}
+
+		line = strings.Join(strings.Fields(line), " ")
+
+		if line == "" {
+			emptyCount++
+
+			if emptyCount > 1 {
+				continue
+			}
+		} else {
+			emptyCount = 0
+		}
+
+		result = append(result, line)
+	}
+
+	return strings.Trim(strings.Join(result, "\n"), "\n")
+}
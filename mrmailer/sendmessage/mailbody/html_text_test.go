@@ -0,0 +1,73 @@
+package mailbody_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailbody"
+)
+
+func TestHTMLToText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "plain text",
+			html: "Hello,   world",
+			want: "Hello, world",
+		},
+		{
+			name: "paragraphs and line breaks",
+			html: "<p>First<br>line</p>\n<p>Second</p>",
+			want: "First\nline\n\nSecond",
+		},
+		{
+			name: "entities",
+			html: "<b>Tom &amp; Jerry</b>&nbsp;&lt;3",
+			want: "Tom & Jerry <3",
+		},
+		{
+			name: "links",
+			html: `Go to <a href="https://example.com/page">site</a> or <a href='https://example.com'>https://example.com</a>`,
+			want: "Go to site (https://example.com/page) or https://example.com",
+		},
+		{
+			name: "link attribute on boundary",
+			html: `<a data-href="https://example.com/data" title="href=x" HREF = "https://example.com/page">site</a>`,
+			want: "site (https://example.com/page)",
+		},
+		{
+			name: "link without href",
+			html: `<a data-href="https://example.com/data">site</a>`,
+			want: "site",
+		},
+		{
+			name: "preformatted text",
+			html: "<p>Code:</p><pre>func  main() {\n\treturn\n\n\n}</pre><p>End   of  text</p>",
+			want: "Code:\n\nfunc  main() {\n\treturn\n\n\n}\n\nEnd of text",
+		},
+		{
+			name: "lists",
+			html: "<ul><li>one</li><li>two</li></ul>",
+			want: "- one\n- two",
+		},
+		{
+			name: "skipped tags and comments",
+			html: "<html><head><title>T</title><style>p {color: red}</style></head><body><!-- c -->Text<script>alert(1)</script></body></html>",
+			want: "Text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, mailbody.HTMLToText(tt.html))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package mailbody
+
+import (
+	"slices"
+	"strings"
+)
+
+// Compose - формирует письмо в формате RFC 5322: заголовки в порядке их имён, пустая строка и тело.
+// Заголовки с пустым значением пропускаются, переводы строк тела письма приводятся к \r\n,
+// поэтому сформированное письмо передаётся почтовому серверу без изменений.
+func Compose(header map[string]string, body string) []byte {
+	names := make([]string, 0, len(header))
+
+	for name := range header {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var buf strings.Builder
+
+	buf.Grow(len(body) + 64*len(names))
+
+	for _, name := range names {
+		if value := header[name]; value != "" {
+			buf.WriteString(name + ": " + value + "\r\n")
+		}
+	}
+
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return []byte(buf.String())
+}
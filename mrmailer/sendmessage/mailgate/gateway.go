@@ -0,0 +1,32 @@
+package mailgate
+
+import (
+	"context"
+	"net/mail"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+type (
+	// Gateway - клиент почтового сервиса с HTTP API (SendGrid, Postmark, Resend и т.д.),
+	// который формирует письмо из переданных частей самостоятельно.
+	// Ошибки, после которых отправку имеет смысл повторить, должны быть типа System (см. senderror.Classify).
+	Gateway interface {
+		SendMail(ctx context.Context, mail Mail) error
+	}
+
+	// Mail - электронное письмо, передаваемое почтовому сервису.
+	// Адреса получателей уже разобраны и проверены, а содержимое вложений загружено.
+	Mail struct {
+		From        *mail.Address
+		ReplyTo     *mail.Address // OPTIONAL
+		To          []*mail.Address
+		Cc          []*mail.Address
+		Bcc         []*mail.Address
+		Subject     string
+		HTML        string // HTML версия письма (может быть пустой)
+		Text        string // текстовая версия письма (может быть пустой)
+		Headers     map[string]string
+		Attachments []entity.MailAttachment
+	}
+)
@@ -0,0 +1,125 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailgate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	maxResponseBodyLen = 4096
+)
+
+type (
+	// Client - клиент почтового сервиса с HTTP API, запросы к которому формируются
+	// по описанию Mapping (встроенные описания: SendGrid, Postmark, Resend).
+	// Ответы 2xx считаются успешными, ответы 408, 425, 429, 5xx и 4xx с заголовком Retry-After,
+	// а также сетевые ошибки считаются временными (System), остальные ответы - окончательным
+	// отказом сервиса (см. senderror.Classify).
+	Client struct {
+		httpClient *http.Client
+		mapping    Mapping
+		endpoint   string
+		apiKey     string
+		header     http.Header
+	}
+)
+
+// New - создаёт объект Client для почтового сервиса с указанным описанием API и ключом API.
+func New(mapping Mapping, apiKey string, opts ...Option) *Client {
+	o := options{
+		client: &Client{
+			httpClient: &http.Client{
+				Timeout: defaultTimeout,
+			},
+			mapping:  mapping,
+			endpoint: mapping.Endpoint,
+			apiKey:   apiKey,
+			header:   make(http.Header),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.client
+}
+
+// SendMail - отправляет письмо через почтовый сервис.
+// ID письма, назначенный сервисом, записывается в mrmailer.DeliveryReceipt контекста
+// (если сервис его не вернул, то ID провайдера не записывается).
+func (c *Client) SendMail(ctx context.Context, mail mailgate.Mail) error {
+	if mail.From == nil || len(mail.To) == 0 {
+		return errors.ErrInternalIncorrectInputData.WithDetails("mail.From or mail.To is empty")
+	}
+
+	payload, err := c.mapping.Body(mail)
+	if err != nil {
+		return errors.WrapInternalError(err, "building mail request failed", "endpoint", c.endpoint)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.WrapInternalError(err, "marshal mail request failed")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapInternalError(err, "create mail request failed", "endpoint", c.endpoint)
+	}
+
+	for name, values := range c.header {
+		request.Header[name] = values
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+
+	if c.mapping.AuthHeader != "" {
+		request.Header.Set(c.mapping.AuthHeader, c.authValue())
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return senderror.Classify(err, "endpoint", c.endpoint)
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return senderror.Classify(senderror.NewStatusError(response), "endpoint", c.endpoint)
+	}
+
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBodyLen))
+
+	var messageID string
+
+	if c.mapping.MessageID != nil {
+		messageID = c.mapping.MessageID(response.Header, responseBody)
+	}
+
+	if messageID != "" {
+		mrmailer.SetProviderMessageID(ctx, messageID)
+	}
+
+	return nil
+}
+
+func (c *Client) authValue() string {
+	if c.mapping.AuthScheme == "" {
+		return c.apiKey
+	}
+
+	return c.mapping.AuthScheme + " " + c.apiKey
+}
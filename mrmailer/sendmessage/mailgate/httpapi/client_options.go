@@ -0,0 +1,34 @@
+package httpapi
+
+import "net/http"
+
+type (
+	// Option - настройка объекта Client.
+	Option func(o *options)
+
+	options struct {
+		client *Client
+	}
+)
+
+// WithHTTPClient - устанавливает HTTP клиента, через которого выполняются запросы к сервису.
+func WithHTTPClient(value *http.Client) Option {
+	return func(o *options) {
+		o.client.httpClient = value
+	}
+}
+
+// WithEndpoint - устанавливает URL метода отправки письма вместо указанного в Mapping
+// (например, URL регионального сервера сервиса).
+func WithEndpoint(value string) Option {
+	return func(o *options) {
+		o.client.endpoint = value
+	}
+}
+
+// WithHeader - устанавливает дополнительный заголовок запроса (например, служебный заголовок сервиса).
+func WithHeader(name, value string) Option {
+	return func(o *options) {
+		o.client.header.Set(name, value)
+	}
+}
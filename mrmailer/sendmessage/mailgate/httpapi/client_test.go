@@ -0,0 +1,269 @@
+package httpapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"testing"
+
+	"github.com/mondegor/go-core/errors/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailgate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailgate/httpapi"
+)
+
+func newTestMail() mailgate.Mail {
+	return mailgate.Mail{
+		From:    &mail.Address{Name: "Shop", Address: "noreply@shop.localhost"},
+		ReplyTo: &mail.Address{Address: "support@shop.localhost"},
+		To:      []*mail.Address{{Name: "John", Address: "john@localhost"}},
+		Bcc:     []*mail.Address{{Address: "audit@shop.localhost"}},
+		Subject: "Order paid",
+		HTML:    "<p>Order 1001 paid</p>",
+		Text:    "Order 1001 paid",
+		Headers: map[string]string{"Message-ID": "<1001@shop.localhost>", "X-Order": "1001"},
+		Attachments: []entity.MailAttachment{
+			{Name: "logo.png", ContentType: "image/png", ContentID: "logo", Content: []byte("png")},
+		},
+	}
+}
+
+func TestClient_SendMail_Providers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		mapping    httpapi.Mapping
+		authHeader string
+		authValue  string
+		response   func(w http.ResponseWriter)
+		expectedID string
+		expected   map[string]any
+	}{
+		{
+			name:       "sendgrid",
+			mapping:    httpapi.SendGrid(),
+			authHeader: "Authorization",
+			authValue:  "Bearer api-key",
+			response: func(w http.ResponseWriter) {
+				w.Header().Set("X-Message-Id", "sg-1001")
+				w.WriteHeader(http.StatusAccepted)
+			},
+			expectedID: "sg-1001",
+			expected: map[string]any{
+				"personalizations": []any{
+					map[string]any{
+						"to":  []any{map[string]any{"email": "john@localhost", "name": "John"}},
+						"bcc": []any{map[string]any{"email": "audit@shop.localhost"}},
+					},
+				},
+				"from":     map[string]any{"email": "noreply@shop.localhost", "name": "Shop"},
+				"reply_to": map[string]any{"email": "support@shop.localhost"},
+				"subject":  "Order paid",
+				"content": []any{
+					map[string]any{"type": "text/plain", "value": "Order 1001 paid"},
+					map[string]any{"type": "text/html", "value": "<p>Order 1001 paid</p>"},
+				},
+				"headers": map[string]any{"Message-ID": "<1001@shop.localhost>", "X-Order": "1001"},
+				"attachments": []any{
+					map[string]any{
+						"content":     "cG5n",
+						"type":        "image/png",
+						"filename":    "logo.png",
+						"disposition": "inline",
+						"content_id":  "logo",
+					},
+				},
+			},
+		},
+		{
+			name:       "postmark",
+			mapping:    httpapi.Postmark(),
+			authHeader: "X-Postmark-Server-Token",
+			authValue:  "api-key",
+			response: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`{"ErrorCode":0,"Message":"OK","MessageID":"pm-1001"}`))
+			},
+			expectedID: "pm-1001",
+			expected: map[string]any{
+				"From":     `"Shop" <noreply@shop.localhost>`,
+				"To":       `"John" <john@localhost>`,
+				"Bcc":      "<audit@shop.localhost>",
+				"ReplyTo":  "<support@shop.localhost>",
+				"Subject":  "Order paid",
+				"HtmlBody": "<p>Order 1001 paid</p>",
+				"TextBody": "Order 1001 paid",
+				"Headers": []any{
+					map[string]any{"Name": "Message-ID", "Value": "<1001@shop.localhost>"},
+					map[string]any{"Name": "X-Order", "Value": "1001"},
+				},
+				"Attachments": []any{
+					map[string]any{
+						"Name":        "logo.png",
+						"Content":     "cG5n",
+						"ContentType": "image/png",
+						"ContentID":   "cid:logo",
+					},
+				},
+			},
+		},
+		{
+			name:       "resend",
+			mapping:    httpapi.Resend(),
+			authHeader: "Authorization",
+			authValue:  "Bearer api-key",
+			response: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`{"id":"rs-1001"}`))
+			},
+			expectedID: "rs-1001",
+			expected: map[string]any{
+				"from":     `"Shop" <noreply@shop.localhost>`,
+				"to":       []any{`"John" <john@localhost>`},
+				"bcc":      []any{"<audit@shop.localhost>"},
+				"reply_to": []any{"<support@shop.localhost>"},
+				"subject":  "Order paid",
+				"html":     "<p>Order 1001 paid</p>",
+				"text":     "Order 1001 paid",
+				"headers":  map[string]any{"Message-ID": "<1001@shop.localhost>", "X-Order": "1001"},
+				"attachments": []any{
+					map[string]any{
+						"filename":     "logo.png",
+						"content":      "cG5n",
+						"content_type": "image/png",
+						"content_id":   "logo",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var received map[string]any
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, tt.authValue, r.Header.Get(tt.authHeader))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+				tt.response(w)
+			}))
+			defer server.Close()
+
+			client := httpapi.New(tt.mapping, "api-key", httpapi.WithEndpoint(server.URL))
+
+			ctx, receipt := mrmailer.WithDeliveryReceipt(context.Background())
+
+			require.NoError(t, client.SendMail(ctx, newTestMail()))
+			assert.Equal(t, tt.expected, received)
+			assert.Equal(t, tt.expectedID, receipt.ProviderMessageID())
+		})
+	}
+}
+
+func TestClient_SendMail_CustomMapping(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Token api-key", r.Header.Get("X-Auth"))
+		assert.Equal(t, "eu", r.Header.Get("X-Region"))
+
+		_, _ = w.Write([]byte(`{"data":{"message":{"id":"custom-1001"}}}`))
+	}))
+	defer server.Close()
+
+	client := httpapi.New(
+		httpapi.Mapping{
+			Endpoint:   server.URL,
+			AuthHeader: "X-Auth",
+			AuthScheme: "Token",
+			Body: func(m mailgate.Mail) (any, error) {
+				return map[string]string{"to": m.To[0].Address, "subject": m.Subject}, nil
+			},
+			MessageID: httpapi.MessageIDFromJSON("data", "message", "id"),
+		},
+		"api-key",
+		httpapi.WithHeader("X-Region", "eu"),
+	)
+
+	ctx, receipt := mrmailer.WithDeliveryReceipt(context.Background())
+
+	require.NoError(t, client.SendMail(ctx, newTestMail()))
+	assert.Equal(t, "custom-1001", receipt.ProviderMessageID())
+}
+
+func TestClient_SendMail_MessageIDNotReturned(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := httpapi.New(httpapi.SendGrid(), "api-key", httpapi.WithEndpoint(server.URL))
+
+	ctx, receipt := mrmailer.WithDeliveryReceipt(context.Background())
+
+	// заголовок Message-ID письма не является ID письма в почтовом сервисе
+	require.NoError(t, client.SendMail(ctx, newTestMail()))
+	assert.Empty(t, receipt.ProviderMessageID())
+}
+
+func TestClient_SendMail_ErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		temporary  bool
+	}{
+		{name: "unprocessable entity is permanent", status: http.StatusUnprocessableEntity, temporary: false},
+		{name: "unauthorized is permanent", status: http.StatusUnauthorized, temporary: false},
+		{name: "forbidden with retry after is temporary", status: http.StatusForbidden, retryAfter: "60", temporary: true},
+		{name: "too many requests is temporary", status: http.StatusTooManyRequests, temporary: true},
+		{name: "server error is temporary", status: http.StatusServiceUnavailable, temporary: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"ErrorCode":300,"Message":"Invalid email request"}`))
+			}))
+			defer server.Close()
+
+			err := httpapi.New(httpapi.Postmark(), "api-key", httpapi.WithEndpoint(server.URL)).
+				SendMail(context.Background(), newTestMail())
+			require.Error(t, err)
+			assert.Equal(t, tt.temporary, kind.Extract(err) == kind.System)
+		})
+	}
+}
+
+func TestClient_SendMail_NetworkError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Close()
+
+	err := httpapi.New(httpapi.Resend(), "api-key", httpapi.WithEndpoint(server.URL)).
+		SendMail(context.Background(), newTestMail())
+	require.Error(t, err)
+	assert.Equal(t, kind.System, kind.Extract(err))
+}
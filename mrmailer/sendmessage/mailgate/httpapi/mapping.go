@@ -0,0 +1,59 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailgate"
+)
+
+type (
+	// Mapping - описание HTTP API почтового сервиса: куда и с какой авторизацией
+	// отправляется запрос, как из письма формируется JSON тело запроса
+	// и как из успешного ответа извлекается ID, назначенный письму сервисом.
+	Mapping struct {
+		Endpoint   string // URL метода отправки письма
+		AuthHeader string // имя заголовка, в котором передаётся ключ API
+		AuthScheme string // OPTIONAL: схема авторизации перед ключом API (например, Bearer)
+
+		// Body - возвращает значение, которое сериализуется в JSON тело запроса.
+		Body func(mail mailgate.Mail) (any, error)
+
+		// MessageID - OPTIONAL: возвращает ID письма из заголовков и тела успешного ответа
+		// (пустое значение, если ID в ответе не найден).
+		MessageID func(header http.Header, body []byte) string
+	}
+)
+
+// MessageIDFromHeader - возвращает функцию Mapping.MessageID,
+// которая извлекает ID письма из указанного заголовка ответа.
+func MessageIDFromHeader(name string) func(header http.Header, body []byte) string {
+	return func(header http.Header, _ []byte) string {
+		return header.Get(name)
+	}
+}
+
+// MessageIDFromJSON - возвращает функцию Mapping.MessageID, которая извлекает ID письма
+// из строкового поля JSON тела ответа по указанному пути (например, "data", "id").
+func MessageIDFromJSON(path ...string) func(header http.Header, body []byte) string {
+	return func(_ http.Header, body []byte) string {
+		var value any
+
+		if err := json.Unmarshal(body, &value); err != nil {
+			return ""
+		}
+
+		for _, name := range path {
+			object, ok := value.(map[string]any)
+			if !ok {
+				return ""
+			}
+
+			value = object[name]
+		}
+
+		id, _ := value.(string)
+
+		return id
+	}
+}
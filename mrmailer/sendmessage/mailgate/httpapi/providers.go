@@ -0,0 +1,279 @@
+package httpapi
+
+import (
+	"net/mail"
+	"slices"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailaddr"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailgate"
+)
+
+const (
+	dispositionAttachment = "attachment"
+	dispositionInline     = "inline"
+)
+
+type (
+	sendGridBody struct {
+		Personalizations []sendGridPersonalization `json:"personalizations"`
+		From             sendGridAddress           `json:"from"`
+		ReplyTo          *sendGridAddress          `json:"reply_to,omitempty"`
+		Subject          string                    `json:"subject"`
+		Content          []sendGridContent         `json:"content"`
+		Headers          map[string]string         `json:"headers,omitempty"`
+		Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+	}
+
+	sendGridPersonalization struct {
+		To  []sendGridAddress `json:"to"`
+		Cc  []sendGridAddress `json:"cc,omitempty"`
+		Bcc []sendGridAddress `json:"bcc,omitempty"`
+	}
+
+	sendGridAddress struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}
+
+	sendGridContent struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+
+	sendGridAttachment struct {
+		Content     []byte `json:"content"` // в JSON передаётся в base64
+		Type        string `json:"type,omitempty"`
+		Filename    string `json:"filename"`
+		Disposition string `json:"disposition"`
+		ContentID   string `json:"content_id,omitempty"`
+	}
+
+	postmarkBody struct {
+		From        string               `json:"From"`
+		To          string               `json:"To"`
+		Cc          string               `json:"Cc,omitempty"`
+		Bcc         string               `json:"Bcc,omitempty"`
+		ReplyTo     string               `json:"ReplyTo,omitempty"`
+		Subject     string               `json:"Subject"`
+		HTMLBody    string               `json:"HtmlBody,omitempty"`
+		TextBody    string               `json:"TextBody,omitempty"`
+		Headers     []postmarkHeader     `json:"Headers,omitempty"`
+		Attachments []postmarkAttachment `json:"Attachments,omitempty"`
+	}
+
+	postmarkHeader struct {
+		Name  string `json:"Name"`
+		Value string `json:"Value"`
+	}
+
+	postmarkAttachment struct {
+		Name        string `json:"Name"`
+		Content     []byte `json:"Content"` // в JSON передаётся в base64
+		ContentType string `json:"ContentType"`
+		ContentID   string `json:"ContentID,omitempty"`
+	}
+
+	resendBody struct {
+		From        string             `json:"from"`
+		To          []string           `json:"to"`
+		Cc          []string           `json:"cc,omitempty"`
+		Bcc         []string           `json:"bcc,omitempty"`
+		ReplyTo     []string           `json:"reply_to,omitempty"`
+		Subject     string             `json:"subject"`
+		HTML        string             `json:"html,omitempty"`
+		Text        string             `json:"text,omitempty"`
+		Headers     map[string]string  `json:"headers,omitempty"`
+		Attachments []resendAttachment `json:"attachments,omitempty"`
+	}
+
+	resendAttachment struct {
+		Filename    string `json:"filename"`
+		Content     []byte `json:"content"` // в JSON передаётся в base64
+		ContentType string `json:"content_type,omitempty"`
+		ContentID   string `json:"content_id,omitempty"`
+	}
+)
+
+// SendGrid - возвращает описание API SendGrid v3 (POST /v3/mail/send, ключ API в Authorization: Bearer),
+// ID письма возвращается в заголовке ответа X-Message-Id.
+func SendGrid() Mapping {
+	return Mapping{
+		Endpoint:   "https://api.sendgrid.com/v3/mail/send",
+		AuthHeader: "Authorization",
+		AuthScheme: "Bearer",
+		Body:       makeSendGridBody,
+		MessageID:  MessageIDFromHeader("X-Message-Id"),
+	}
+}
+
+// Postmark - возвращает описание API Postmark (POST /email, токен сервера в X-Postmark-Server-Token),
+// ID письма возвращается в поле MessageID тела ответа.
+func Postmark() Mapping {
+	return Mapping{
+		Endpoint:   "https://api.postmarkapp.com/email",
+		AuthHeader: "X-Postmark-Server-Token",
+		Body:       makePostmarkBody,
+		MessageID:  MessageIDFromJSON("MessageID"),
+	}
+}
+
+// Resend - возвращает описание API Resend (POST /emails, ключ API в Authorization: Bearer),
+// ID письма возвращается в поле id тела ответа.
+func Resend() Mapping {
+	return Mapping{
+		Endpoint:   "https://api.resend.com/emails",
+		AuthHeader: "Authorization",
+		AuthScheme: "Bearer",
+		Body:       makeResendBody,
+		MessageID:  MessageIDFromJSON("id"),
+	}
+}
+
+func makeSendGridBody(m mailgate.Mail) (any, error) {
+	body := sendGridBody{
+		Personalizations: []sendGridPersonalization{
+			{
+				To:  sendGridAddresses(m.To),
+				Cc:  sendGridAddresses(m.Cc),
+				Bcc: sendGridAddresses(m.Bcc),
+			},
+		},
+		From:    sendGridAddress{Email: m.From.Address, Name: m.From.Name},
+		Subject: m.Subject,
+		Headers: m.Headers,
+	}
+
+	if m.ReplyTo != nil {
+		body.ReplyTo = &sendGridAddress{Email: m.ReplyTo.Address, Name: m.ReplyTo.Name}
+	}
+
+	// SendGrid требует, чтобы текстовая версия письма шла перед HTML версией
+	if m.Text != "" {
+		body.Content = append(body.Content, sendGridContent{Type: "text/plain", Value: m.Text})
+	}
+
+	if m.HTML != "" {
+		body.Content = append(body.Content, sendGridContent{Type: "text/html", Value: m.HTML})
+	}
+
+	for _, attachment := range m.Attachments {
+		item := sendGridAttachment{
+			Content:     attachment.Content,
+			Type:        attachment.ContentType,
+			Filename:    attachment.Name,
+			Disposition: dispositionAttachment,
+		}
+
+		if attachment.ContentID != "" {
+			item.Disposition = dispositionInline
+			item.ContentID = attachment.ContentID
+		}
+
+		body.Attachments = append(body.Attachments, item)
+	}
+
+	return body, nil
+}
+
+func makePostmarkBody(m mailgate.Mail) (any, error) {
+	body := postmarkBody{
+		From:     m.From.String(),
+		To:       mailaddr.FormatList(m.To),
+		Cc:       mailaddr.FormatList(m.Cc),
+		Bcc:      mailaddr.FormatList(m.Bcc),
+		Subject:  m.Subject,
+		HTMLBody: m.HTML,
+		TextBody: m.Text,
+	}
+
+	if m.ReplyTo != nil {
+		body.ReplyTo = m.ReplyTo.String()
+	}
+
+	names := make([]string, 0, len(m.Headers))
+
+	for name := range m.Headers {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		body.Headers = append(body.Headers, postmarkHeader{Name: name, Value: m.Headers[name]})
+	}
+
+	for _, attachment := range m.Attachments {
+		item := postmarkAttachment{
+			Name:        attachment.Name,
+			Content:     attachment.Content,
+			ContentType: attachment.ContentType,
+		}
+
+		if attachment.ContentID != "" {
+			item.ContentID = "cid:" + attachment.ContentID
+		}
+
+		body.Attachments = append(body.Attachments, item)
+	}
+
+	return body, nil
+}
+
+func makeResendBody(m mailgate.Mail) (any, error) {
+	body := resendBody{
+		From:    m.From.String(),
+		To:      formatAddresses(m.To),
+		Cc:      formatAddresses(m.Cc),
+		Bcc:     formatAddresses(m.Bcc),
+		Subject: m.Subject,
+		HTML:    m.HTML,
+		Text:    m.Text,
+		Headers: m.Headers,
+	}
+
+	if m.ReplyTo != nil {
+		body.ReplyTo = []string{m.ReplyTo.String()}
+	}
+
+	for _, attachment := range m.Attachments {
+		body.Attachments = append(
+			body.Attachments,
+			resendAttachment{
+				Filename:    attachment.Name,
+				Content:     attachment.Content,
+				ContentType: attachment.ContentType,
+				ContentID:   attachment.ContentID,
+			},
+		)
+	}
+
+	return body, nil
+}
+
+func sendGridAddresses(list []*mail.Address) []sendGridAddress {
+	if len(list) == 0 {
+		return nil
+	}
+
+	addresses := make([]sendGridAddress, len(list))
+
+	for i, addr := range list {
+		addresses[i] = sendGridAddress{Email: addr.Address, Name: addr.Name}
+	}
+
+	return addresses
+}
+
+func formatAddresses(list []*mail.Address) []string {
+	if len(list) == 0 {
+		return nil
+	}
+
+	addresses := make([]string, len(list))
+
+	for i, addr := range list {
+		addresses[i] = addr.String()
+	}
+
+	return addresses
+}
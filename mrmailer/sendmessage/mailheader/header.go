@@ -0,0 +1,219 @@
+package mailheader
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ListUnsubscribe - заголовок со ссылками для отписки от рассылки (RFC 2369).
+	ListUnsubscribe = "List-Unsubscribe"
+
+	// ListUnsubscribePost - заголовок отписки в один клик (RFC 8058).
+	ListUnsubscribePost = "List-Unsubscribe-Post"
+
+	// InReplyTo - заголовок с Message-ID письма, на которое отправляется ответ (RFC 5322).
+	InReplyTo = "In-Reply-To"
+
+	// References - заголовок с Message-ID писем цепочки переписки (RFC 5322).
+	References = "References"
+
+	// MessageID - заголовок с уникальным идентификатором письма (RFC 5322).
+	MessageID = "Message-ID"
+
+	listUnsubscribeOneClick = "List-Unsubscribe=One-Click"
+	maxHeaderLineLen        = 998
+	customHeaderPrefix      = "X-"
+)
+
+var (
+	// ErrHeaderNotAllowed - заголовок не может быть указан в сообщении.
+	ErrHeaderNotAllowed = errors.New("mail header is not allowed")
+
+	// ErrHeaderValueInvalid - значение заголовка некорректно.
+	ErrHeaderValueInvalid = errors.New("mail header value is invalid")
+)
+
+//nolint:gochecknoglobals
+var (
+	// allowedHeaders - заголовки, разрешённые к указанию в сообщении (помимо заголовков с префиксом X-),
+	// ключ - каноническое имя заголовка, значение - его общепринятое написание.
+	allowedHeaders = map[string]string{
+		textproto.CanonicalMIMEHeaderKey(ListUnsubscribe):     ListUnsubscribe,
+		textproto.CanonicalMIMEHeaderKey(ListUnsubscribePost): ListUnsubscribePost,
+		textproto.CanonicalMIMEHeaderKey(InReplyTo):           InReplyTo,
+		textproto.CanonicalMIMEHeaderKey(References):          References,
+		textproto.CanonicalMIMEHeaderKey("List-Id"):           "List-Id",
+		textproto.CanonicalMIMEHeaderKey("Auto-Submitted"):    "Auto-Submitted",
+		textproto.CanonicalMIMEHeaderKey("Precedence"):        "Precedence",
+	}
+)
+
+// Normalize - проверяет пользовательские заголовки письма и возвращает их
+// с приведёнными к общепринятому написанию именами.
+// Разрешены только заголовки из списка allowedHeaders и заголовки с префиксом X-,
+// заголовки, формируемые почтовым адаптером (From, To, Subject, Message-ID, Content-Type и т.д.), запрещены.
+func Normalize(headers map[string]string) (map[string]string, error) {
+	if len(headers) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	normalized := make(map[string]string, len(headers))
+
+	for name, value := range headers {
+		key, err := normalizeName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := normalized[key]; ok {
+			return nil, fmt.Errorf("%w: %s is duplicated", ErrHeaderNotAllowed, key)
+		}
+
+		value = strings.TrimSpace(value)
+
+		if err = checkValue(key, value); err != nil {
+			return nil, err
+		}
+
+		normalized[key] = value
+	}
+
+	if _, ok := normalized[ListUnsubscribePost]; ok {
+		if !hasHTTPSUnsubscribe(normalized[ListUnsubscribe]) {
+			return nil, fmt.Errorf("%w: %s requires https URI in %s", ErrHeaderValueInvalid, ListUnsubscribePost, ListUnsubscribe)
+		}
+	}
+
+	return normalized, nil
+}
+
+// NewMessageID - возвращает стабильный Message-ID письма, сформированный
+// на основе ID сообщения и указанного домена (при повторной отправке сообщения он не меняется).
+func NewMessageID(messageID uint64, domain string) string {
+	return "<" + strconv.FormatUint(messageID, 10) + ".mrmailer@" + domain + ">"
+}
+
+func normalizeName(name string) (string, error) {
+	if name == "" || !isFieldName(name) {
+		return "", fmt.Errorf("%w: %q", ErrHeaderNotAllowed, name)
+	}
+
+	if key, ok := allowedHeaders[textproto.CanonicalMIMEHeaderKey(name)]; ok {
+		return key, nil
+	}
+
+	if len(name) > len(customHeaderPrefix) && strings.EqualFold(name[:len(customHeaderPrefix)], customHeaderPrefix) {
+		return textproto.CanonicalMIMEHeaderKey(name), nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrHeaderNotAllowed, name)
+}
+
+// isFieldName - проверяет имя заголовка на соответствие RFC 5322 (печатные ASCII символы кроме ':').
+func isFieldName(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if name[i] < '!' || name[i] > '~' || name[i] == ':' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func checkValue(name, value string) error {
+	if value == "" || len(name)+len(value)+2 > maxHeaderLineLen {
+		return fmt.Errorf("%w: %s is empty or too long", ErrHeaderValueInvalid, name)
+	}
+
+	// переводы строк запрещены, чтобы исключить внедрение заголовков
+	if strings.ContainsAny(value, "\r\n\x00") {
+		return fmt.Errorf("%w: %s contains line breaks", ErrHeaderValueInvalid, name)
+	}
+
+	switch name {
+	case ListUnsubscribe:
+		if _, ok := parseUnsubscribeURIs(value); !ok {
+			return fmt.Errorf("%w: %s must contain <https:...> or <mailto:...> URIs", ErrHeaderValueInvalid, name)
+		}
+	case ListUnsubscribePost:
+		if value != listUnsubscribeOneClick {
+			return fmt.Errorf("%w: %s must be %s", ErrHeaderValueInvalid, name, listUnsubscribeOneClick)
+		}
+	case InReplyTo, References:
+		if !isMessageIDList(value) {
+			return fmt.Errorf("%w: %s must contain <id@domain> message IDs", ErrHeaderValueInvalid, name)
+		}
+	}
+
+	return nil
+}
+
+// parseUnsubscribeURIs - разбирает список URI вида <uri>, <uri> заголовка List-Unsubscribe.
+func parseUnsubscribeURIs(value string) ([]*url.URL, bool) {
+	items := strings.Split(value, ",")
+	uris := make([]*url.URL, 0, len(items))
+
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+
+		if len(item) < 3 || item[0] != '<' || item[len(item)-1] != '>' {
+			return nil, false
+		}
+
+		uri, err := url.Parse(item[1 : len(item)-1])
+		if err != nil {
+			return nil, false
+		}
+
+		switch uri.Scheme {
+		case "https", "http":
+			if uri.Host == "" {
+				return nil, false
+			}
+		case "mailto":
+			if uri.Opaque == "" {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+
+		uris = append(uris, uri)
+	}
+
+	return uris, true
+}
+
+func hasHTTPSUnsubscribe(value string) bool {
+	uris, _ := parseUnsubscribeURIs(value)
+
+	for _, uri := range uris {
+		if uri.Scheme == "https" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMessageIDList - проверяет, что значение является списком идентификаторов вида <left@right>,
+// разделённых пробелами.
+func isMessageIDList(value string) bool {
+	for _, item := range strings.Fields(value) {
+		if len(item) < 5 || item[0] != '<' || item[len(item)-1] != '>' {
+			return false
+		}
+
+		left, right, ok := strings.Cut(item[1:len(item)-1], "@")
+		if !ok || left == "" || right == "" || strings.ContainsAny(left+right, "<>@") {
+			return false
+		}
+	}
+
+	return true
+}
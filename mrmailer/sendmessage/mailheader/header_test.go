@@ -0,0 +1,116 @@
+package mailheader_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailheader"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	got, err := mailheader.Normalize(
+		map[string]string{
+			"list-unsubscribe":      "<https://example.com/unsubscribe?id=1>, <mailto:unsubscribe@example.com>",
+			"LIST-UNSUBSCRIBE-POST": "List-Unsubscribe=One-Click",
+			"in-reply-to":           "<1.mrmailer@example.com>",
+			"References":            "<1.mrmailer@example.com> <2.mrmailer@example.com>",
+			"x-campaign-id":         " spring ",
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[string]string{
+			mailheader.ListUnsubscribe:     "<https://example.com/unsubscribe?id=1>, <mailto:unsubscribe@example.com>",
+			mailheader.ListUnsubscribePost: "List-Unsubscribe=One-Click",
+			mailheader.InReplyTo:           "<1.mrmailer@example.com>",
+			mailheader.References:          "<1.mrmailer@example.com> <2.mrmailer@example.com>",
+			"X-Campaign-Id":                "spring",
+		},
+		got,
+	)
+}
+
+func TestNormalize_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantErr error
+	}{
+		{
+			name:    "reserved header",
+			headers: map[string]string{"Subject": "test"},
+			wantErr: mailheader.ErrHeaderNotAllowed,
+		},
+		{
+			name:    "message id is generated",
+			headers: map[string]string{"Message-Id": "<1@example.com>"},
+			wantErr: mailheader.ErrHeaderNotAllowed,
+		},
+		{
+			name:    "invalid name",
+			headers: map[string]string{"X-Bad:Name": "test"},
+			wantErr: mailheader.ErrHeaderNotAllowed,
+		},
+		{
+			name:    "duplicated name",
+			headers: map[string]string{"X-Id": "1", "x-id": "2"},
+			wantErr: mailheader.ErrHeaderNotAllowed,
+		},
+		{
+			name:    "header injection",
+			headers: map[string]string{"X-Id": "1\r\nBcc: victim@example.com"},
+			wantErr: mailheader.ErrHeaderValueInvalid,
+		},
+		{
+			name:    "unsubscribe without brackets",
+			headers: map[string]string{"List-Unsubscribe": "https://example.com/unsubscribe"},
+			wantErr: mailheader.ErrHeaderValueInvalid,
+		},
+		{
+			name:    "unsubscribe with unsupported scheme",
+			headers: map[string]string{"List-Unsubscribe": "<ftp://example.com/unsubscribe>"},
+			wantErr: mailheader.ErrHeaderValueInvalid,
+		},
+		{
+			name: "one click without https",
+			headers: map[string]string{
+				"List-Unsubscribe":      "<mailto:unsubscribe@example.com>",
+				"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+			},
+			wantErr: mailheader.ErrHeaderValueInvalid,
+		},
+		{
+			name:    "invalid one click value",
+			headers: map[string]string{"List-Unsubscribe-Post": "yes"},
+			wantErr: mailheader.ErrHeaderValueInvalid,
+		},
+		{
+			name:    "invalid references",
+			headers: map[string]string{"References": "<1@example.com> 2@example.com"},
+			wantErr: mailheader.ErrHeaderValueInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := mailheader.Normalize(tt.headers)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewMessageID(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "<42.mrmailer@example.com>", mailheader.NewMessageID(42, "example.com"))
+}
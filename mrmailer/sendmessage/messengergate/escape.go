@@ -0,0 +1,53 @@
+package messengergate
+
+import (
+	"html"
+	"strings"
+)
+
+//nolint:gochecknoglobals
+var (
+	replacerMarkdown = strings.NewReplacer(
+		"_", "\\_",
+		"*", "\\*",
+		"`", "\\`",
+		"[", "\\[",
+	)
+	replacerMarkdownV2 = strings.NewReplacer(
+		"\\", "\\\\",
+		"_", "\\_",
+		"*", "\\*",
+		"[", "\\[",
+		"]", "\\]",
+		"(", "\\(",
+		")", "\\)",
+		"~", "\\~",
+		"`", "\\`",
+		">", "\\>",
+		"#", "\\#",
+		"+", "\\+",
+		"-", "\\-",
+		"=", "\\=",
+		"|", "\\|",
+		"{", "\\{",
+		"}", "\\}",
+		".", "\\.",
+		"!", "\\!",
+	)
+)
+
+// Escape - экранирует значение для вставки в текст с указанной разметкой,
+// чтобы символы значения отображались как есть и не нарушали разметку сообщения.
+// Для текста без разметки (или с разметкой по умолчанию клиента) значение не изменяется.
+func Escape(parseMode, value string) string {
+	switch parseMode {
+	case ParseModeMarkdown:
+		return replacerMarkdown.Replace(value)
+	case ParseModeMarkdownV2:
+		return replacerMarkdownV2.Replace(value)
+	case ParseModeHTML:
+		return html.EscapeString(value)
+	default:
+		return value
+	}
+}
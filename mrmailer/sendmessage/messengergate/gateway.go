@@ -0,0 +1,152 @@
+package messengergate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	// ParseModePlain - текст без разметки.
+	ParseModePlain = "plain"
+
+	// ParseModeMarkdown - разметка Markdown (устаревший вариант Telegram: *bold*, _italic_, `code`, [text](url)).
+	ParseModeMarkdown = "Markdown"
+
+	// ParseModeMarkdownV2 - разметка MarkdownV2 (все служебные символы вне разметки экранируются).
+	ParseModeMarkdownV2 = "MarkdownV2"
+
+	// ParseModeHTML - разметка HTML (<b>, <i>, <a href>, <code> и т.д.).
+	ParseModeHTML = "HTML"
+
+	maxButtonRows    = 16
+	maxButtonsPerRow = 8
+)
+
+var (
+	// ErrMessageInvalid - сообщение мессенджера некорректно.
+	ErrMessageInvalid = errors.New("messenger message is invalid")
+)
+
+type (
+	// Gateway - клиент мессенджера, поддерживающий оформление сообщений (разметку, кнопки, параметры доставки).
+	// Ошибки, после которых отправку имеет смысл повторить, должны быть типа System (см. senderror.Classify).
+	Gateway interface {
+		SendMessage(ctx context.Context, message Message) error
+	}
+
+	// Message - сообщение мессенджера, передаваемое клиенту.
+	Message struct {
+		ChatID             string
+		ThreadID           string
+		Text               string
+		ParseMode          string // пустое значение - разметка по умолчанию клиента
+		Buttons            [][]Button
+		DisableLinkPreview bool
+		Silent             bool
+	}
+
+	// Button - кнопка со ссылкой, отображаемая под сообщением.
+	Button struct {
+		Text string
+		URL  string
+	}
+)
+
+// NewMessage - создаёт сообщение для клиента из данных сообщения.
+func NewMessage(data *entity.DataMessenger) Message {
+	message := Message{
+		ChatID:             data.ChatID,
+		ThreadID:           data.ThreadID,
+		Text:               data.Content,
+		ParseMode:          data.ParseMode,
+		DisableLinkPreview: data.DisableLinkPreview,
+		Silent:             data.Silent,
+	}
+
+	if len(data.Buttons) > 0 {
+		message.Buttons = make([][]Button, len(data.Buttons))
+
+		for i, row := range data.Buttons {
+			message.Buttons[i] = make([]Button, len(row))
+
+			for j, button := range row {
+				message.Buttons[i][j] = Button{Text: button.Text, URL: button.URL}
+			}
+		}
+	}
+
+	return message
+}
+
+// IsPlain - сообщает, что у сообщения нет оформления и параметров доставки,
+// т.е. его можно отправить клиентом, поддерживающим только текст.
+func IsPlain(data *entity.DataMessenger) bool {
+	return data.ThreadID == "" &&
+		(data.ParseMode == "" || data.ParseMode == ParseModePlain) &&
+		len(data.Buttons) == 0 &&
+		!data.DisableLinkPreview &&
+		!data.Silent
+}
+
+// IsKnownParseMode - сообщает, является ли разметка известной (пустое значение - разметка по умолчанию клиента).
+func IsKnownParseMode(parseMode string) bool {
+	switch parseMode {
+	case "", ParseModePlain, ParseModeMarkdown, ParseModeMarkdownV2, ParseModeHTML:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate - проверяет, что у сообщения указаны чат и текст, ID темы чата (если указан) является целым числом,
+// разметка известна, а у кнопок указан текст и абсолютная ссылка.
+func Validate(data *entity.DataMessenger) error {
+	if data == nil {
+		return fmt.Errorf("%w: data is nil", ErrMessageInvalid)
+	}
+
+	if data.ChatID == "" {
+		return fmt.Errorf("%w: chat id is empty", ErrMessageInvalid)
+	}
+
+	if data.ThreadID != "" {
+		if _, err := strconv.ParseInt(data.ThreadID, 10, 64); err != nil {
+			return fmt.Errorf("%w: thread id '%s' is not an integer", ErrMessageInvalid, data.ThreadID)
+		}
+	}
+
+	if data.Content == "" {
+		return fmt.Errorf("%w: content is empty", ErrMessageInvalid)
+	}
+
+	if !IsKnownParseMode(data.ParseMode) {
+		return fmt.Errorf("%w: parse mode '%s' is unknown", ErrMessageInvalid, data.ParseMode)
+	}
+
+	if len(data.Buttons) > maxButtonRows {
+		return fmt.Errorf("%w: too many button rows", ErrMessageInvalid)
+	}
+
+	for _, row := range data.Buttons {
+		if len(row) == 0 || len(row) > maxButtonsPerRow {
+			return fmt.Errorf("%w: button row is empty or too long", ErrMessageInvalid)
+		}
+
+		for _, button := range row {
+			if button.Text == "" {
+				return fmt.Errorf("%w: button text is empty", ErrMessageInvalid)
+			}
+
+			if parsed, err := url.Parse(button.URL); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+				return fmt.Errorf("%w: button url '%s' is not absolute", ErrMessageInvalid, button.URL)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package messengergate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/messengergate"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, messengergate.Validate(&entity.DataMessenger{ChatID: "100500", Content: "text"}))
+	require.NoError(
+		t,
+		messengergate.Validate(&entity.DataMessenger{
+			ChatID:    "100500",
+			ThreadID:  "7",
+			Content:   "<b>text</b>",
+			ParseMode: messengergate.ParseModeHTML,
+			Buttons:   [][]entity.MessengerButton{{{Text: "Open", URL: "https://localhost/order/1"}}},
+		}),
+	)
+
+	invalid := []*entity.DataMessenger{
+		nil,
+		{Content: "text"},
+		{ChatID: "100500"},
+		{ChatID: "100500", ThreadID: "general", Content: "text"},
+		{ChatID: "100500", Content: "text", ParseMode: "BBCode"},
+		{ChatID: "100500", Content: "text", Buttons: [][]entity.MessengerButton{{}}},
+		{ChatID: "100500", Content: "text", Buttons: [][]entity.MessengerButton{{{URL: "https://localhost"}}}},
+		{ChatID: "100500", Content: "text", Buttons: [][]entity.MessengerButton{{{Text: "Open", URL: "/order/1"}}}},
+	}
+
+	for _, data := range invalid {
+		require.ErrorIs(t, messengergate.Validate(data), messengergate.ErrMessageInvalid)
+	}
+}
+
+func TestNewMessage(t *testing.T) {
+	t.Parallel()
+
+	data := &entity.DataMessenger{
+		ChatID:    "100500",
+		ThreadID:  "7",
+		Content:   "*text*",
+		ParseMode: messengergate.ParseModeMarkdownV2,
+		Buttons:   [][]entity.MessengerButton{{{Text: "Open", URL: "https://localhost"}}},
+		Silent:    true,
+	}
+
+	assert.Equal(
+		t,
+		messengergate.Message{
+			ChatID:    "100500",
+			ThreadID:  "7",
+			Text:      "*text*",
+			ParseMode: messengergate.ParseModeMarkdownV2,
+			Buttons:   [][]messengergate.Button{{{Text: "Open", URL: "https://localhost"}}},
+			Silent:    true,
+		},
+		messengergate.NewMessage(data),
+	)
+	assert.False(t, messengergate.IsPlain(data))
+	assert.True(t, messengergate.IsPlain(&entity.DataMessenger{ChatID: "100500", Content: "text", ParseMode: messengergate.ParseModePlain}))
+}
+
+func TestEscape(t *testing.T) {
+	t.Parallel()
+
+	value := "a_b*c [d](e) <f> & 1.5!"
+
+	assert.Equal(t, value, messengergate.Escape(messengergate.ParseModePlain, value))
+	assert.Equal(t, value, messengergate.Escape("", value))
+	assert.Equal(t, `a\_b\*c \[d](e) <f> & 1.5!`, messengergate.Escape(messengergate.ParseModeMarkdown, value))
+	assert.Equal(t, `a\_b\*c \[d\]\(e\) <f\> & 1\.5\!`, messengergate.Escape(messengergate.ParseModeMarkdownV2, value))
+	assert.Equal(t, "a_b*c [d](e) &lt;f&gt; &amp; 1.5!", messengergate.Escape(messengergate.ParseModeHTML, value))
+}
@@ -0,0 +1,201 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/messengergate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
+)
+
+const (
+	defaultBaseURL     = "https://api.telegram.org"
+	defaultTimeout     = 10 * time.Second
+	maxResponseBodyLen = 2048
+)
+
+type (
+	// Client - клиент Telegram Bot API, отправляющий сообщения методом sendMessage
+	// с разметкой, кнопками со ссылками (inline keyboard), отключением предпросмотра ссылок,
+	// доставкой без звука и указанием темы (топика) чата.
+	// Ответы 429 (с parameters.retry_after) и 5xx, а также сетевые ошибки считаются временными (System),
+	// остальные ответы - окончательным отказом (см. senderror.Classify).
+	Client struct {
+		httpClient *http.Client
+		url        string
+	}
+
+	requestBody struct {
+		ChatID              string              `json:"chat_id"`
+		MessageThreadID     int64               `json:"message_thread_id,omitempty"`
+		Text                string              `json:"text"`
+		ParseMode           string              `json:"parse_mode,omitempty"`
+		LinkPreviewOptions  *linkPreviewOptions `json:"link_preview_options,omitempty"`
+		DisableNotification bool                `json:"disable_notification,omitempty"`
+		ReplyMarkup         *replyMarkup        `json:"reply_markup,omitempty"`
+	}
+
+	linkPreviewOptions struct {
+		IsDisabled bool `json:"is_disabled"`
+	}
+
+	replyMarkup struct {
+		InlineKeyboard [][]inlineButton `json:"inline_keyboard"`
+	}
+
+	inlineButton struct {
+		Text string `json:"text"`
+		URL  string `json:"url"`
+	}
+
+	responseBody struct {
+		OK          bool   `json:"ok"`
+		ErrorCode   int    `json:"error_code"`
+		Description string `json:"description"`
+		Result      struct {
+			MessageID int64 `json:"message_id"`
+		} `json:"result"`
+		Parameters struct {
+			RetryAfter int `json:"retry_after"`
+		} `json:"parameters"`
+	}
+)
+
+// New - создаёт объект Client для бота с указанным токеном.
+func New(token string, opts ...Option) *Client {
+	o := options{
+		client: &Client{
+			httpClient: &http.Client{
+				Timeout: defaultTimeout,
+			},
+		},
+		baseURL: defaultBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	o.client.url = strings.TrimRight(o.baseURL, "/") + "/bot" + token + "/sendMessage"
+
+	return o.client
+}
+
+// SendToChat - отправляет текстовое сообщение без разметки в указанный чат.
+func (c *Client) SendToChat(ctx context.Context, chatID, message string) error {
+	return c.SendMessage(ctx, messengergate.Message{ChatID: chatID, Text: message})
+}
+
+// SendMessage - отправляет сообщение в чат.
+// ID сообщения, назначенный Telegram, записывается в mrmailer.DeliveryReceipt контекста.
+func (c *Client) SendMessage(ctx context.Context, message messengergate.Message) error {
+	request, err := c.makeRequestBody(message)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return errors.WrapInternalError(err, "marshal telegram request failed")
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapInternalError(err, "create telegram request failed")
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	response, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		// ошибка клиента содержит URL с токеном бота, поэтому передаётся только её причина
+		var urlErr *url.Error
+
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		return senderror.Classify(err, "chatId", message.ChatID)
+	}
+
+	defer response.Body.Close()
+
+	responseData, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBodyLen))
+
+	var result responseBody
+
+	if json.Unmarshal(responseData, &result) == nil && result.OK {
+		if result.Result.MessageID > 0 {
+			mrmailer.SetProviderMessageID(ctx, strconv.FormatInt(result.Result.MessageID, 10))
+		}
+
+		return nil
+	}
+
+	statusErr := &senderror.StatusError{
+		Status:     response.StatusCode,
+		RetryAfter: time.Duration(result.Parameters.RetryAfter) * time.Second,
+		Response:   result.Description,
+	}
+
+	if result.ErrorCode > 0 {
+		statusErr.Status = result.ErrorCode
+	}
+
+	if statusErr.Response == "" {
+		statusErr.Response = string(responseData)
+	}
+
+	return senderror.Classify(statusErr, "chatId", message.ChatID)
+}
+
+func (c *Client) makeRequestBody(message messengergate.Message) (requestBody, error) {
+	request := requestBody{
+		ChatID:              message.ChatID,
+		Text:                message.Text,
+		DisableNotification: message.Silent,
+	}
+
+	if message.ParseMode != messengergate.ParseModePlain {
+		request.ParseMode = message.ParseMode
+	}
+
+	if message.ThreadID != "" {
+		threadID, err := strconv.ParseInt(message.ThreadID, 10, 64)
+		if err != nil {
+			return requestBody{}, errors.ErrInternalIncorrectInputData.WithDetails("thread id must be an integer: " + message.ThreadID)
+		}
+
+		request.MessageThreadID = threadID
+	}
+
+	if message.DisableLinkPreview {
+		request.LinkPreviewOptions = &linkPreviewOptions{IsDisabled: true}
+	}
+
+	if len(message.Buttons) > 0 {
+		request.ReplyMarkup = &replyMarkup{
+			InlineKeyboard: make([][]inlineButton, len(message.Buttons)),
+		}
+
+		for i, row := range message.Buttons {
+			request.ReplyMarkup.InlineKeyboard[i] = make([]inlineButton, len(row))
+
+			for j, button := range row {
+				request.ReplyMarkup.InlineKeyboard[i][j] = inlineButton{Text: button.Text, URL: button.URL}
+			}
+		}
+	}
+
+	return request, nil
+}
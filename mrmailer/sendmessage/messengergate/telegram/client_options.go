@@ -0,0 +1,30 @@
+package telegram
+
+import (
+	"net/http"
+)
+
+type (
+	// Option - настройка объекта Client.
+	Option func(o *options)
+
+	options struct {
+		client  *Client
+		baseURL string
+	}
+)
+
+// WithHTTPClient - устанавливает HTTP клиента, через которого выполняются запросы к Bot API.
+func WithHTTPClient(value *http.Client) Option {
+	return func(o *options) {
+		o.client.httpClient = value
+	}
+}
+
+// WithBaseURL - устанавливает адрес сервера Bot API (по умолчанию https://api.telegram.org),
+// например, при использовании локального сервера Bot API.
+func WithBaseURL(value string) Option {
+	return func(o *options) {
+		o.baseURL = value
+	}
+}
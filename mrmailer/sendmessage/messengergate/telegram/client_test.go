@@ -0,0 +1,135 @@
+package telegram_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mondegor/go-core/errors/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/messengergate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/messengergate/telegram"
+)
+
+func TestClient_SendMessage(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/botBOT-TOKEN/sendMessage", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":42}}`))
+	}))
+	defer server.Close()
+
+	client := telegram.New("BOT-TOKEN", telegram.WithBaseURL(server.URL))
+
+	ctx, receipt := mrmailer.WithDeliveryReceipt(context.Background())
+
+	err := client.SendMessage(
+		ctx,
+		messengergate.Message{
+			ChatID:             "-100500",
+			ThreadID:           "7",
+			Text:               "<b>Заказ оплачен</b>",
+			ParseMode:          messengergate.ParseModeHTML,
+			Buttons:            [][]messengergate.Button{{{Text: "Открыть", URL: "https://localhost/order/1"}}},
+			DisableLinkPreview: true,
+			Silent:             true,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[string]any{
+			"chat_id":              "-100500",
+			"message_thread_id":    float64(7),
+			"text":                 "<b>Заказ оплачен</b>",
+			"parse_mode":           "HTML",
+			"link_preview_options": map[string]any{"is_disabled": true},
+			"disable_notification": true,
+			"reply_markup": map[string]any{
+				"inline_keyboard": []any{
+					[]any{map[string]any{"text": "Открыть", "url": "https://localhost/order/1"}},
+				},
+			},
+		},
+		received,
+	)
+	assert.Equal(t, "42", receipt.ProviderMessageID())
+}
+
+func TestClient_SendToChat(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	require.NoError(t, telegram.New("BOT-TOKEN", telegram.WithBaseURL(server.URL)).SendToChat(context.Background(), "100500", "text"))
+	assert.Equal(t, map[string]any{"chat_id": "100500", "text": "text"}, received)
+}
+
+func TestClient_SendMessage_ErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    int
+		response  string
+		temporary bool
+	}{
+		{
+			name:     "chat not found is permanent",
+			status:   http.StatusBadRequest,
+			response: `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`,
+		},
+		{
+			name:     "bot blocked is permanent",
+			status:   http.StatusForbidden,
+			response: `{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`,
+		},
+		{
+			name:      "flood control is temporary",
+			status:    http.StatusTooManyRequests,
+			response:  `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 5","parameters":{"retry_after":5}}`,
+			temporary: true,
+		},
+		{
+			name:      "bad gateway is temporary",
+			status:    http.StatusBadGateway,
+			response:  `<html>502 Bad Gateway</html>`,
+			temporary: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			err := telegram.New("BOT-TOKEN", telegram.WithBaseURL(server.URL)).SendToChat(context.Background(), "100500", "text")
+			require.Error(t, err)
+			assert.Equal(t, tt.temporary, kind.Extract(err) == kind.System)
+			assert.NotContains(t, err.Error(), "BOT-TOKEN")
+		})
+	}
+}
@@ -12,6 +12,8 @@ type (
 		clientMail      mrmailer.MessageSender
 		clientMessenger mrmailer.MessageSender
 		clientSMS       mrmailer.MessageSender
+		clientWebhook   mrmailer.MessageSender
+		clientPush      mrmailer.MessageSender
 	}
 )
 
@@ -37,6 +39,14 @@ func New(opts ...Option) sendmessage.SenderProvider {
 		if o.sender.clientSMS != nil {
 			o.sender.clientSMS = newTraceWrapper(o.tracer, "clientSMS", o.sender.clientSMS)
 		}
+
+		if o.sender.clientWebhook != nil {
+			o.sender.clientWebhook = newTraceWrapper(o.tracer, "clientWebhook", o.sender.clientWebhook)
+		}
+
+		if o.sender.clientPush != nil {
+			o.sender.clientPush = newTraceWrapper(o.tracer, "clientPush", o.sender.clientPush)
+		}
 	}
 
 	return o.sender
@@ -74,6 +84,26 @@ func (p *messageSender) Sender(data entity.MessageData) (mrmailer.MessageSender,
 		return p.clientSMS, nil
 	}
 
+	if data.Webhook != nil {
+		if p.clientWebhook == nil {
+			return nil, mrmailer.ErrInternalProviderClientNotSpecified.New(
+				"type", "webhook",
+			)
+		}
+
+		return p.clientWebhook, nil
+	}
+
+	if data.Push != nil {
+		if p.clientPush == nil {
+			return nil, mrmailer.ErrInternalProviderClientNotSpecified.New(
+				"type", "push",
+			)
+		}
+
+		return p.clientPush, nil
+	}
+
 	return nil, mrmailer.ErrInternalProviderClientNotSpecified.New(
 		"type", "unknown",
 	)
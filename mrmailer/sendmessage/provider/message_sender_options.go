@@ -37,6 +37,20 @@ func WithClientSMS(value mrmailer.MessageSender) Option {
 	}
 }
 
+// WithClientWebhook - устанавливает клиента, для возможности отправки веб-хуков во внешние системы (например, webhook.Sender).
+func WithClientWebhook(value mrmailer.MessageSender) Option {
+	return func(o *options) {
+		o.sender.clientWebhook = value
+	}
+}
+
+// WithClientPush - устанавливает клиента, для возможности отправки push уведомлений на мобильные устройства.
+func WithClientPush(value mrmailer.MessageSender) Option {
+	return func(o *options) {
+		o.sender.clientPush = value
+	}
+}
+
 // WithTracer - устанавливает трейсинг отправки сообщений.
 func WithTracer(tracer mrtrace.Tracer) Option {
 	return func(o *options) {
@@ -0,0 +1,215 @@
+package apns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/pushgate"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	maxResponseBodyLen = 512
+
+	pushTypeAlert      = "alert"
+	pushTypeBackground = "background"
+	priorityImmediate  = "10"
+	priorityBackground = "5"
+
+	reasonBadDeviceToken         = "BadDeviceToken"
+	reasonDeviceTokenNotForTopic = "DeviceTokenNotForTopic"
+	reasonExpiredProviderToken   = "ExpiredProviderToken"
+
+	headerNotificationID = "apns-id"
+	maxCollapseIDLen     = 64
+)
+
+type (
+	// Client - клиент шлюза push уведомлений в формате APNs (HTTP/2 provider API).
+	// Отправляет POST запрос {"aps": {"alert": {...}}, ...data} на URL {baseURL}/3/device/{token}
+	// с заголовками apns-topic, apns-push-type, apns-priority, apns-expiration и apns-collapse-id.
+	// Протокол HTTP/2 согласуется HTTP клиентом автоматически при TLS соединении.
+	// Ответы 410 и 400 с причинами BadDeviceToken, DeviceTokenNotForTopic означают недействительный токен устройства,
+	// ответы 429, 5xx и 403 ExpiredProviderToken, а также сетевые ошибки считаются временными (System),
+	// остальные ответы - окончательным отказом шлюза.
+	Client struct {
+		httpClient *http.Client
+		baseURL    string
+		topic      string
+		authToken  func(ctx context.Context) (string, error) // OPTIONAL
+		nowFunc    func() time.Time
+	}
+
+	alert struct {
+		Title string `json:"title,omitempty"`
+		Body  string `json:"body,omitempty"`
+	}
+
+	aps struct {
+		Alert            *alert `json:"alert,omitempty"`
+		ContentAvailable int    `json:"content-available,omitempty"`
+	}
+
+	errorBody struct {
+		Reason string `json:"reason"`
+	}
+)
+
+// New - создаёт объект Client.
+// В baseURL указывается адрес шлюза (например, https://api.push.apple.com),
+// в topic - идентификатор приложения (bundle ID).
+func New(baseURL, topic string, opts ...Option) *Client {
+	o := options{
+		client: &Client{
+			httpClient: &http.Client{
+				Timeout: defaultTimeout,
+			},
+			baseURL: baseURL,
+			topic:   topic,
+			nowFunc: time.Now,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.client
+}
+
+// SendPush - отправляет push уведомление на устройство.
+// ID уведомления, назначенный шлюзом, записывается в mrmailer.DeliveryReceipt контекста.
+func (c *Client) SendPush(ctx context.Context, n pushgate.Notification) error {
+	body, err := c.makeRequestBody(n)
+	if err != nil {
+		return errors.WrapInternalError(err, "marshal push request failed")
+	}
+
+	requestURL := c.baseURL + "/3/device/" + url.PathEscape(n.DeviceToken)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapInternalError(err, "create push request failed", "url", c.baseURL)
+	}
+
+	if err = c.setHeaders(ctx, request.Header, n); err != nil {
+		return err
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return mrmailer.ErrSystemPushGatewayUnavailable.Wrap(err, "url", c.baseURL)
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusOK {
+		_, _ = io.Copy(io.Discard, response.Body)
+
+		if id := response.Header.Get(headerNotificationID); id != "" {
+			mrmailer.SetProviderMessageID(ctx, id)
+		}
+
+		return nil
+	}
+
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBodyLen))
+
+	var reason errorBody
+
+	_ = json.Unmarshal(responseBody, &reason)
+
+	if c.isTokenInvalid(response.StatusCode, reason.Reason) {
+		return mrmailer.ErrInternalPushTokenInvalid.New(
+			"status", response.StatusCode,
+			"reason", reason.Reason,
+		)
+	}
+
+	if c.isTemporaryStatus(response.StatusCode, reason.Reason) {
+		return mrmailer.ErrSystemPushGatewayUnavailable.New(
+			"status", response.StatusCode,
+			"response", string(responseBody),
+		)
+	}
+
+	return mrmailer.ErrInternalPushGatewayRejected.New(
+		"status", response.StatusCode,
+		"response", string(responseBody),
+	)
+}
+
+func (c *Client) makeRequestBody(n pushgate.Notification) ([]byte, error) {
+	payload := make(map[string]any, len(n.Data)+1)
+
+	// пользовательские данные передаются на верхнем уровне рядом с ключом aps
+	for key, value := range n.Data {
+		payload[key] = value
+	}
+
+	if n.IsBackground() {
+		payload["aps"] = aps{ContentAvailable: 1}
+	} else {
+		payload["aps"] = aps{Alert: &alert{Title: n.Title, Body: n.Body}}
+	}
+
+	return json.Marshal(payload)
+}
+
+func (c *Client) setHeaders(ctx context.Context, header http.Header, n pushgate.Notification) error {
+	header.Set("Content-Type", "application/json")
+	header.Set("apns-topic", c.topic)
+
+	if n.IsBackground() {
+		header.Set("apns-push-type", pushTypeBackground)
+		header.Set("apns-priority", priorityBackground)
+	} else {
+		header.Set("apns-push-type", pushTypeAlert)
+		header.Set("apns-priority", priorityImmediate)
+	}
+
+	if n.TTL > 0 {
+		header.Set("apns-expiration", strconv.FormatInt(c.nowFunc().Add(n.TTL).Unix(), 10))
+	}
+
+	if n.CollapseKey != "" && len(n.CollapseKey) <= maxCollapseIDLen {
+		header.Set("apns-collapse-id", n.CollapseKey)
+	}
+
+	if c.authToken != nil {
+		token, err := c.authToken(ctx)
+		if err != nil {
+			return mrmailer.ErrSystemPushGatewayUnavailable.Wrap(err, "url", c.baseURL)
+		}
+
+		header.Set("Authorization", "bearer "+token)
+	}
+
+	return nil
+}
+
+func (c *Client) isTokenInvalid(status int, reason string) bool {
+	if status == http.StatusGone {
+		return true
+	}
+
+	return status == http.StatusBadRequest && (reason == reasonBadDeviceToken || reason == reasonDeviceTokenNotForTopic)
+}
+
+func (c *Client) isTemporaryStatus(status int, reason string) bool {
+	// токен провайдера обновляется функцией WithAuthTokenFunc, после чего отправку можно повторить
+	if status == http.StatusForbidden && reason == reasonExpiredProviderToken {
+		return true
+	}
+
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
@@ -0,0 +1,40 @@
+package apns
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// Option - настройка объекта Client.
+	Option func(o *options)
+
+	options struct {
+		client *Client
+	}
+)
+
+// WithHTTPClient - устанавливает HTTP клиента, через которого выполняются запросы к шлюзу.
+func WithHTTPClient(value *http.Client) Option {
+	return func(o *options) {
+		o.client.httpClient = value
+	}
+}
+
+// WithAuthToken - устанавливает постоянный токен, передаваемый шлюзу в заголовке Authorization.
+func WithAuthToken(value string) Option {
+	return func(o *options) {
+		o.client.authToken = func(_ context.Context) (string, error) {
+			return value, nil
+		}
+	}
+}
+
+// WithAuthTokenFunc - устанавливает функцию получения токена, передаваемого шлюзу в заголовке Authorization
+// (JWT токен провайдера, подписанный ключом ES256), функция вызывается перед каждым запросом
+// и должна сама кэшировать и обновлять токен.
+func WithAuthTokenFunc(value func(ctx context.Context) (string, error)) Option {
+	return func(o *options) {
+		o.client.authToken = value
+	}
+}
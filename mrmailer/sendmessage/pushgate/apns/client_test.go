@@ -0,0 +1,148 @@
+package apns_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mondegor/go-core/errors/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/pushgate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/pushgate/apns"
+)
+
+// newServer - создаёт локальный шлюз, принимающий запросы по HTTP/2 через TLS.
+func newServer(handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewUnstartedServer(handler)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+
+	return server
+}
+
+func TestClient_SendPush(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]any
+
+	server := newServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, 2, r.ProtoMajor)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/3/device/device-token", r.URL.Path)
+		assert.Equal(t, "com.example.app", r.Header.Get("apns-topic"))
+		assert.Equal(t, "alert", r.Header.Get("apns-push-type"))
+		assert.Equal(t, "10", r.Header.Get("apns-priority"))
+		assert.Equal(t, "order-1001", r.Header.Get("apns-collapse-id"))
+		assert.Equal(t, "bearer provider-token", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		w.Header().Set("apns-id", "EC1BF194-B3B2-424A-89A9-5A918A6E6B5D")
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	client := apns.New(
+		server.URL,
+		"com.example.app",
+		apns.WithHTTPClient(server.Client()),
+		apns.WithAuthTokenFunc(func(_ context.Context) (string, error) {
+			return "provider-token", nil
+		}),
+	)
+
+	ctx, receipt := mrmailer.WithDeliveryReceipt(context.Background())
+
+	err := client.SendPush(
+		ctx,
+		pushgate.Notification{
+			DeviceToken: "device-token",
+			Title:       "Заказ оплачен",
+			Body:        "Заказ №1001 оплачен",
+			Data:        map[string]string{"orderId": "1001"},
+			CollapseKey: "order-1001",
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[string]any{
+			"aps": map[string]any{
+				"alert": map[string]any{
+					"title": "Заказ оплачен",
+					"body":  "Заказ №1001 оплачен",
+				},
+			},
+			"orderId": "1001",
+		},
+		received,
+	)
+	assert.Equal(t, "EC1BF194-B3B2-424A-89A9-5A918A6E6B5D", receipt.ProviderMessageID())
+}
+
+func TestClient_SendPush_Background(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(func(w http.ResponseWriter, r *http.Request) {
+		var received map[string]any
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		assert.Equal(t, map[string]any{"content-available": float64(1)}, received["aps"])
+		assert.Equal(t, "background", r.Header.Get("apns-push-type"))
+		assert.Equal(t, "5", r.Header.Get("apns-priority"))
+
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	client := apns.New(server.URL, "com.example.app", apns.WithHTTPClient(server.Client()))
+
+	err := client.SendPush(context.Background(), pushgate.Notification{DeviceToken: "token", Data: map[string]string{"sync": "1"}})
+	require.NoError(t, err)
+}
+
+func TestClient_SendPush_ErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		status       int
+		reason       string
+		temporary    bool
+		tokenInvalid bool
+	}{
+		{name: "unregistered token is permanent", status: http.StatusGone, reason: "Unregistered", tokenInvalid: true},
+		{name: "bad device token is permanent", status: http.StatusBadRequest, reason: "BadDeviceToken", tokenInvalid: true},
+		{name: "bad topic is permanent", status: http.StatusBadRequest, reason: "BadTopic"},
+		{name: "expired provider token is temporary", status: http.StatusForbidden, reason: "ExpiredProviderToken", temporary: true},
+		{name: "too many requests is temporary", status: http.StatusTooManyRequests, reason: "TooManyRequests", temporary: true},
+		{name: "service unavailable is temporary", status: http.StatusServiceUnavailable, reason: "ServiceUnavailable", temporary: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newServer(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"reason":"` + tt.reason + `"}`))
+			})
+			defer server.Close()
+
+			client := apns.New(server.URL, "com.example.app", apns.WithHTTPClient(server.Client()))
+
+			err := client.SendPush(context.Background(), pushgate.Notification{DeviceToken: "token", Body: "text"})
+			require.Error(t, err)
+			assert.Equal(t, tt.temporary, kind.Extract(err) == kind.System)
+
+			if tt.tokenInvalid {
+				assert.ErrorContains(t, err, "push device token is invalid or unregistered")
+			}
+		})
+	}
+}
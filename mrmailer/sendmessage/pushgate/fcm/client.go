@@ -0,0 +1,208 @@
+package fcm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/pushgate"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	maxResponseBodyLen = 2048
+
+	errorCodeUnregistered     = "UNREGISTERED"
+	errorCodeSenderIDMismatch = "SENDER_ID_MISMATCH"
+	errorDetailTypeFcmError   = "type.googleapis.com/google.firebase.fcm.v1.FcmError"
+
+	androidPriorityHigh   = "HIGH"
+	androidPriorityNormal = "NORMAL" // для фоновых уведомлений
+)
+
+type (
+	// Client - клиент шлюза push уведомлений в формате FCM HTTP v1.
+	// Отправляет POST запрос {"message": {"token": "...", "notification": {...}, "data": {...}, "android": {...}}}
+	// на URL вида https://fcm.googleapis.com/v1/projects/{project_id}/messages:send.
+	// Ответы с кодом ошибки UNREGISTERED и SENDER_ID_MISMATCH означают недействительный токен устройства,
+	// ответы 408, 429 и 5xx, а также сетевые ошибки считаются временными (System),
+	// остальные ответы - окончательным отказом шлюза.
+	Client struct {
+		httpClient *http.Client
+		url        string
+		authToken  func(ctx context.Context) (string, error) // OPTIONAL
+	}
+
+	requestBody struct {
+		Message message `json:"message"`
+	}
+
+	message struct {
+		Token        string            `json:"token"`
+		Notification *notification     `json:"notification,omitempty"`
+		Data         map[string]string `json:"data,omitempty"`
+		Android      android           `json:"android"`
+	}
+
+	notification struct {
+		Title string `json:"title,omitempty"`
+		Body  string `json:"body,omitempty"`
+	}
+
+	android struct {
+		Priority    string `json:"priority"`
+		TTL         string `json:"ttl,omitempty"`
+		CollapseKey string `json:"collapse_key,omitempty"`
+	}
+
+	successBody struct {
+		Name string `json:"name"`
+	}
+
+	errorBody struct {
+		Error struct {
+			Details []struct {
+				Type      string `json:"@type"`
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+)
+
+// New - создаёт объект Client.
+func New(url string, opts ...Option) *Client {
+	o := options{
+		client: &Client{
+			httpClient: &http.Client{
+				Timeout: defaultTimeout,
+			},
+			url: url,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.client
+}
+
+// SendPush - отправляет push уведомление на устройство.
+// Имя сообщения, назначенное шлюзом, записывается в mrmailer.DeliveryReceipt контекста.
+func (c *Client) SendPush(ctx context.Context, n pushgate.Notification) error {
+	body, err := json.Marshal(c.makeRequestBody(n))
+	if err != nil {
+		return errors.WrapInternalError(err, "marshal push request failed")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapInternalError(err, "create push request failed", "url", c.url)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	if c.authToken != nil {
+		token, err := c.authToken(ctx)
+		if err != nil {
+			return mrmailer.ErrSystemPushGatewayUnavailable.Wrap(err, "url", c.url)
+		}
+
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return mrmailer.ErrSystemPushGatewayUnavailable.Wrap(err, "url", c.url)
+	}
+
+	defer response.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBodyLen))
+
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		var success successBody
+
+		if json.Unmarshal(responseBody, &success) == nil && success.Name != "" {
+			mrmailer.SetProviderMessageID(ctx, success.Name)
+		}
+
+		return nil
+	}
+
+	if errorCode := c.parseErrorCode(responseBody); errorCode == errorCodeUnregistered || errorCode == errorCodeSenderIDMismatch {
+		return mrmailer.ErrInternalPushTokenInvalid.New(
+			"status", response.StatusCode,
+			"reason", errorCode,
+		)
+	}
+
+	if c.isTemporaryStatus(response.StatusCode) {
+		return mrmailer.ErrSystemPushGatewayUnavailable.New(
+			"status", response.StatusCode,
+			"response", string(responseBody),
+		)
+	}
+
+	return mrmailer.ErrInternalPushGatewayRejected.New(
+		"status", response.StatusCode,
+		"response", string(responseBody),
+	)
+}
+
+func (c *Client) makeRequestBody(n pushgate.Notification) requestBody {
+	msg := message{
+		Token: n.DeviceToken,
+		Data:  n.Data,
+		Android: android{
+			Priority:    androidPriorityHigh,
+			CollapseKey: n.CollapseKey,
+		},
+	}
+
+	if n.IsBackground() {
+		msg.Android.Priority = androidPriorityNormal
+	} else {
+		msg.Notification = &notification{
+			Title: n.Title,
+			Body:  n.Body,
+		}
+	}
+
+	if n.TTL > 0 {
+		// длительность в формате protobuf Duration: "3600s"
+		msg.Android.TTL = strconv.FormatInt(int64(n.TTL/time.Second), 10) + "s"
+	}
+
+	return requestBody{Message: msg}
+}
+
+// parseErrorCode - возвращает код ошибки FCM из тела ответа (если он указан).
+func (c *Client) parseErrorCode(responseBody []byte) string {
+	var body errorBody
+
+	if err := json.Unmarshal(responseBody, &body); err != nil {
+		return ""
+	}
+
+	for _, detail := range body.Error.Details {
+		if detail.Type == errorDetailTypeFcmError && detail.ErrorCode != "" {
+			return detail.ErrorCode
+		}
+	}
+
+	return ""
+}
+
+func (c *Client) isTemporaryStatus(status int) bool {
+	return status == http.StatusRequestTimeout ||
+		status == http.StatusTooManyRequests ||
+		status >= http.StatusInternalServerError
+}
@@ -0,0 +1,127 @@
+package fcm_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mondegor/go-core/errors/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/pushgate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/pushgate/fcm"
+)
+
+func TestClient_SendPush(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		_, _ = w.Write([]byte(`{"name":"projects/app/messages/0:123"}`))
+	}))
+	defer server.Close()
+
+	client := fcm.New(server.URL, fcm.WithAuthToken("access-token"))
+
+	ctx, receipt := mrmailer.WithDeliveryReceipt(context.Background())
+
+	err := client.SendPush(
+		ctx,
+		pushgate.Notification{
+			DeviceToken: "device-token",
+			Title:       "Заказ оплачен",
+			Body:        "Заказ №1001 оплачен",
+			Data:        map[string]string{"orderId": "1001"},
+			TTL:         time.Hour,
+			CollapseKey: "order-1001",
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[string]any{
+			"message": map[string]any{
+				"token": "device-token",
+				"notification": map[string]any{
+					"title": "Заказ оплачен",
+					"body":  "Заказ №1001 оплачен",
+				},
+				"data": map[string]any{"orderId": "1001"},
+				"android": map[string]any{
+					"priority":     "HIGH",
+					"ttl":          "3600s",
+					"collapse_key": "order-1001",
+				},
+			},
+		},
+		received,
+	)
+	assert.Equal(t, "projects/app/messages/0:123", receipt.ProviderMessageID())
+}
+
+func TestClient_SendPush_ErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		status       int
+		response     string
+		temporary    bool
+		tokenInvalid bool
+	}{
+		{
+			name:         "unregistered token is permanent",
+			status:       http.StatusNotFound,
+			response:     `{"error":{"code":404,"status":"NOT_FOUND","details":[{"@type":"type.googleapis.com/google.firebase.fcm.v1.FcmError","errorCode":"UNREGISTERED"}]}}`,
+			tokenInvalid: true,
+		},
+		{
+			name:     "invalid argument is permanent",
+			status:   http.StatusBadRequest,
+			response: `{"error":{"code":400,"status":"INVALID_ARGUMENT"}}`,
+		},
+		{
+			name:      "quota exceeded is temporary",
+			status:    http.StatusTooManyRequests,
+			response:  `{"error":{"code":429,"status":"RESOURCE_EXHAUSTED"}}`,
+			temporary: true,
+		},
+		{
+			name:      "unavailable is temporary",
+			status:    http.StatusServiceUnavailable,
+			response:  `{"error":{"code":503,"status":"UNAVAILABLE"}}`,
+			temporary: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			err := fcm.New(server.URL).SendPush(context.Background(), pushgate.Notification{DeviceToken: "token", Body: "text"})
+			require.Error(t, err)
+			assert.Equal(t, tt.temporary, kind.Extract(err) == kind.System)
+
+			if tt.tokenInvalid {
+				assert.ErrorContains(t, err, "push device token is invalid or unregistered")
+			}
+		})
+	}
+}
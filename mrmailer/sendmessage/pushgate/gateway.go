@@ -0,0 +1,72 @@
+package pushgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	maxDeviceTokenLen = 4096
+)
+
+var (
+	// ErrNotificationInvalid - push уведомление некорректно.
+	ErrNotificationInvalid = errors.New("push notification is invalid")
+)
+
+type (
+	// Gateway - клиент шлюза push уведомлений (FCM, APNs и т.д.).
+	// Ошибки, после которых отправку имеет смысл повторить, должны быть типа System,
+	// а недействительный токен устройства должен возвращаться как mrmailer.ErrInternalPushTokenInvalid.
+	Gateway interface {
+		SendPush(ctx context.Context, notification Notification) error
+	}
+
+	// Notification - push уведомление, передаваемое шлюзу.
+	Notification struct {
+		DeviceToken string
+		Title       string
+		Body        string
+		Data        map[string]string
+		TTL         time.Duration // 0 - время жизни по умолчанию шлюза
+		CollapseKey string
+	}
+)
+
+// NewNotification - создаёт уведомление для шлюза из данных сообщения.
+func NewNotification(data *entity.DataPush) Notification {
+	return Notification{
+		DeviceToken: data.DeviceToken,
+		Title:       data.Title,
+		Body:        data.Body,
+		Data:        data.Data,
+		TTL:         time.Duration(data.TTL) * time.Second,
+		CollapseKey: data.CollapseKey,
+	}
+}
+
+// IsBackground - сообщает, является ли уведомление фоновым (без заголовка и текста).
+func (n Notification) IsBackground() bool {
+	return n.Title == "" && n.Body == ""
+}
+
+// Validate - проверяет, что у уведомления указан токен устройства и есть что отправлять.
+func Validate(data *entity.DataPush) error {
+	if data == nil {
+		return fmt.Errorf("%w: data is nil", ErrNotificationInvalid)
+	}
+
+	if data.DeviceToken == "" || len(data.DeviceToken) > maxDeviceTokenLen {
+		return fmt.Errorf("%w: device token is empty or too long", ErrNotificationInvalid)
+	}
+
+	if data.Title == "" && data.Body == "" && len(data.Data) == 0 {
+		return fmt.Errorf("%w: title, body and data are empty", ErrNotificationInvalid)
+	}
+
+	return nil
+}
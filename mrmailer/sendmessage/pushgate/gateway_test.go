@@ -0,0 +1,40 @@
+package pushgate_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/pushgate"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, pushgate.Validate(&entity.DataPush{DeviceToken: "token", Title: "title"}))
+	require.NoError(t, pushgate.Validate(&entity.DataPush{DeviceToken: "token", Data: map[string]string{"sync": "1"}}))
+
+	invalid := []*entity.DataPush{
+		nil,
+		{Title: "title"},
+		{DeviceToken: strings.Repeat("a", 4097), Title: "title"},
+		{DeviceToken: "token"},
+	}
+
+	for _, data := range invalid {
+		require.ErrorIs(t, pushgate.Validate(data), pushgate.ErrNotificationInvalid)
+	}
+}
+
+func TestNewNotification(t *testing.T) {
+	t.Parallel()
+
+	n := pushgate.NewNotification(&entity.DataPush{DeviceToken: "token", Data: map[string]string{"sync": "1"}, TTL: 60})
+
+	assert.Equal(t, time.Minute, n.TTL)
+	assert.True(t, n.IsBackground())
+}
@@ -0,0 +1,131 @@
+package routing
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	prefixSuffix = "**"
+)
+
+type (
+	// Rule - правило выбора отправителя по каналу сообщения (entity.Message.Channel).
+	// Pattern может быть:
+	//   - точным значением канала: "email/notifier/SignUp/en-US";
+	//   - префиксом канала, который оканчивается на **: "email/marketing/**";
+	//   - шаблоном, в котором * и ? не выходят за пределы сегмента канала (см. path.Match): "email/notifier/*/ru-RU".
+	Rule struct {
+		Pattern string
+		Sender  string
+	}
+
+	// Router - отправитель сообщений, выбирающий конкретного отправителя по каналу сообщения.
+	// Правила проверяются в порядке их указания, используется первое подходящее правило,
+	// если ни одно из правил не подошло, то используется отправитель по умолчанию.
+	Router struct {
+		rules         []compiledRule
+		defaultSender mrmailer.MessageSender // OPTIONAL
+	}
+
+	compiledRule struct {
+		match  func(channel string) bool
+		sender mrmailer.MessageSender
+	}
+)
+
+// New - создаёт объект Router.
+// В senders указываются именованные отправители, на которые ссылаются правила и defaultSender
+// (пустое значение defaultSender означает отсутствие отправителя по умолчанию).
+func New(senders map[string]mrmailer.MessageSender, rules []Rule, defaultSender string) (*Router, error) {
+	r := &Router{
+		rules: make([]compiledRule, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		match, err := CompilePattern(rule.Pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		sender, ok := senders[rule.Sender]
+		if !ok || sender == nil {
+			return nil, mrmailer.ErrInternalRoutingSenderNotFound.New("sender", rule.Sender)
+		}
+
+		r.rules = append(r.rules, compiledRule{match: match, sender: sender})
+	}
+
+	if defaultSender != "" {
+		sender, ok := senders[defaultSender]
+		if !ok || sender == nil {
+			return nil, mrmailer.ErrInternalRoutingSenderNotFound.New("sender", defaultSender)
+		}
+
+		r.defaultSender = sender
+	}
+
+	return r, nil
+}
+
+// Send - отправляет сообщение через отправителя, выбранного по каналу сообщения.
+func (r *Router) Send(ctx context.Context, message entity.Message) error {
+	sender := r.route(message.Channel)
+	if sender == nil {
+		return mrmailer.ErrInternalProviderClientNotSpecified.New(
+			"channel", message.Channel,
+			"type", "routing",
+		)
+	}
+
+	return sender.Send(ctx, message)
+}
+
+func (r *Router) route(channel string) mrmailer.MessageSender {
+	for _, rule := range r.rules {
+		if rule.match(channel) {
+			return rule.sender
+		}
+	}
+
+	return r.defaultSender
+}
+
+// CompilePattern - создаёт функцию проверки соответствия канала сообщения шаблону
+// (форматы шаблона описаны в Rule.Pattern).
+func CompilePattern(pattern string) (func(channel string) bool, error) {
+	if pattern == "" {
+		return nil, mrmailer.ErrInternalRoutingPatternInvalid.New("pattern", pattern)
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, prefixSuffix); ok {
+		if strings.ContainsAny(prefix, "*?[\\") {
+			return nil, mrmailer.ErrInternalRoutingPatternInvalid.New("pattern", pattern)
+		}
+
+		return func(channel string) bool {
+			return strings.HasPrefix(channel, prefix)
+		}, nil
+	}
+
+	if !strings.ContainsAny(pattern, "*?[\\") {
+		return func(channel string) bool {
+			return channel == pattern
+		}, nil
+	}
+
+	// проверка корректности шаблона
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, mrmailer.ErrInternalRoutingPatternInvalid.Wrap(err, "pattern", pattern)
+	}
+
+	return func(channel string) bool {
+		ok, _ := path.Match(pattern, channel)
+
+		return ok
+	}, nil
+}
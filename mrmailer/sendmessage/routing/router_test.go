@@ -0,0 +1,97 @@
+package routing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/routing"
+)
+
+type fakeSender struct {
+	channels []string
+}
+
+func (s *fakeSender) Send(_ context.Context, message entity.Message) error {
+	s.channels = append(s.channels, message.Channel)
+
+	return nil
+}
+
+func TestRouter_Send(t *testing.T) {
+	t.Parallel()
+
+	transactional := &fakeSender{}
+	marketing := &fakeSender{}
+	russian := &fakeSender{}
+
+	router, err := routing.New(
+		map[string]mrmailer.MessageSender{
+			"transactional": transactional,
+			"marketing":     marketing,
+			"russian":       russian,
+		},
+		[]routing.Rule{
+			{Pattern: "email/marketing/**", Sender: "marketing"},
+			{Pattern: "email/notifier/*/ru-RU", Sender: "russian"},
+			{Pattern: "email/invoice", Sender: "marketing"},
+		},
+		"transactional",
+	)
+	require.NoError(t, err)
+
+	channels := []string{
+		"email/marketing/spring/en-US",
+		"email/notifier/SignUp/ru-RU",
+		"email/notifier/SignUp/en-US",
+		"email/invoice",
+		"email/invoice/copy",
+	}
+
+	for _, channel := range channels {
+		require.NoError(t, router.Send(context.Background(), entity.Message{Channel: channel}))
+	}
+
+	assert.Equal(t, []string{"email/marketing/spring/en-US", "email/invoice"}, marketing.channels)
+	assert.Equal(t, []string{"email/notifier/SignUp/ru-RU"}, russian.channels)
+	assert.Equal(t, []string{"email/notifier/SignUp/en-US", "email/invoice/copy"}, transactional.channels)
+}
+
+func TestRouter_SendWithoutDefault(t *testing.T) {
+	t.Parallel()
+
+	router, err := routing.New(
+		map[string]mrmailer.MessageSender{"bot": &fakeSender{}},
+		[]routing.Rule{{Pattern: "messenger/support/**", Sender: "bot"}},
+		"",
+	)
+	require.NoError(t, err)
+
+	err = router.Send(context.Background(), entity.Message{Channel: "messenger/sales"})
+	require.ErrorIs(t, err, mrmailer.ErrInternalProviderClientNotSpecified)
+}
+
+func TestNew_Errors(t *testing.T) {
+	t.Parallel()
+
+	senders := map[string]mrmailer.MessageSender{"smtp": &fakeSender{}}
+
+	_, err := routing.New(senders, []routing.Rule{{Pattern: "email/**", Sender: "unknown"}}, "")
+	require.ErrorIs(t, err, mrmailer.ErrInternalRoutingSenderNotFound)
+
+	_, err = routing.New(senders, nil, "unknown")
+	require.ErrorIs(t, err, mrmailer.ErrInternalRoutingSenderNotFound)
+
+	_, err = routing.New(senders, []routing.Rule{{Pattern: "email/[a-", Sender: "smtp"}}, "")
+	require.ErrorIs(t, err, mrmailer.ErrInternalRoutingPatternInvalid)
+
+	_, err = routing.New(senders, []routing.Rule{{Pattern: "email/*/**", Sender: "smtp"}}, "")
+	require.ErrorIs(t, err, mrmailer.ErrInternalRoutingPatternInvalid)
+
+	_, err = routing.New(senders, []routing.Rule{{Pattern: "", Sender: "smtp"}}, "")
+	require.ErrorIs(t, err, mrmailer.ErrInternalRoutingPatternInvalid)
+}
@@ -0,0 +1,140 @@
+package senderror
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+
+	"github.com/mondegor/go-core/errors"
+	"github.com/mondegor/go-core/errors/kind"
+
+	"github.com/mondegor/go-components/mrmailer"
+)
+
+const (
+	// SMTP ответ 421 - сервис недоступен, сервер закрывает соединение.
+	smtpCodeServiceNotAvailable = 421
+)
+
+type (
+	// statusCoder - ошибка ответа HTTP сервиса, которая сообщает код статуса ответа
+	// (используется для ошибок клиентов, не основанных на StatusError).
+	statusCoder interface {
+		StatusCode() int
+	}
+)
+
+// Classify - определяет по ошибке клиента провайдера, имеет ли смысл повторять отправку сообщения,
+// и возвращает ошибку соответствующего типа: временную (System), после которой сообщение
+// будет отправлено повторно, или окончательную, после которой сообщение считается неотправленным:
+//   - ответы SMTP сервера 4xx временные, 5xx окончательные;
+//   - ответы HTTP сервиса 408, 425, 429, 5xx, а также ответы 4xx с заголовком Retry-After временные,
+//     остальные окончательные (см. StatusError);
+//   - сетевые ошибки и таймауты временные;
+//   - отмена контекста (отправка прервана самим сервисом, например, при его остановке)
+//     и остальные ошибки возвращаются без изменений.
+//
+// Исходная ошибка с текстом ответа провайдера сохраняется как причина возвращаемой ошибки.
+// Функция идемпотентна: ошибка, тип которой уже соответствует ответу провайдера, возвращается без изменений.
+func Classify(err error, attrs ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	var replyErr *textproto.Error
+	if errors.As(err, &replyErr) {
+		return classified(
+			err,
+			IsTemporarySMTPCode(replyErr.Code),
+			append(attrs, "code", replyErr.Code, "reply", replyErr.Msg)...,
+		)
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		attrs = append(attrs, "status", statusErr.Status, "reply", statusErr.Response)
+
+		if statusErr.RetryAfter > 0 {
+			attrs = append(attrs, "retryAfter", statusErr.RetryAfter)
+		}
+
+		return classified(err, IsTemporaryStatus(statusErr.Status, statusErr.RetryAfter > 0), attrs...)
+	}
+
+	var coder statusCoder
+	if errors.As(err, &coder) {
+		return classified(err, IsTemporaryStatus(coder.StatusCode(), false), append(attrs, "status", coder.StatusCode())...)
+	}
+
+	// клиенты HTTP и SMTP оборачивают отмену контекста в сетевую ошибку
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	if isNetworkError(err) {
+		return classified(err, true, attrs...)
+	}
+
+	return err
+}
+
+// IsTemporarySMTPCode - сообщает, является ли код ответа SMTP сервера временной ошибкой (4xx).
+func IsTemporarySMTPCode(code int) bool {
+	return code >= 400 && code < 500
+}
+
+// IsTemporaryStatus - сообщает, является ли код статуса ответа HTTP сервиса временной ошибкой:
+// 408, 425, 429, 5xx, а также любой ответ 4xx, в котором сервис указал Retry-After.
+func IsTemporaryStatus(status int, hasRetryAfter bool) bool {
+	switch {
+	case status == http.StatusRequestTimeout,
+		status == http.StatusTooEarly,
+		status == http.StatusTooManyRequests,
+		status >= http.StatusInternalServerError:
+		return true
+	case status >= http.StatusBadRequest:
+		return hasRetryAfter
+	default:
+		return false
+	}
+}
+
+// IsRetainedConnection - сообщает, остаётся ли SMTP сессия рабочей после указанной ошибки
+// (сервер ответил отказом, но не закрыл соединение), и её можно использовать для следующего письма.
+func IsRetainedConnection(err error) bool {
+	var replyErr *textproto.Error
+
+	return errors.As(err, &replyErr) && replyErr.Code != smtpCodeServiceNotAvailable
+}
+
+func classified(err error, temporary bool, attrs ...any) error {
+	isSystem := kind.Extract(err) == kind.System
+
+	if temporary {
+		if isSystem {
+			return err
+		}
+
+		return mrmailer.ErrSystemProviderUnavailable.Wrap(err, attrs...)
+	}
+
+	if !isSystem && errors.Is(err, mrmailer.ErrInternalProviderRejected) {
+		return err
+	}
+
+	return mrmailer.ErrInternalProviderRejected.Wrap(err, attrs...)
+}
+
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,106 @@
+package senderror_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mondegor/go-core/errors/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
+)
+
+type statusCodeError int
+
+func (e statusCodeError) Error() string   { return "status " + http.StatusText(int(e)) }
+func (e statusCodeError) StatusCode() int { return int(e) }
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		err       error
+		temporary bool
+	}{
+		{name: "smtp mailbox does not exist", err: &textproto.Error{Code: 550, Msg: "5.1.1 mailbox does not exist"}},
+		{name: "smtp service not available", err: &textproto.Error{Code: 421, Msg: "4.3.2 try again later"}, temporary: true},
+		{
+			name:      "smtp greylisting wrapped by client",
+			err:       fmt.Errorf("send mail: %w", &textproto.Error{Code: 451, Msg: "4.7.1 greylisted"}),
+			temporary: true,
+		},
+		{
+			name: "smtp rejection wrapped as system",
+			err:  mrmailer.ErrSystemProviderUnavailable.Wrap(&textproto.Error{Code: 554, Msg: "5.7.1 rejected"}),
+		},
+		{name: "http bad request", err: &senderror.StatusError{Status: http.StatusBadRequest}},
+		{name: "http too many requests", err: &senderror.StatusError{Status: http.StatusTooManyRequests}, temporary: true},
+		{name: "http forbidden with retry after", err: &senderror.StatusError{Status: http.StatusForbidden, RetryAfter: time.Minute}, temporary: true},
+		{name: "http status coder", err: statusCodeError(http.StatusServiceUnavailable), temporary: true},
+		{name: "context deadline", err: context.DeadlineExceeded, temporary: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := senderror.Classify(tt.err)
+			require.Error(t, err)
+			assert.Equal(t, tt.temporary, kind.Extract(err) == kind.System)
+			assert.ErrorIs(t, err, tt.err, "cause with provider reply is preserved")
+
+			// повторная классификация не меняет ошибку
+			assert.Equal(t, err, senderror.Classify(err))
+		})
+	}
+}
+
+func TestClassify_Unknown(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, senderror.Classify(nil))
+
+	err := errors.New("unknown")
+	assert.Equal(t, err, senderror.Classify(err))
+
+	// отмена контекста не считается временной ошибкой провайдера, даже если клиент обернул её в сетевую ошибку
+	canceled := &url.Error{Op: "Post", URL: "https://sms.localhost", Err: context.Canceled}
+	assert.Equal(t, canceled, senderror.Classify(canceled))
+	assert.Equal(t, context.Canceled, senderror.Classify(context.Canceled))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 120*time.Second, senderror.ParseRetryAfter("120", now))
+	assert.Equal(t, 90*time.Second, senderror.ParseRetryAfter("Mon, 19 Oct 2026 12:01:30 GMT", now))
+	assert.Equal(t, time.Duration(0), senderror.ParseRetryAfter("Mon, 19 Oct 2026 11:00:00 GMT", now))
+	assert.Equal(t, time.Duration(0), senderror.ParseRetryAfter("soon", now))
+	assert.Equal(t, time.Duration(0), senderror.ParseRetryAfter("", now))
+}
+
+func TestClassify_RetryDelay(t *testing.T) {
+	t.Parallel()
+
+	err := senderror.Classify(&senderror.StatusError{Status: http.StatusTooManyRequests, RetryAfter: 2 * time.Minute})
+	require.ErrorIs(t, err, mrmailer.ErrSystemProviderUnavailable)
+
+	// задержка доступна очереди через обёртку классифицированной ошибки (см. consume.QueueConsumer.Reject)
+	var delayer interface {
+		RetryDelay() time.Duration
+	}
+
+	require.ErrorAs(t, err, &delayer)
+	assert.Equal(t, 2*time.Minute, delayer.RetryDelay())
+}
@@ -0,0 +1,83 @@
+package senderror
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxResponseLen = 512
+)
+
+type (
+	// StatusError - неуспешный ответ HTTP сервиса провайдера (шлюза SMS, мессенджера и т.д.),
+	// по которому Classify определяет, имеет ли смысл повторять отправку.
+	StatusError struct {
+		Status     int
+		RetryAfter time.Duration // время, через которое сервис просит повторить запрос (из заголовка Retry-After)
+		Response   string        // начало тела ответа
+	}
+)
+
+// NewStatusError - создаёт объект StatusError по ответу сервиса, при этом читается начало тела ответа.
+func NewStatusError(response *http.Response) *StatusError {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseLen))
+
+	return &StatusError{
+		Status:     response.StatusCode,
+		RetryAfter: ParseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+		Response:   string(body),
+	}
+}
+
+// Error - возвращает описание ошибки с кодом статуса и ответом сервиса.
+func (e *StatusError) Error() string {
+	var buf strings.Builder
+
+	buf.WriteString("provider responded with status " + strconv.Itoa(e.Status))
+
+	if e.RetryAfter > 0 {
+		buf.WriteString(", retry after " + e.RetryAfter.String())
+	}
+
+	if e.Response != "" {
+		buf.WriteString(": " + e.Response)
+	}
+
+	return buf.String()
+}
+
+// StatusCode - возвращает код статуса ответа сервиса.
+func (e *StatusError) StatusCode() int {
+	return e.Status
+}
+
+// RetryDelay - возвращает время, раньше которого повторять отправку не имеет смысла (из заголовка Retry-After),
+// по нему очередь откладывает следующую попытку обработки сообщения (см. consume.QueueConsumer.Reject).
+func (e *StatusError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// ParseRetryAfter - возвращает задержку из значения заголовка Retry-After,
+// который задаётся в секундах или в виде даты HTTP (0, если значение не задано или некорректно).
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay.Round(time.Second)
+		}
+	}
+
+	return 0
+}
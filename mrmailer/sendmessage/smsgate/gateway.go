@@ -0,0 +1,12 @@
+package smsgate
+
+import "context"
+
+type (
+	// Gateway - клиент SMS шлюза, через который отправляются короткие сообщения на телефон.
+	// Ошибки, после которых отправку имеет смысл повторить, должны быть типа System,
+	// неуспешные ответы HTTP шлюза также можно возвращать в виде senderror.StatusError.
+	Gateway interface {
+		SendSMS(ctx context.Context, from, phone, text string) error
+	}
+)
@@ -0,0 +1,101 @@
+package httpgate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+)
+
+type (
+	// Client - клиент SMS шлюза с HTTP API. Отправляет POST запрос
+	// с JSON телом {"from": "...", "to": "...", "text": "..."} на указанный URL.
+	// Ответы 2xx считаются успешными, ответы 408, 425, 429, 5xx и 4xx с заголовком Retry-After,
+	// а также сетевые ошибки считаются временными (System), остальные ответы - окончательным отказом шлюза
+	// (см. senderror.Classify). Ответ шлюза, в том числе Retry-After, сохраняется в senderror.StatusError.
+	Client struct {
+		httpClient *http.Client
+		url        string
+		authToken  string
+		header     http.Header
+	}
+
+	requestBody struct {
+		From string `json:"from,omitempty"`
+		To   string `json:"to"`
+		Text string `json:"text"`
+	}
+)
+
+// New - создаёт объект Client.
+func New(url string, opts ...Option) *Client {
+	o := options{
+		client: &Client{
+			httpClient: &http.Client{
+				Timeout: defaultTimeout,
+			},
+			url:    url,
+			header: make(http.Header),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.client
+}
+
+// SendSMS - отправляет короткое сообщение на указанный номер телефона.
+func (c *Client) SendSMS(ctx context.Context, from, phone, text string) error {
+	body, err := json.Marshal(
+		requestBody{
+			From: from,
+			To:   phone,
+			Text: text,
+		},
+	)
+	if err != nil {
+		return errors.WrapInternalError(err, "marshal sms request failed")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapInternalError(err, "create sms request failed", "url", c.url)
+	}
+
+	for name, values := range c.header {
+		request.Header[name] = values
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	if c.authToken != "" {
+		request.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return senderror.Classify(err, "url", c.url)
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, response.Body)
+
+		return nil
+	}
+
+	return senderror.Classify(senderror.NewStatusError(response), "url", c.url)
+}
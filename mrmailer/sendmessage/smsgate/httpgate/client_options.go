@@ -0,0 +1,33 @@
+package httpgate
+
+import "net/http"
+
+type (
+	// Option - настройка объекта Client.
+	Option func(o *options)
+
+	options struct {
+		client *Client
+	}
+)
+
+// WithHTTPClient - устанавливает HTTP клиента, через которого выполняются запросы к шлюзу.
+func WithHTTPClient(value *http.Client) Option {
+	return func(o *options) {
+		o.client.httpClient = value
+	}
+}
+
+// WithAuthToken - устанавливает токен, передаваемый шлюзу в заголовке Authorization: Bearer.
+func WithAuthToken(value string) Option {
+	return func(o *options) {
+		o.client.authToken = value
+	}
+}
+
+// WithHeader - устанавливает дополнительный заголовок запроса (например, ключ API шлюза).
+func WithHeader(name, value string) Option {
+	return func(o *options) {
+		o.client.header.Set(name, value)
+	}
+}
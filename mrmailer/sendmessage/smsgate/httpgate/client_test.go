@@ -0,0 +1,99 @@
+package httpgate_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mondegor/go-core/errors/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/smsgate/httpgate"
+)
+
+func TestClient_SendSMS(t *testing.T) {
+	t.Parallel()
+
+	var received map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "key", r.Header.Get("X-Api-Key"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := httpgate.New(
+		server.URL,
+		httpgate.WithAuthToken("secret"),
+		httpgate.WithHeader("X-Api-Key", "key"),
+	)
+
+	err := client.SendSMS(context.Background(), "Sender", "+79001234567", "Код: 1234")
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		map[string]string{
+			"from": "Sender",
+			"to":   "+79001234567",
+			"text": "Код: 1234",
+		},
+		received,
+	)
+}
+
+func TestClient_SendSMS_ErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		temporary  bool
+	}{
+		{name: "bad request is permanent", status: http.StatusBadRequest, temporary: false},
+		{name: "forbidden with retry after is temporary", status: http.StatusForbidden, retryAfter: "120", temporary: true},
+		{name: "too many requests is temporary", status: http.StatusTooManyRequests, temporary: true},
+		{name: "server error is temporary", status: http.StatusBadGateway, temporary: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"error":"failed"}`))
+			}))
+			defer server.Close()
+
+			err := httpgate.New(server.URL).SendSMS(context.Background(), "", "+79001234567", "text")
+			require.Error(t, err)
+			assert.Equal(t, tt.temporary, kind.Extract(err) == kind.System)
+
+			// ответ шлюза сохраняется в причине ошибки вместе с Retry-After
+			var statusErr *senderror.StatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tt.status, statusErr.Status)
+			assert.JSONEq(t, `{"error":"failed"}`, statusErr.Response)
+
+			if tt.retryAfter != "" {
+				assert.Equal(t, 2*time.Minute, statusErr.RetryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package smsgate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+var (
+	// ErrMessageInvalid - короткое сообщение некорректно.
+	ErrMessageInvalid = errors.New("sms message is invalid")
+)
+
+//nolint:gochecknoglobals
+var (
+	// буквенно-цифровое имя отправителя (до 11 символов) или номер телефона (до 15 цифр).
+	regexpSenderID = regexp.MustCompile(`^(?:[A-Za-z0-9 ._\-]{1,11}|\+?[0-9]{3,15})$`)
+
+	// номер телефона в формате E.164 (знак + необязателен).
+	regexpPhone = regexp.MustCompile(`^\+?[1-9][0-9]{6,14}$`)
+)
+
+// IsValidSenderID - сообщает, является ли значение допустимым именем отправителя (sender ID).
+func IsValidSenderID(value string) bool {
+	return regexpSenderID.MatchString(value)
+}
+
+// NormalizePhone - удаляет из номера телефона разделители, которые допускаются при его вводе
+// (пробелы, дефисы, скобки), и сообщает, является ли результат номером в формате E.164 (знак + необязателен).
+// Номер должен начинаться со знака +, цифры или скобки, поэтому, например,
+// отрицательные ID чатов мессенджеров номером телефона не считаются.
+func NormalizePhone(value string) (phone string, ok bool) {
+	value = strings.TrimSpace(value)
+
+	phone = strings.Map(
+		func(r rune) rune {
+			switch r {
+			case ' ', '-', '(', ')':
+				return -1
+			default:
+				return r
+			}
+		},
+		value,
+	)
+
+	if value == "" || value[0] == '-' {
+		return phone, false
+	}
+
+	return phone, regexpPhone.MatchString(phone)
+}
+
+// Validate - проверяет имя отправителя (если указано), номер телефона получателя
+// и что текст сообщения не пустой. Ограничение кол-ва частей сообщения проверяется при его отправке.
+func Validate(data *entity.DataSMS) error {
+	if data == nil {
+		return fmt.Errorf("%w: data is nil", ErrMessageInvalid)
+	}
+
+	if data.From != "" && !IsValidSenderID(data.From) {
+		return fmt.Errorf("%w: sender ID is invalid", ErrMessageInvalid)
+	}
+
+	if _, ok := NormalizePhone(data.Phone); !ok {
+		return fmt.Errorf("%w: phone is invalid", ErrMessageInvalid)
+	}
+
+	if data.Content == "" {
+		return fmt.Errorf("%w: content is empty", ErrMessageInvalid)
+	}
+
+	return nil
+}
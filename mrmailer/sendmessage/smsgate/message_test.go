@@ -0,0 +1,88 @@
+package smsgate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/smsgate"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		value  string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "e164",
+			value:  "+79001234567",
+			want:   "+79001234567",
+			wantOk: true,
+		},
+		{
+			name:   "with separators",
+			value:  " +7 (900) 123-45-67 ",
+			want:   "+79001234567",
+			wantOk: true,
+		},
+		{
+			name:   "without plus",
+			value:  "8 900 123 45 67",
+			want:   "89001234567",
+			wantOk: true,
+		},
+		{
+			name:  "too short",
+			value: "12345",
+			want:  "12345",
+		},
+		{
+			name:  "negative chat id",
+			value: "-1001234567890",
+			want:  "1001234567890",
+		},
+		{
+			name:  "letters",
+			value: "+7900CALLME",
+			want:  "+7900CALLME",
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := smsgate.NormalizePhone(tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, smsgate.Validate(&entity.DataSMS{Phone: "+7 900 123-45-67", Content: "code 1234"}))
+	require.NoError(t, smsgate.Validate(&entity.DataSMS{From: "Shop", Phone: "+79001234567", Content: "code 1234"}))
+
+	invalid := []*entity.DataSMS{
+		nil,
+		{From: "Sender name is too long", Phone: "+79001234567", Content: "code 1234"},
+		{Phone: "12345", Content: "code 1234"},
+		{Phone: "", Content: "code 1234"},
+		{Phone: "+79001234567"},
+	}
+
+	for _, data := range invalid {
+		require.ErrorIs(t, smsgate.Validate(data), smsgate.ErrMessageInvalid)
+	}
+}
@@ -0,0 +1,151 @@
+package smsgate
+
+import "unicode/utf16"
+
+// Кодировки SMS сообщений.
+const (
+	EncodingGSM7 Encoding = iota + 1 // 7-битная кодировка GSM 03.38 (латиница и основные символы)
+	EncodingUCS2                     // 16-битная кодировка UCS-2 (все остальные символы, включая кириллицу)
+)
+
+const (
+	gsm7SingleLen = 160 // кол-во септетов в одном сообщении
+	gsm7MultiLen  = 153 // кол-во септетов в одной части составного сообщения (7 септетов уходят на UDH)
+	ucs2SingleLen = 70  // кол-во UTF-16 единиц в одном сообщении
+	ucs2MultiLen  = 67  // кол-во UTF-16 единиц в одной части составного сообщения
+)
+
+type (
+	// Encoding - кодировка SMS сообщения.
+	Encoding uint8
+
+	// Segments - результат разбиения текста SMS сообщения на части.
+	Segments struct {
+		Encoding Encoding
+		Units    int // длина текста в септетах (GSM-7) или в UTF-16 единицах (UCS-2)
+		Count    int // кол-во частей, на которые будет разбито сообщение
+	}
+)
+
+//nolint:gochecknoglobals
+var (
+	// gsm7Basic - основная таблица GSM 03.38 (каждый символ занимает один септет).
+	gsm7Basic = makeCharset(
+		"@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+			"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà",
+	)
+
+	// gsm7Extension - расширенная таблица GSM 03.38 (каждый символ занимает два септета: ESC + символ).
+	gsm7Extension = makeCharset("\f^{}\\[~]|€")
+)
+
+// String - возвращает название кодировки.
+func (e Encoding) String() string {
+	switch e {
+	case EncodingGSM7:
+		return "GSM-7"
+	case EncodingUCS2:
+		return "UCS-2"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// CalcSegments - вычисляет кодировку текста и кол-во частей, на которые он будет разбит при отправке.
+// Символы расширенной таблицы GSM-7 и суррогатные пары UCS-2 не разрываются между частями.
+func CalcSegments(text string) Segments {
+	if text == "" {
+		return Segments{
+			Encoding: EncodingGSM7,
+		}
+	}
+
+	if units, ok := gsm7Units(text); ok {
+		return Segments{
+			Encoding: EncodingGSM7,
+			Units:    sum(units),
+			Count:    countSegments(units, gsm7SingleLen, gsm7MultiLen),
+		}
+	}
+
+	units := ucs2Units(text)
+
+	return Segments{
+		Encoding: EncodingUCS2,
+		Units:    sum(units),
+		Count:    countSegments(units, ucs2SingleLen, ucs2MultiLen),
+	}
+}
+
+// gsm7Units - возвращает кол-во септетов каждого символа или false,
+// если текст не может быть представлен в кодировке GSM-7.
+func gsm7Units(text string) (units []int, ok bool) {
+	units = make([]int, 0, len(text))
+
+	for _, r := range text {
+		if _, ok := gsm7Basic[r]; ok {
+			units = append(units, 1)
+
+			continue
+		}
+
+		if _, ok := gsm7Extension[r]; ok {
+			units = append(units, 2)
+
+			continue
+		}
+
+		return nil, false
+	}
+
+	return units, true
+}
+
+// ucs2Units - возвращает кол-во UTF-16 единиц каждого символа.
+func ucs2Units(text string) []int {
+	units := make([]int, 0, len(text))
+
+	for _, r := range text {
+		units = append(units, utf16.RuneLen(r))
+	}
+
+	return units
+}
+
+func countSegments(units []int, singleLen, multiLen int) int {
+	if sum(units) <= singleLen {
+		return 1
+	}
+
+	count := 1
+	current := 0
+
+	for _, unit := range units {
+		if current+unit > multiLen {
+			count++
+			current = 0
+		}
+
+		current += unit
+	}
+
+	return count
+}
+
+func sum(units []int) (total int) {
+	for _, unit := range units {
+		total += unit
+	}
+
+	return total
+}
+
+func makeCharset(chars string) map[rune]struct{} {
+	charset := make(map[rune]struct{}, len(chars))
+
+	for _, r := range chars {
+		charset[r] = struct{}{}
+	}
+
+	return charset
+}
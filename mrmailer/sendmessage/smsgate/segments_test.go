@@ -0,0 +1,44 @@
+package smsgate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/smsgate"
+)
+
+func TestCalcSegments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want smsgate.Segments
+	}{
+		{name: "empty", text: "", want: smsgate.Segments{Encoding: smsgate.EncodingGSM7}},
+		{name: "gsm7 single max", text: strings.Repeat("a", 160), want: smsgate.Segments{Encoding: smsgate.EncodingGSM7, Units: 160, Count: 1}},
+		{name: "gsm7 two parts", text: strings.Repeat("a", 161), want: smsgate.Segments{Encoding: smsgate.EncodingGSM7, Units: 161, Count: 2}},
+		{name: "gsm7 two parts max", text: strings.Repeat("a", 306), want: smsgate.Segments{Encoding: smsgate.EncodingGSM7, Units: 306, Count: 2}},
+		{name: "gsm7 three parts", text: strings.Repeat("a", 307), want: smsgate.Segments{Encoding: smsgate.EncodingGSM7, Units: 307, Count: 3}},
+		{name: "gsm7 extension chars", text: strings.Repeat("€", 80), want: smsgate.Segments{Encoding: smsgate.EncodingGSM7, Units: 160, Count: 1}},
+		{
+			name: "gsm7 extension char is not split",
+			text: strings.Repeat("a", 152) + "€" + strings.Repeat("a", 10),
+			want: smsgate.Segments{Encoding: smsgate.EncodingGSM7, Units: 164, Count: 2},
+		},
+		{name: "ucs2 single max", text: strings.Repeat("я", 70), want: smsgate.Segments{Encoding: smsgate.EncodingUCS2, Units: 70, Count: 1}},
+		{name: "ucs2 two parts", text: strings.Repeat("я", 71), want: smsgate.Segments{Encoding: smsgate.EncodingUCS2, Units: 71, Count: 2}},
+		{name: "ucs2 three parts", text: strings.Repeat("я", 135), want: smsgate.Segments{Encoding: smsgate.EncodingUCS2, Units: 135, Count: 3}},
+		{name: "ucs2 surrogate pairs", text: strings.Repeat("😀", 36), want: smsgate.Segments{Encoding: smsgate.EncodingUCS2, Units: 72, Count: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, smsgate.CalcSegments(tt.text))
+		})
+	}
+}
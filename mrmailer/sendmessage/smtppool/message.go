@@ -0,0 +1,12 @@
+package smtppool
+
+import "net/mail"
+
+// envelopeAddress - возвращает адрес конверта без имени получателя (для команд MAIL FROM и RCPT TO).
+func envelopeAddress(value string) string {
+	if addr, err := mail.ParseAddress(value); err == nil {
+		return addr.Address
+	}
+
+	return value
+}
@@ -0,0 +1,386 @@
+package smtppool
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailbody"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/senderror"
+)
+
+const (
+	defaultPoolSize    = 4
+	defaultIdleTimeout = 30 * time.Second
+	defaultDialTimeout = 10 * time.Second
+)
+
+type (
+	// Pool - почтовый клиент, который отправляет письма через пул долгоживущих SMTP соединений.
+	// Соединение после отправки письма возвращается в пул, а перед следующим конвертом
+	// сбрасывается командой RSET, поэтому для рассылки не требуется открывать новую SMTP сессию на каждое письмо.
+	// Одновременно открыто не более poolSize соединений, соединения, простаивающие дольше idleTimeout, закрываются.
+	// Ошибки классифицируются через senderror.Classify: отказ сервера с кодом 5xx окончательный,
+	// коды 4xx и сетевые ошибки временные (System).
+	// Реализует интерфейсы mrclient.MailSender и adapter.RawMailSender.
+	Pool struct {
+		addr        string
+		host        string
+		localName   string
+		auth        smtp.Auth
+		tlsConfig   *tls.Config
+		tlsRequired bool
+		implicitTLS bool
+		poolSize    int
+		idleTimeout time.Duration
+		dialTimeout time.Duration
+		nowFunc     func() time.Time
+
+		slots  chan struct{}
+		mu     sync.Mutex
+		idle   []*conn
+		closed bool
+	}
+
+	conn struct {
+		netConn  net.Conn
+		client   *smtp.Client
+		lastUsed time.Time
+	}
+)
+
+// New - создаёт объект Pool для SMTP сервера с указанным адресом (host:port).
+// Соединения открываются по мере необходимости, после использования пул следует закрыть через Close.
+func New(addr string, opts ...Option) (*Pool, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, errors.WrapInternalError(err, "parsing smtp server address failed", "addr", addr)
+	}
+
+	o := options{
+		pool: &Pool{
+			addr:        addr,
+			host:        host,
+			poolSize:    defaultPoolSize,
+			idleTimeout: defaultIdleTimeout,
+			dialTimeout: defaultDialTimeout,
+			nowFunc:     time.Now,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := o.pool
+
+	if p.tlsConfig == nil {
+		p.tlsConfig = &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	}
+
+	p.slots = make(chan struct{}, p.poolSize)
+
+	return p, nil
+}
+
+// SendMail - отправляет письмо с указанными заголовками и телом одному получателю конверта,
+// используя свободное соединение пула или открывая новое, если свободных нет и лимит пула не исчерпан.
+func (p *Pool) SendMail(ctx context.Context, from, to string, header map[string]string, body string) error {
+	return p.SendRawMail(ctx, from, []string{to}, mailbody.Compose(header, body))
+}
+
+// SendRawMail - отправляет сформированное письмо (RFC 5322) всем получателям конверта в одной SMTP транзакции.
+// Если сервер отклонил хотя бы одного получателя, то транзакция отменяется и письмо не передаётся никому,
+// поэтому повторная отправка письма не приводит к его дублированию.
+// Переводы строк письма должны быть \r\n (см. mailbody.Compose).
+func (p *Pool) SendRawMail(ctx context.Context, from string, to []string, message []byte) error {
+	c, err := p.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.send(ctx, from, to, message)
+	p.release(c, err)
+
+	if err != nil {
+		return senderror.Classify(err, "addr", p.addr, "to", strings.Join(to, ", "))
+	}
+
+	return nil
+}
+
+// Size - возвращает максимальное кол-во одновременно открытых соединений с сервером.
+func (p *Pool) Size() int {
+	return p.poolSize
+}
+
+// Close - закрывает все свободные соединения пула (после отправки писем сессии завершаются командой QUIT),
+// соединения, занятые в данный момент, закрываются при их возврате в пул.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		c.quit()
+	}
+
+	return nil
+}
+
+// acquire - возвращает соединение, готовое к отправке нового конверта.
+func (p *Pool) acquire(ctx context.Context) (*conn, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, senderror.Classify(ctx.Err(), "addr", p.addr)
+	}
+
+	for {
+		c, closed := p.popIdle()
+		if closed {
+			<-p.slots
+
+			return nil, mrmailer.ErrInternalMailPoolClosed.New("addr", p.addr)
+		}
+
+		if c == nil {
+			break
+		}
+
+		if p.nowFunc().Sub(c.lastUsed) > p.idleTimeout {
+			c.close()
+
+			continue
+		}
+
+		// между конвертами состояние сессии сбрасывается, если это не удалось,
+		// то соединение считается разорванным сервером и вместо него используется другое
+		if err := c.reset(ctx); err != nil {
+			c.close()
+
+			continue
+		}
+
+		return c, nil
+	}
+
+	c, err := p.dial(ctx)
+	if err != nil {
+		<-p.slots
+
+		return nil, senderror.Classify(err, "addr", p.addr)
+	}
+
+	return c, nil
+}
+
+// release - возвращает соединение в пул, если после ошибки отправки оно осталось в рабочем состоянии.
+func (p *Pool) release(c *conn, sendErr error) {
+	defer func() { <-p.slots }()
+
+	if sendErr != nil && !senderror.IsRetainedConnection(sendErr) {
+		c.close()
+
+		return
+	}
+
+	c.lastUsed = p.nowFunc()
+
+	p.mu.Lock()
+
+	if p.closed {
+		p.mu.Unlock()
+		c.quit()
+
+		return
+	}
+
+	p.idle = append(p.idle, c)
+	expired := p.takeExpired(c.lastUsed)
+	p.mu.Unlock()
+
+	for _, ec := range expired {
+		ec.quit()
+	}
+}
+
+// takeExpired - извлекает из пула соединения, простаивающие дольше idleTimeout
+// (свободные соединения хранятся в порядке их возврата в пул, поэтому самые старые находятся в начале).
+func (p *Pool) takeExpired(now time.Time) []*conn {
+	count := 0
+
+	for count < len(p.idle) && now.Sub(p.idle[count].lastUsed) > p.idleTimeout {
+		count++
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	expired := slices.Clone(p.idle[:count])
+	p.idle = slices.Delete(p.idle, 0, count)
+
+	return expired
+}
+
+// popIdle - возвращает последнее использованное свободное соединение или nil, если таких нет,
+// а также признак того, что пул закрыт.
+func (p *Pool) popIdle() (c *conn, closed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, true
+	}
+
+	count := len(p.idle)
+	if count == 0 {
+		return nil, false
+	}
+
+	c = p.idle[count-1]
+	p.idle = p.idle[:count-1]
+
+	return c, false
+}
+
+// dial - открывает новое SMTP соединение: приветствие, STARTTLS (если сервер его поддерживает
+// или он обязателен, см. WithTLSConfig) и аутентификация.
+func (p *Pool) dial(ctx context.Context) (*conn, error) {
+	dialer := &net.Dialer{Timeout: p.dialTimeout}
+
+	var (
+		netConn net.Conn
+		err     error
+	)
+
+	if p.implicitTLS {
+		netConn, err = (&tls.Dialer{NetDialer: dialer, Config: p.tlsConfig}).DialContext(ctx, "tcp", p.addr)
+	} else {
+		netConn, err = dialer.DialContext(ctx, "tcp", p.addr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := &conn{netConn: netConn}
+	defer c.watch(ctx)()
+
+	if c.client, err = smtp.NewClient(netConn, p.host); err != nil {
+		_ = netConn.Close()
+
+		return nil, err
+	}
+
+	if err = p.handshake(c.client); err != nil {
+		c.close()
+
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (p *Pool) handshake(client *smtp.Client) error {
+	if p.localName != "" {
+		if err := client.Hello(p.localName); err != nil {
+			return err
+		}
+	}
+
+	if !p.implicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(p.tlsConfig); err != nil {
+				return err
+			}
+		} else if p.tlsRequired {
+			// без шифрования письма и учётные данные передавались бы открытым текстом
+			return mrmailer.ErrInternalMailServerExtensionRequired.New("addr", p.addr, "extension", "STARTTLS")
+		}
+	}
+
+	if p.auth != nil {
+		ok, _ := client.Extension("AUTH")
+		if !ok {
+			return mrmailer.ErrInternalMailServerExtensionRequired.New("addr", p.addr, "extension", "AUTH")
+		}
+
+		if err := client.Auth(p.auth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send - передаёт серверу один конверт: MAIL FROM, RCPT TO для каждого получателя и DATA.
+func (c *conn) send(ctx context.Context, from string, to []string, message []byte) error {
+	defer c.watch(ctx)()
+
+	if err := c.client.Mail(envelopeAddress(from)); err != nil {
+		return err
+	}
+
+	for _, rcpt := range to {
+		if err := c.client.Rcpt(envelopeAddress(rcpt)); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(message); err != nil {
+		_ = w.Close()
+
+		return err
+	}
+
+	return w.Close()
+}
+
+func (c *conn) reset(ctx context.Context) error {
+	defer c.watch(ctx)()
+
+	return c.client.Reset()
+}
+
+// watch - ограничивает операции с соединением сроком действия контекста
+// и прерывает их при отмене контекста, возвращает функцию снятия ограничения.
+func (c *conn) watch(ctx context.Context) (stop func()) {
+	deadline, _ := ctx.Deadline()
+	_ = c.netConn.SetDeadline(deadline)
+
+	stopAfter := context.AfterFunc(ctx, func() {
+		_ = c.netConn.SetDeadline(time.Unix(1, 0))
+	})
+
+	return func() {
+		stopAfter()
+		_ = c.netConn.SetDeadline(time.Time{})
+	}
+}
+
+func (c *conn) quit() {
+	if err := c.client.Quit(); err != nil {
+		c.close()
+	}
+}
+
+func (c *conn) close() {
+	_ = c.client.Close()
+}
@@ -0,0 +1,79 @@
+package smtppool
+
+import (
+	"crypto/tls"
+	"net/smtp"
+	"time"
+)
+
+type (
+	// Option - настройка объекта Pool.
+	Option func(o *options)
+
+	options struct {
+		pool *Pool
+	}
+)
+
+// WithAuth - устанавливает аутентификацию на SMTP сервере (например, smtp.PlainAuth),
+// она выполняется при открытии соединения, если сервер не поддерживает расширение AUTH,
+// то соединение не открывается.
+func WithAuth(value smtp.Auth) Option {
+	return func(o *options) {
+		o.pool.auth = value
+	}
+}
+
+// WithTLSConfig - устанавливает настройки TLS, используемые для STARTTLS или неявного TLS
+// (по умолчанию проверяется сертификат сервера с именем хоста из адреса).
+// При указании настроек шифрование обязательно: если сервер не поддерживает STARTTLS,
+// то соединение не открывается.
+func WithTLSConfig(value *tls.Config) Option {
+	return func(o *options) {
+		o.pool.tlsConfig = value
+		o.pool.tlsRequired = true
+	}
+}
+
+// WithImplicitTLS - включает неявный TLS (SMTPS, обычно порт 465): соединение шифруется сразу при открытии,
+// по умолчанию шифрование включается командой STARTTLS, если сервер её поддерживает.
+func WithImplicitTLS() Option {
+	return func(o *options) {
+		o.pool.implicitTLS = true
+	}
+}
+
+// WithLocalName - устанавливает имя хоста, передаваемое серверу в команде EHLO (по умолчанию localhost).
+func WithLocalName(value string) Option {
+	return func(o *options) {
+		o.pool.localName = value
+	}
+}
+
+// WithPoolSize - устанавливает максимальное кол-во одновременно открытых соединений с сервером.
+func WithPoolSize(value int) Option {
+	return func(o *options) {
+		if value > 0 {
+			o.pool.poolSize = value
+		}
+	}
+}
+
+// WithIdleTimeout - устанавливает время, после простоя в течение которого соединение не используется повторно
+// и закрывается (значение должно быть меньше таймаута простоя, установленного на сервере).
+func WithIdleTimeout(value time.Duration) Option {
+	return func(o *options) {
+		if value > 0 {
+			o.pool.idleTimeout = value
+		}
+	}
+}
+
+// WithDialTimeout - устанавливает максимальное время открытия соединения с сервером.
+func WithDialTimeout(value time.Duration) Option {
+	return func(o *options) {
+		if value > 0 {
+			o.pool.dialTimeout = value
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package smtppool_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mondegor/go-core/errors/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/smtppool"
+)
+
+type (
+	// fakeServer - минимальный SMTP сервер, который запоминает полученные команды и письма.
+	fakeServer struct {
+		listener net.Listener
+
+		mu       sync.Mutex
+		sessions int
+		commands []string
+		messages []string
+	}
+)
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeServer{listener: listener}
+
+	go s.serve()
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	return s
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.sessions++
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 fake.localhost ESMTP")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		s.mu.Lock()
+		s.commands = append(s.commands, command)
+		s.mu.Unlock()
+
+		switch {
+		case command == "EHLO":
+			reply("250-fake.localhost")
+			reply("250 8BITMIME")
+		case command == "RCPT" && strings.Contains(line, "rejected@"):
+			reply("550 5.1.1 user unknown")
+		case command == "RCPT" && strings.Contains(line, "greylisted@"):
+			reply("451 4.7.1 try again later")
+		case command == "DATA":
+			reply("354 go ahead")
+
+			var data strings.Builder
+
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if dataLine == ".\r\n" {
+					break
+				}
+
+				data.WriteString(dataLine)
+			}
+
+			s.mu.Lock()
+			s.messages = append(s.messages, data.String())
+			s.mu.Unlock()
+
+			reply("250 2.0.0 queued")
+		case command == "QUIT":
+			reply("221 bye")
+
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func (s *fakeServer) stats() (sessions int, commands, messages []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sessions, append([]string(nil), s.commands...), append([]string(nil), s.messages...)
+}
+
+func count(items []string, value string) int {
+	var n int
+
+	for _, item := range items {
+		if item == value {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestPool_SendMail_ReusesConnection(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t)
+
+	pool, err := smtppool.New(server.listener.Addr().String(), smtppool.WithPoolSize(1))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pool.Size())
+
+	defer func() { _ = pool.Close() }()
+
+	header := map[string]string{
+		"From":    "Sender <sender@example.com>",
+		"To":      "user@example.com",
+		"Subject": "Hello",
+	}
+
+	for range 3 {
+		err = pool.SendMail(context.Background(), "Sender <sender@example.com>", "user@example.com", header, "text\n")
+		require.NoError(t, err)
+	}
+
+	sessions, commands, messages := server.stats()
+
+	assert.Equal(t, 1, sessions)
+	assert.Equal(t, 3, count(commands, "MAIL"))
+	assert.Equal(t, 2, count(commands, "RSET"))
+	require.Len(t, messages, 3)
+	assert.Equal(t, "From: Sender <sender@example.com>\r\nSubject: Hello\r\nTo: user@example.com\r\n\r\ntext\r\n", messages[0])
+}
+
+func TestPool_SendMail_ErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t)
+
+	pool, err := smtppool.New(server.listener.Addr().String(), smtppool.WithPoolSize(1))
+	require.NoError(t, err)
+
+	defer func() { _ = pool.Close() }()
+
+	err = pool.SendMail(context.Background(), "sender@example.com", "rejected@example.com", nil, "text")
+	require.Error(t, err)
+	assert.NotEqual(t, kind.System, kind.Extract(err))
+
+	err = pool.SendMail(context.Background(), "sender@example.com", "greylisted@example.com", nil, "text")
+	require.Error(t, err)
+	assert.Equal(t, kind.System, kind.Extract(err))
+
+	// после отказа сервера соединение остаётся рабочим и используется повторно
+	require.NoError(t, pool.SendMail(context.Background(), "sender@example.com", "user@example.com", nil, "text"))
+
+	sessions, _, _ := server.stats()
+	assert.Equal(t, 1, sessions)
+}
+
+func TestPool_SendRawMail_SingleTransaction(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t)
+
+	pool, err := smtppool.New(server.listener.Addr().String(), smtppool.WithPoolSize(1))
+	require.NoError(t, err)
+
+	defer func() { _ = pool.Close() }()
+
+	message := []byte("Subject: Hello\r\n\r\ntext\r\n")
+	to := []string{"John <john@example.com>", "copy@example.com", "hidden@example.com"}
+
+	require.NoError(t, pool.SendRawMail(context.Background(), "sender@example.com", to, message))
+
+	_, commands, messages := server.stats()
+
+	assert.Equal(t, 1, count(commands, "MAIL"))
+	assert.Equal(t, 3, count(commands, "RCPT"))
+	assert.Equal(t, []string{string(message)}, messages)
+}
+
+func TestPool_SendRawMail_RejectedRecipient(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t)
+
+	pool, err := smtppool.New(server.listener.Addr().String(), smtppool.WithPoolSize(1))
+	require.NoError(t, err)
+
+	defer func() { _ = pool.Close() }()
+
+	to := []string{"user@example.com", "rejected@example.com", "copy@example.com"}
+
+	err = pool.SendRawMail(context.Background(), "sender@example.com", to, []byte("\r\ntext\r\n"))
+	require.Error(t, err)
+	assert.NotEqual(t, kind.System, kind.Extract(err))
+
+	// письмо не передаётся ни одному получателю, если хотя бы один из них отклонён
+	_, commands, messages := server.stats()
+
+	assert.Equal(t, 0, count(commands, "DATA"))
+	assert.Empty(t, messages)
+}
+
+func TestPool_SendMail_IdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeServer(t)
+
+	pool, err := smtppool.New(server.listener.Addr().String(), smtppool.WithIdleTimeout(10*time.Millisecond))
+	require.NoError(t, err)
+
+	defer func() { _ = pool.Close() }()
+
+	require.NoError(t, pool.SendMail(context.Background(), "sender@example.com", "user@example.com", nil, "text"))
+
+	time.Sleep(50 * time.Millisecond)
+
+	require.NoError(t, pool.SendMail(context.Background(), "sender@example.com", "user@example.com", nil, "text"))
+
+	sessions, _, _ := server.stats()
+	assert.Equal(t, 2, sessions)
+}
+
+func TestPool_SendMail_Unavailable(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	pool, err := smtppool.New(addr)
+	require.NoError(t, err)
+
+	err = pool.SendMail(context.Background(), "sender@example.com", "user@example.com", nil, "text")
+	require.Error(t, err)
+	assert.Equal(t, kind.System, kind.Extract(err))
+}
+
+func TestPool_SendMail_RequiredExtension(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opt  smtppool.Option
+	}{
+		{
+			name: "starttls is required by tls config",
+			opt:  smtppool.WithTLSConfig(&tls.Config{ServerName: "fake.localhost", MinVersion: tls.VersionTLS12}),
+		},
+		{
+			name: "auth is required by credentials",
+			opt:  smtppool.WithAuth(smtp.PlainAuth("", "user", "password", "127.0.0.1")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newFakeServer(t)
+
+			pool, err := smtppool.New(server.listener.Addr().String(), tt.opt)
+			require.NoError(t, err)
+
+			defer func() { _ = pool.Close() }()
+
+			// сервер не объявляет ни STARTTLS, ни AUTH, поэтому письмо не передаётся
+			err = pool.SendMail(context.Background(), "sender@example.com", "user@example.com", nil, "text")
+			require.ErrorIs(t, err, mrmailer.ErrInternalMailServerExtensionRequired)
+
+			_, commands, _ := server.stats()
+			assert.Equal(t, 0, count(commands, "MAIL"))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+const (
+	dialTimeout   = 30 * time.Second
+	dialKeepAlive = 30 * time.Second
+)
+
+var (
+	// ErrAddressDenied - соединение с адресом получателя веб-хука запрещено настройками отправителя.
+	ErrAddressDenied = errors.New("webhook address is denied")
+
+	// ErrTransportNotSupported - проверка адресов не может быть установлена для транспорта HTTP клиента.
+	ErrTransportNotSupported = errors.New("webhook http transport is not supported")
+)
+
+type (
+	// addressChecker - проверяет IP адрес, с которым устанавливается соединение.
+	// Проверка выполняется после разрешения имени хоста, поэтому её нельзя обойти,
+	// указав в URL имя, которое разрешается во внутренний адрес.
+	addressChecker struct {
+		allowed []netip.Prefix
+		denied  []netip.Prefix
+	}
+)
+
+// PrivateNetworks - возвращает сети, которые не должны быть доступны получателям веб-хуков:
+// локальные, частные, служебные и multicast адреса IPv4 и IPv6.
+func PrivateNetworks() []netip.Prefix {
+	return []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("100.64.0.0/10"),
+		netip.MustParsePrefix("127.0.0.0/8"),
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+		netip.MustParsePrefix("224.0.0.0/4"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+		netip.MustParsePrefix("::/128"),
+		netip.MustParsePrefix("::1/128"),
+		netip.MustParsePrefix("fc00::/7"),
+		netip.MustParsePrefix("fe80::/10"),
+		netip.MustParsePrefix("ff00::/8"),
+	}
+}
+
+// control - вызывается при установке соединения с уже разрешённым IP адресом.
+func (c addressChecker) control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAddressDenied, err)
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAddressDenied, err)
+	}
+
+	ip = ip.Unmap()
+
+	if containsAddr(c.denied, ip) || (len(c.allowed) > 0 && !containsAddr(c.allowed, ip)) {
+		return fmt.Errorf("%w: %s", ErrAddressDenied, ip)
+	}
+
+	return nil
+}
+
+// transport - возвращает копию указанного транспорта, соединения которого проверяются,
+// прокси из переменных окружения при этом не используется, т.к. иначе проверялся бы адрес прокси.
+func (c addressChecker) transport(value http.RoundTripper) (*http.Transport, error) {
+	if value == nil {
+		value = http.DefaultTransport
+	}
+
+	base, ok := value.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrTransportNotSupported, value)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+		Control:   c.control,
+	}
+
+	transport := base.Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return transport, nil
+}
+
+func containsAddr(networks []netip.Prefix, ip netip.Addr) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// denyRedirect - запрещает HTTP клиенту переходить по перенаправлениям,
+// ответ 3xx возвращается как есть и считается окончательным отказом получателя.
+func denyRedirect(_ *http.Request, _ []*http.Request) error {
+	return http.ErrUseLastResponse
+}
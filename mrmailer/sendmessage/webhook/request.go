@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	// HeaderID - заголовок с ID сообщения, который не меняется при повторных попытках
+	// (получатель может использовать его для отбрасывания дублей).
+	HeaderID = "X-Webhook-Id"
+
+	// HeaderTimestamp - заголовок с временем формирования запроса (unix time в секундах).
+	HeaderTimestamp = "X-Webhook-Timestamp"
+
+	// HeaderSignature - заголовок с подписью запроса: sha256=hex(HMAC-SHA256(secret, timestamp + "." + body)).
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	// ErrRequestInvalid - HTTP запрос веб-хука некорректен.
+	ErrRequestInvalid = errors.New("webhook request is invalid")
+)
+
+//nolint:gochecknoglobals
+var (
+	// allowedMethods - HTTP методы, которыми может быть отправлен веб-хук.
+	allowedMethods = map[string]struct{}{
+		http.MethodPost:  {},
+		http.MethodPut:   {},
+		http.MethodPatch: {},
+	}
+
+	// reservedHeaders - заголовки, которые формируются отправителем и не могут быть указаны в сообщении.
+	reservedHeaders = map[string]struct{}{
+		textproto.CanonicalMIMEHeaderKey("Content-Type"):   {},
+		textproto.CanonicalMIMEHeaderKey("Content-Length"): {},
+		textproto.CanonicalMIMEHeaderKey("Host"):           {},
+		textproto.CanonicalMIMEHeaderKey(HeaderID):         {},
+		textproto.CanonicalMIMEHeaderKey(HeaderTimestamp):  {},
+		textproto.CanonicalMIMEHeaderKey(HeaderSignature):  {},
+	}
+)
+
+// Validate - проверяет URL, метод, заголовки и тело запроса веб-хука.
+// URL должен быть абсолютным с протоколом http или https, тело запроса (если указано) - корректным JSON.
+func Validate(data *entity.DataWebhook) error {
+	if data == nil {
+		return fmt.Errorf("%w: data is nil", ErrRequestInvalid)
+	}
+
+	u, err := url.Parse(data.URL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRequestInvalid, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url '%s' is not absolute http(s) url", ErrRequestInvalid, data.URL)
+	}
+
+	if _, ok := allowedMethods[Method(data)]; !ok {
+		return fmt.Errorf("%w: method '%s' is not allowed", ErrRequestInvalid, data.Method)
+	}
+
+	for name, value := range data.Headers {
+		if err = checkHeader(name, value); err != nil {
+			return err
+		}
+	}
+
+	if len(data.Body) > 0 && !json.Valid(data.Body) {
+		return fmt.Errorf("%w: body is not valid json", ErrRequestInvalid)
+	}
+
+	return nil
+}
+
+// Method - возвращает HTTP метод веб-хука (по умолчанию POST).
+func Method(data *entity.DataWebhook) string {
+	if data.Method == "" {
+		return http.MethodPost
+	}
+
+	return strings.ToUpper(data.Method)
+}
+
+// Sign - возвращает подпись тела запроса для заголовка HeaderSignature.
+func Sign(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func checkHeader(name, value string) error {
+	if name == "" || strings.ContainsFunc(name, isInvalidNameRune) {
+		return fmt.Errorf("%w: header name '%s' is invalid", ErrRequestInvalid, name)
+	}
+
+	if _, ok := reservedHeaders[textproto.CanonicalMIMEHeaderKey(name)]; ok {
+		return fmt.Errorf("%w: header %s is reserved", ErrRequestInvalid, name)
+	}
+
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("%w: value of header %s contains line break", ErrRequestInvalid, name)
+	}
+
+	return nil
+}
+
+func isInvalidNameRune(r rune) bool {
+	return r <= ' ' || r >= 0x7f || r == ':'
+}
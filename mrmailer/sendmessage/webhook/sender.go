@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	maxResponseBodyLen = 512
+)
+
+type (
+	// Sender - отправитель веб-хуков: HTTP запросов с JSON телом во внешние системы.
+	// Если указан секрет, то запрос подписывается HMAC-SHA256 (заголовки HeaderTimestamp и HeaderSignature).
+	// Ответы 2xx считаются успешными, ответы 408, 425, 429 и 5xx, а также сетевые ошибки и таймаут
+	// считаются временными (System), остальные ответы (в том числе перенаправления 3xx, по которым
+	// отправитель не переходит) и запрет соединения с адресом получателя - окончательным отказом.
+	Sender struct {
+		httpClient *http.Client
+		secret     []byte
+		timeout    time.Duration
+		nowFunc    func() time.Time
+	}
+)
+
+// New - создаёт объект Sender.
+// Если указаны WithAllowedNetworks или WithDeniedNetworks, то транспорт HTTP клиента
+// должен быть *http.Transport (или не указан), иначе возвращается ErrTransportNotSupported.
+func New(opts ...Option) (*Sender, error) {
+	o := options{
+		sender: &Sender{
+			httpClient: &http.Client{},
+			timeout:    defaultTimeout,
+			nowFunc:    time.Now,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// копия клиента, чтобы не менять настройки клиента, переданного в WithHTTPClient
+	httpClient := *o.sender.httpClient
+	httpClient.CheckRedirect = denyRedirect
+
+	if len(o.allowedNetworks) > 0 || len(o.deniedNetworks) > 0 {
+		checker := addressChecker{
+			allowed: o.allowedNetworks,
+			denied:  o.deniedNetworks,
+		}
+
+		transport, err := checker.transport(httpClient.Transport)
+		if err != nil {
+			return nil, err
+		}
+
+		httpClient.Transport = transport
+	}
+
+	o.sender.httpClient = &httpClient
+
+	return o.sender, nil
+}
+
+// Send - отправляет указанное сообщение.
+func (s *Sender) Send(ctx context.Context, message entity.Message) error {
+	data := message.Data.Webhook
+
+	if data == nil {
+		return errors.ErrInternalIncorrectInputData.WithDetails("message.Data.Webhook is nil")
+	}
+
+	if err := Validate(data); err != nil {
+		return mrmailer.ErrInternalCheckMessageWebhookInvalid.Wrap(err, "channel", message.Channel)
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, Method(data), data.URL, bytes.NewReader(data.Body))
+	if err != nil {
+		return errors.WrapInternalError(err, "create webhook request failed", "url", data.URL)
+	}
+
+	for name, value := range data.Headers {
+		request.Header.Set(name, value)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderID, strconv.FormatUint(message.ID, 10))
+
+	if len(s.secret) > 0 {
+		timestamp := s.nowFunc().Unix()
+
+		request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		request.Header.Set(HeaderSignature, Sign(s.secret, timestamp, data.Body))
+	}
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		if errors.Is(err, ErrAddressDenied) {
+			return mrmailer.ErrInternalWebhookRejected.Wrap(err, "url", data.URL)
+		}
+
+		return mrmailer.ErrSystemWebhookUnavailable.Wrap(err, "url", data.URL)
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, response.Body)
+
+		return nil
+	}
+
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBodyLen))
+
+	if IsTemporaryStatus(response.StatusCode) {
+		return mrmailer.ErrSystemWebhookUnavailable.New(
+			"url", data.URL,
+			"status", response.StatusCode,
+			"response", string(responseBody),
+		)
+	}
+
+	return mrmailer.ErrInternalWebhookRejected.New(
+		"url", data.URL,
+		"status", response.StatusCode,
+		"response", string(responseBody),
+	)
+}
+
+// IsTemporaryStatus - сообщает, имеет ли смысл повторить запрос, получивший указанный код ответа.
+func IsTemporaryStatus(status int) bool {
+	return status == http.StatusRequestTimeout ||
+		status == http.StatusTooEarly ||
+		status == http.StatusTooManyRequests ||
+		status >= http.StatusInternalServerError
+}
@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"net/http"
+	"net/netip"
+	"time"
+)
+
+type (
+	// Option - настройка объекта Sender.
+	Option func(o *options)
+
+	options struct {
+		sender          *Sender
+		allowedNetworks []netip.Prefix
+		deniedNetworks  []netip.Prefix
+	}
+)
+
+// WithHTTPClient - устанавливает HTTP клиента, через которого выполняются запросы
+// (переходы по перенаправлениям отключаются и у указанного клиента).
+func WithHTTPClient(value *http.Client) Option {
+	return func(o *options) {
+		if value != nil {
+			o.sender.httpClient = value
+		}
+	}
+}
+
+// WithSecret - устанавливает секрет, которым подписываются запросы (HMAC-SHA256).
+func WithSecret(value []byte) Option {
+	return func(o *options) {
+		o.sender.secret = value
+	}
+}
+
+// WithTimeout - устанавливает максимальное время выполнения одного запроса
+// (нулевое значение означает, что используется только таймаут контекста).
+func WithTimeout(value time.Duration) Option {
+	return func(o *options) {
+		o.sender.timeout = value
+	}
+}
+
+// WithAllowedNetworks - устанавливает сети, с адресами которых разрешено устанавливать соединения
+// (если не указаны, то разрешены все адреса, кроме запрещённых в WithDeniedNetworks).
+func WithAllowedNetworks(values ...netip.Prefix) Option {
+	return func(o *options) {
+		o.allowedNetworks = append(o.allowedNetworks, values...)
+	}
+}
+
+// WithDeniedNetworks - устанавливает сети, с адресами которых запрещено устанавливать соединения
+// (например, PrivateNetworks() для защиты от SSRF).
+func WithDeniedNetworks(values ...netip.Prefix) Option {
+	return func(o *options) {
+		o.deniedNetworks = append(o.deniedNetworks, values...)
+	}
+}
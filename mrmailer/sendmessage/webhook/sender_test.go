@@ -0,0 +1,231 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mondegor/go-core/errors/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/webhook"
+)
+
+func newMessage(url string) entity.Message {
+	return entity.Message{
+		ID:      42,
+		Channel: "webhook/partner/OrderPaid",
+		Data: entity.MessageData{
+			Webhook: &entity.DataWebhook{
+				URL:     url,
+				Headers: map[string]string{"X-Partner-Key": "key"},
+				Body:    json.RawMessage(`{"orderId":1001}`),
+			},
+		},
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "key", r.Header.Get("X-Partner-Key"))
+		assert.Equal(t, "42", r.Header.Get(webhook.HeaderID))
+		assert.Equal(t, webhook.Sign(secret, timestamp, body), r.Header.Get(webhook.HeaderSignature))
+		assert.JSONEq(t, `{"orderId":1001}`, string(body))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender, err := webhook.New(webhook.WithSecret(secret))
+	require.NoError(t, err)
+
+	require.NoError(t, sender.Send(context.Background(), newMessage(server.URL)))
+}
+
+func TestSender_Send_ErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    int
+		temporary bool
+	}{
+		{name: "bad request is permanent", status: http.StatusBadRequest, temporary: false},
+		{name: "gone is permanent", status: http.StatusGone, temporary: false},
+		{name: "too many requests is temporary", status: http.StatusTooManyRequests, temporary: true},
+		{name: "server error is temporary", status: http.StatusServiceUnavailable, temporary: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"error":"failed"}`))
+			}))
+			defer server.Close()
+
+			sender, err := webhook.New()
+			require.NoError(t, err)
+
+			err = sender.Send(context.Background(), newMessage(server.URL))
+			require.Error(t, err)
+			assert.Equal(t, tt.temporary, kind.Extract(err) == kind.System)
+		})
+	}
+}
+
+func TestSender_Send_Timeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sender, err := webhook.New(webhook.WithTimeout(50 * time.Millisecond))
+	require.NoError(t, err)
+
+	err = sender.Send(context.Background(), newMessage(server.URL))
+	require.Error(t, err)
+	assert.Equal(t, kind.System, kind.Extract(err))
+}
+
+func TestSender_Send_RedirectIsNotFollowed(t *testing.T) {
+	t.Parallel()
+
+	var redirected atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal" {
+			redirected.Store(true)
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	sender, err := webhook.New()
+	require.NoError(t, err)
+
+	err = sender.Send(context.Background(), newMessage(server.URL))
+	require.Error(t, err)
+	assert.Equal(t, kind.Internal, kind.Extract(err))
+	assert.False(t, redirected.Load())
+}
+
+func TestSender_Send_AddressCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    []webhook.Option
+		wantErr bool
+	}{
+		{
+			name:    "private network is denied",
+			opts:    []webhook.Option{webhook.WithDeniedNetworks(webhook.PrivateNetworks()...)},
+			wantErr: true,
+		},
+		{
+			name:    "address is not in allowed networks",
+			opts:    []webhook.Option{webhook.WithAllowedNetworks(netip.MustParsePrefix("192.0.2.0/24"))},
+			wantErr: true,
+		},
+		{
+			name:    "address is in allowed networks",
+			opts:    []webhook.Option{webhook.WithAllowedNetworks(netip.MustParsePrefix("127.0.0.0/8"))},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			sender, err := webhook.New(tt.opts...)
+			require.NoError(t, err)
+
+			err = sender.Send(context.Background(), newMessage(server.URL))
+			if !tt.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, webhook.ErrAddressDenied)
+			assert.Equal(t, kind.Internal, kind.Extract(err))
+		})
+	}
+}
+
+func TestNew_TransportNotSupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := webhook.New(
+		webhook.WithHTTPClient(&http.Client{Transport: roundTripFunc(http.DefaultTransport.RoundTrip)}),
+		webhook.WithDeniedNetworks(webhook.PrivateNetworks()...),
+	)
+	require.ErrorIs(t, err, webhook.ErrTransportNotSupported)
+}
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := newMessage("https://partner.localhost/hook").Data.Webhook
+	require.NoError(t, webhook.Validate(valid))
+
+	invalid := []*entity.DataWebhook{
+		{URL: "/hook"},
+		{URL: "ftp://partner.localhost/hook"},
+		{URL: "https://partner.localhost/hook", Method: http.MethodGet},
+		{URL: "https://partner.localhost/hook", Headers: map[string]string{"X-Webhook-Signature": "fake"}},
+		{URL: "https://partner.localhost/hook", Headers: map[string]string{"X-Key": "a\r\nb"}},
+		{URL: "https://partner.localhost/hook", Body: json.RawMessage(`{"orderId":`)},
+	}
+
+	for _, data := range invalid {
+		require.ErrorIs(t, webhook.Validate(data), webhook.ErrRequestInvalid, data)
+	}
+}
@@ -0,0 +1,82 @@
+package delivery
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailaddr"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/smsgate"
+)
+
+const (
+	// maxRecipientLen - максимальная длина получателя, хранимого в статусах доставки
+	// и в списке подавления, более длинные значения (например, токены устройств) заменяются их хешем.
+	maxRecipientLen = 320
+)
+
+// Recipients - возвращает список получателей сообщения для поиска статусов доставки:
+// email адреса (To, Cc, Bcc в нижнем регистре), ID чата, номер телефона, хеш URL веб-хука
+// или токен устройства (см. NormalizeRecipient).
+func Recipients(data entity.MessageData) []string {
+	switch {
+	case data.Mail != nil:
+		recipients, err := mailaddr.ParseRecipients(data.Mail.To, data.Mail.Cc, data.Mail.Bcc)
+		if err != nil {
+			return nil
+		}
+
+		envelope := recipients.Envelope()
+
+		for i := range envelope {
+			envelope[i] = strings.ToLower(envelope[i])
+		}
+
+		return envelope
+	case data.Messenger != nil:
+		return []string{data.Messenger.ChatID}
+	case data.SMS != nil:
+		return []string{NormalizeRecipient(data.SMS.Phone)}
+	case data.Webhook != nil:
+		return []string{NormalizeRecipient(data.Webhook.URL)}
+	case data.Push != nil:
+		return []string{NormalizeRecipient(data.Push.DeviceToken)}
+	default:
+		return nil
+	}
+}
+
+// NormalizeRecipient - приводит получателя к виду, в котором он хранится в статусах доставки
+// (email адреса приводятся к нижнему регистру, из номеров телефонов удаляются разделители
+// так же, как при отправке SMS (см. smsgate.NormalizePhone), URL веб-хука заменяется его хешем sha256:<hex>,
+// т.к. может содержать секреты и превышать допустимую длину получателя, значения длиннее maxRecipientLen,
+// например, токены устройств, также заменяются хешем, остальные значения не меняются).
+func NormalizeRecipient(value string) string {
+	value = strings.TrimSpace(value)
+
+	if strings.Contains(value, "://") {
+		return hashRecipient(value)
+	}
+
+	// у email адреса есть непустая часть перед @ (в отличие от имён каналов мессенджеров: @channel)
+	if pos := strings.LastIndexByte(value, '@'); pos > 0 {
+		return strings.ToLower(value)
+	}
+
+	if phone, ok := smsgate.NormalizePhone(value); ok {
+		return phone
+	}
+
+	if len(value) > maxRecipientLen {
+		return hashRecipient(value)
+	}
+
+	return value
+}
+
+func hashRecipient(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
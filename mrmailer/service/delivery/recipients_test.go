@@ -0,0 +1,62 @@
+package delivery_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/service/delivery"
+)
+
+func TestRecipients(t *testing.T) {
+	t.Parallel()
+
+	mail := entity.MessageData{
+		Mail: &entity.DataMail{
+			To:  "Ivan Ivanov <Ivan@Localhost>",
+			Cc:  "petr@localhost",
+			Bcc: "BOSS@localhost",
+		},
+	}
+	assert.Equal(t, []string{"ivan@localhost", "petr@localhost", "boss@localhost"}, delivery.Recipients(mail))
+
+	messenger := entity.MessageData{Messenger: &entity.DataMessenger{ChatID: "@Channel"}}
+	assert.Equal(t, []string{"@Channel"}, delivery.Recipients(messenger))
+
+	sms := entity.MessageData{SMS: &entity.DataSMS{Phone: "+79001234567"}}
+	assert.Equal(t, []string{"+79001234567"}, delivery.Recipients(sms))
+
+	smsWithSeparators := entity.MessageData{SMS: &entity.DataSMS{Phone: "+7 (900) 123-45-67"}}
+	assert.Equal(t, []string{"+79001234567"}, delivery.Recipients(smsWithSeparators))
+
+	webhook := entity.MessageData{Webhook: &entity.DataWebhook{URL: "https://partner.localhost/hook?token=secret"}}
+	assert.Equal(t, []string{delivery.NormalizeRecipient("https://partner.localhost/hook?token=secret")}, delivery.Recipients(webhook))
+
+	push := entity.MessageData{Push: &entity.DataPush{DeviceToken: "device-token"}}
+	assert.Equal(t, []string{"device-token"}, delivery.Recipients(push))
+
+	longToken := strings.Repeat("t", 4096)
+	longPush := entity.MessageData{Push: &entity.DataPush{DeviceToken: longToken}}
+	assert.Equal(t, []string{delivery.NormalizeRecipient(longToken)}, delivery.Recipients(longPush))
+	assert.True(t, strings.HasPrefix(delivery.Recipients(longPush)[0], "sha256:"))
+
+	assert.Nil(t, delivery.Recipients(entity.MessageData{Mail: &entity.DataMail{To: "not-an-address"}}))
+	assert.Nil(t, delivery.Recipients(entity.MessageData{}))
+}
+
+func TestNormalizeRecipient(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ivan@localhost", delivery.NormalizeRecipient(" Ivan@LocalHost "))
+	assert.Equal(t, "@Channel", delivery.NormalizeRecipient("@Channel"))
+	assert.Equal(t, "+79001234567", delivery.NormalizeRecipient("+79001234567"))
+	assert.Equal(t, "+79001234567", delivery.NormalizeRecipient(" +7 900 123-45-67 "))
+	assert.Equal(t, "-1001234567890", delivery.NormalizeRecipient("-1001234567890"))
+	assert.Equal(
+		t,
+		"sha256:d37b4cdfa60eed6f5b58b4e0b888dbd86b0933d691af3d2a0d4d7f8e2639f625",
+		delivery.NormalizeRecipient(" https://partner.localhost/hook "),
+	)
+}
@@ -0,0 +1,69 @@
+package delivery
+
+import (
+	"context"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	defaultListLimit = 100
+)
+
+type (
+	// StatusService - объект для получения статусов доставки сообщений.
+	StatusService struct {
+		storage      statusStorage
+		errorWrapper errors.Wrapper
+	}
+
+	statusStorage interface {
+		FetchOne(ctx context.Context, messageID uint64) (entity.DeliveryStatus, error)
+		FetchByRecipient(ctx context.Context, recipient string, limit int) ([]entity.DeliveryStatus, error)
+	}
+)
+
+// NewStatusService - создаёт объект StatusService.
+func NewStatusService(storage statusStorage) *StatusService {
+	return &StatusService{
+		storage:      storage,
+		errorWrapper: errors.NewServiceRecordNotFoundWrapper(),
+	}
+}
+
+// GetItem - возвращает статус доставки указанного сообщения.
+func (sv *StatusService) GetItem(ctx context.Context, messageID uint64) (entity.DeliveryStatus, error) {
+	if messageID == 0 {
+		return entity.DeliveryStatus{}, errors.ErrInternalIncorrectInputData.WithDetails("messageID is zero")
+	}
+
+	item, err := sv.storage.FetchOne(ctx, messageID)
+	if err != nil {
+		return entity.DeliveryStatus{}, sv.errorWrapper.Wrap(err, "messageId", messageID)
+	}
+
+	return item, nil
+}
+
+// GetListByRecipient - возвращает статусы доставки последних сообщений указанного получателя
+// (email адреса, ID чата или номера телефона), начиная с самых новых.
+func (sv *StatusService) GetListByRecipient(ctx context.Context, recipient string, limit int) ([]entity.DeliveryStatus, error) {
+	recipient = NormalizeRecipient(recipient)
+
+	if recipient == "" {
+		return nil, errors.ErrInternalIncorrectInputData.WithDetails("recipient is empty")
+	}
+
+	if limit < 1 {
+		limit = defaultListLimit
+	}
+
+	items, err := sv.storage.FetchByRecipient(ctx, recipient, limit)
+	if err != nil {
+		return nil, sv.errorWrapper.Wrap(err, "recipient", recipient)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,100 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/enum/deliverystatus"
+	mrqueuedto "github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+)
+
+type (
+	// StatusTracker - обновляет статусы доставки сообщений по результатам попыток их отправки
+	// (вызывается обработчиком сообщений) и по переходам элементов очереди (является mrqueue.TransitionObserver):
+	//   - PROCESSING -> CRASHED: сообщение не может быть отправлено (FAILED);
+	//   - RETRY -> REMOVED: попытки отправки сообщения исчерпаны (FAILED).
+	StatusTracker struct {
+		storage   trackerStorage
+		errorFunc func(ctx context.Context, err error)
+		nowFunc   func() time.Time
+	}
+
+	trackerStorage interface {
+		UpdateAttempt(ctx context.Context, messageID uint64, provider, providerMessageID, lastError string, sentAt *time.Time) error
+		UpdateStatus(ctx context.Context, messageIDs []uint64, status deliverystatus.Enum, cause string) error
+	}
+)
+
+// NewStatusTracker - создаёт объект StatusTracker.
+func NewStatusTracker(storage trackerStorage, opts ...TrackerOption) *StatusTracker {
+	o := trackerOptions{
+		tracker: &StatusTracker{
+			storage:   storage,
+			errorFunc: func(_ context.Context, _ error) {},
+			nowFunc:   time.Now,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.tracker
+}
+
+// TrackAttempt - фиксирует результат попытки отправки сообщения.
+// Ошибка сохранения статуса не должна приводить к повторной отправке сообщения,
+// поэтому она не возвращается, а передаётся в errorFunc.
+func (t *StatusTracker) TrackAttempt(ctx context.Context, messageID uint64, receipt *mrmailer.DeliveryReceipt, sendErr error) {
+	var (
+		sentAt    *time.Time
+		lastError string
+	)
+
+	if sendErr == nil {
+		now := t.nowFunc().UTC()
+		sentAt = &now
+	} else {
+		lastError = sendErr.Error()
+	}
+
+	err := t.storage.UpdateAttempt(ctx, messageID, receipt.Provider(), receipt.ProviderMessageID(), lastError, sentAt)
+	if err != nil {
+		t.errorFunc(ctx, errors.WrapInternalError(err, "tracking delivery attempt failed", "messageId", messageID))
+	}
+}
+
+// TrackDropped - переводит сообщение, от отправки которого отказались (например, из-за списка подавления),
+// в статус DROPPED с указанием причины, ошибка сохранения статуса передаётся в errorFunc.
+func (t *StatusTracker) TrackDropped(ctx context.Context, messageID uint64, cause string) {
+	if err := t.storage.UpdateStatus(ctx, []uint64{messageID}, deliverystatus.Dropped, cause); err != nil {
+		t.errorFunc(ctx, errors.WrapInternalError(err, "tracking dropped message failed", "messageId", messageID))
+	}
+}
+
+// OnTransition - переводит сообщения, которые больше не будут отправляться, в статус FAILED.
+func (t *StatusTracker) OnTransition(ctx context.Context, transitions []mrqueuedto.Transition) error {
+	// ID сообщений группируются по причине перехода, чтобы обновить их одним запросом
+	failedIDs := make(map[string][]uint64)
+
+	for _, transition := range transitions {
+		isCrashed := transition.To == itemstatus.Crashed
+		isExhausted := transition.From == itemstatus.Retry && transition.To == itemstatus.Removed
+
+		if isCrashed || isExhausted {
+			failedIDs[transition.Cause] = append(failedIDs[transition.Cause], transition.ItemID)
+		}
+	}
+
+	for cause, ids := range failedIDs {
+		if err := t.storage.UpdateStatus(ctx, ids, deliverystatus.Failed, cause); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
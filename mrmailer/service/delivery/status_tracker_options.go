@@ -0,0 +1,21 @@
+package delivery
+
+import "context"
+
+type (
+	// TrackerOption - настройка объекта StatusTracker.
+	TrackerOption func(o *trackerOptions)
+
+	trackerOptions struct {
+		tracker *StatusTracker
+	}
+)
+
+// WithErrorFunc - устанавливает функцию обработки ошибок сохранения статусов доставки сообщений.
+func WithErrorFunc(value func(ctx context.Context, err error)) TrackerOption {
+	return func(o *trackerOptions) {
+		if value != nil {
+			o.tracker.errorFunc = value
+		}
+	}
+}
@@ -2,6 +2,7 @@ package produce
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/mondegor/go-core/errors"
@@ -11,13 +12,29 @@ import (
 	"github.com/mondegor/go-components/mrmailer"
 	"github.com/mondegor/go-components/mrmailer/dto"
 	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/enum/deliverystatus"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailaddr"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailheader"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/messengergate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/pushgate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/smsgate"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/webhook"
+	"github.com/mondegor/go-components/mrmailer/service/delivery"
+	"github.com/mondegor/go-components/mrmailer/service/sendwindow"
 	"github.com/mondegor/go-components/mrqueue"
 	mrqueuedto "github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/tracing"
 )
 
 const (
 	defaultRetryAttempts   = 3
 	defaultDelayCorrection = 15 * time.Second
+
+	defaultMaxRecipients      = 50
+	defaultMaxAttachmentSize  = 10 * 1024 * 1024 // 10 MiB
+	defaultMaxAttachmentsSize = 20 * 1024 * 1024 // 20 MiB (с учётом base64 письмо будет около 27 MiB)
+
+	spanNameEnqueue = "mrmailer.enqueue"
 )
 
 type (
@@ -29,13 +46,38 @@ type (
 		useCaseQueue      mrqueue.Producer
 		errorWrapper      errors.Wrapper
 		traceManager      mrtrace.ContextManager
+		tracer            mrtrace.Tracer    // OPTIONAL
+		statusStorage     statusStorage     // OPTIONAL
+		suppressionFilter suppressionFilter // OPTIONAL
+		messengerSenders  messengerSenders  // OPTIONAL
+		sendWindowPlanner sendWindowPlanner
 		retryAttempts     int16
 		delayCorrection   time.Duration
+
+		maxRecipients      int
+		maxAttachmentSize  int
+		maxAttachmentsSize int
 	}
 
 	messageStorage interface {
 		Insert(ctx context.Context, rows []entity.Message) error
 	}
+
+	statusStorage interface {
+		Insert(ctx context.Context, rows []entity.DeliveryStatus) error
+	}
+
+	suppressionFilter interface {
+		ApplyBatch(ctx context.Context, messages []entity.Message) (filtered []entity.Message, causes []string, err error)
+	}
+
+	messengerSenders interface {
+		HasSender(from string) bool
+	}
+
+	sendWindowPlanner interface {
+		Plan(channel string, window dto.SendWindow, sendAfter time.Time) (time.Time, error)
+	}
 )
 
 // New - создаёт объект MessageProducer.
@@ -55,8 +97,13 @@ func New(
 			useCaseQueue:      useCaseQueue,
 			errorWrapper:      errors.NewServiceOperationFailedWrapper(),
 			traceManager:      traceManager,
+			sendWindowPlanner: &sendwindow.Planner{},
 			retryAttempts:     defaultRetryAttempts,
 			delayCorrection:   defaultDelayCorrection,
+
+			maxRecipients:      defaultMaxRecipients,
+			maxAttachmentSize:  defaultMaxAttachmentSize,
+			maxAttachmentsSize: defaultMaxAttachmentsSize,
 		},
 	}
 
@@ -68,11 +115,18 @@ func New(
 }
 
 // SendMessage - отправляет указанное сообщение.
-func (sv *MessageProducer) SendMessage(ctx context.Context, message dto.Message) error {
-	if err := sv.checkMessage(message); err != nil {
+func (sv *MessageProducer) SendMessage(ctx context.Context, message dto.Message) (err error) {
+	if err = sv.checkMessage(message); err != nil {
 		return sv.errorWrapper.Wrap(err, "channel", message.Channel)
 	}
 
+	ctx = tracing.WithChildSpan(ctx)
+	startedAt := time.Now()
+
+	defer func() {
+		tracing.Trace(ctx, sv.tracer, spanNameEnqueue, startedAt, err, "channel", message.Channel)
+	}()
+
 	nextID, err := sv.sequenceGenerator.Next(ctx)
 	if err != nil {
 		return sv.errorWrapper.Wrap(err)
@@ -86,31 +140,37 @@ func (sv *MessageProducer) SendMessage(ctx context.Context, message dto.Message)
 
 	item.Data.Header = sv.prepareHeader(ctx, item.Data.Header)
 
+	readyDelayed, err := sv.getReadyDelayed(message)
+	if err != nil {
+		return sv.errorWrapper.Wrap(err, "channel", message.Channel)
+	}
+
 	queueItem := mrqueuedto.Item{
 		ID:            nextID,
-		ReadyDelayed:  sv.getReadyDelayed(message),
+		ReadyDelayed:  readyDelayed,
 		RetryAttempts: sv.getRetryAttempts(message),
 	}
 
-	return sv.txManager.Do(ctx, func(ctx context.Context) error {
-		if err := sv.storage.Insert(ctx, []entity.Message{item}); err != nil {
-			return sv.errorWrapper.Wrap(err)
-		}
-
-		return sv.useCaseQueue.Append(ctx, queueItem)
-	})
+	return sv.enqueue(ctx, []entity.Message{item}, []mrqueuedto.Item{queueItem})
 }
 
 // Send - отправляет указанный список сообщений.
-func (sv *MessageProducer) Send(ctx context.Context, messages ...dto.Message) error {
+func (sv *MessageProducer) Send(ctx context.Context, messages ...dto.Message) (err error) {
 	for i := range messages {
-		if err := sv.checkMessage(messages[i]); err != nil {
+		if err = sv.checkMessage(messages[i]); err != nil {
 			return sv.errorWrapper.Wrap(err, "channel", messages[i].Channel)
 		}
 	}
 
 	countMessages := len(messages)
 
+	ctx = tracing.WithChildSpan(ctx)
+	startedAt := time.Now()
+
+	defer func() {
+		tracing.Trace(ctx, sv.tracer, spanNameEnqueue, startedAt, err, "count", countMessages)
+	}()
+
 	nextIDs, err := sv.sequenceGenerator.MultiNext(ctx, countMessages)
 	if err != nil {
 		return sv.errorWrapper.Wrap(err)
@@ -131,23 +191,124 @@ func (sv *MessageProducer) Send(ctx context.Context, messages ...dto.Message) er
 
 		items[i].Data.Header = sv.prepareHeader(ctx, items[i].Data.Header)
 
+		var readyDelayed time.Duration
+
+		if readyDelayed, err = sv.getReadyDelayed(messages[i]); err != nil {
+			return sv.errorWrapper.Wrap(err, "channel", messages[i].Channel)
+		}
+
 		queueItems[i] = mrqueuedto.Item{
 			ID:            nextID,
-			ReadyDelayed:  sv.getReadyDelayed(messages[i]),
+			ReadyDelayed:  readyDelayed,
 			RetryAttempts: sv.getRetryAttempts(messages[i]),
 		}
 	}
 
+	return sv.enqueue(ctx, items, queueItems)
+}
+
+// enqueue - сохраняет сообщения и размещает их в очереди.
+// Сообщения, которые некому отправлять из-за списка подавления, в очередь не попадают,
+// для них сохраняется только статус доставки DROPPED.
+func (sv *MessageProducer) enqueue(ctx context.Context, items []entity.Message, queueItems []mrqueuedto.Item) error {
+	items, queueItems, dropped, err := sv.applySuppression(ctx, items, queueItems)
+	if err != nil {
+		return sv.errorWrapper.Wrap(err)
+	}
+
 	return sv.txManager.Do(ctx, func(ctx context.Context) error {
-		if err := sv.storage.Insert(ctx, items); err != nil {
-			return sv.errorWrapper.Wrap(err)
+		if len(items) > 0 {
+			if err := sv.storage.Insert(ctx, items); err != nil {
+				return sv.errorWrapper.Wrap(err)
+			}
+		}
+
+		if err := sv.insertStatuses(ctx, items, dropped); err != nil {
+			return err
+		}
+
+		if len(queueItems) == 0 {
+			return nil
 		}
 
 		return sv.useCaseQueue.Append(ctx, queueItems...)
 	})
 }
 
+// applySuppression - исключает из сообщений получателей, находящихся в списке подавления,
+// и возвращает отдельно статусы сообщений, от отправки которых пришлось отказаться.
+// Список подавления запрашивается один раз для всех сообщений.
+func (sv *MessageProducer) applySuppression(
+	ctx context.Context,
+	items []entity.Message,
+	queueItems []mrqueuedto.Item,
+) ([]entity.Message, []mrqueuedto.Item, []entity.DeliveryStatus, error) {
+	if sv.suppressionFilter == nil {
+		return items, queueItems, nil, nil
+	}
+
+	filtered, causes, err := sv.suppressionFilter.ApplyBatch(ctx, items)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		dropped []entity.DeliveryStatus
+		count   int
+	)
+
+	for i := range items {
+		if causes[i] != "" {
+			dropped = append(dropped, sv.makeStatus(items[i], deliverystatus.Dropped, causes[i]))
+
+			continue
+		}
+
+		items[count] = filtered[i]
+		queueItems[count] = queueItems[i]
+		count++
+	}
+
+	return items[:count], queueItems[:count], dropped, nil
+}
+
+// insertStatuses - сохраняет для размещённых в очереди сообщений статус доставки QUEUED,
+// а также указанные статусы отклонённых сообщений, если хранилище статусов не установлено, то ничего не делает.
+func (sv *MessageProducer) insertStatuses(ctx context.Context, items []entity.Message, dropped []entity.DeliveryStatus) error {
+	if sv.statusStorage == nil {
+		return nil
+	}
+
+	rows := make([]entity.DeliveryStatus, 0, len(items)+len(dropped))
+
+	for i := range items {
+		rows = append(rows, sv.makeStatus(items[i], deliverystatus.Queued, ""))
+	}
+
+	if err := sv.statusStorage.Insert(ctx, append(rows, dropped...)); err != nil {
+		return sv.errorWrapper.Wrap(err)
+	}
+
+	return nil
+}
+
+func (sv *MessageProducer) makeStatus(item entity.Message, status deliverystatus.Enum, cause string) entity.DeliveryStatus {
+	return entity.DeliveryStatus{
+		MessageID:  item.ID,
+		Channel:    item.Channel,
+		Recipients: delivery.Recipients(item.Data),
+		Status:     status,
+		LastError:  cause,
+	}
+}
+
 func (sv *MessageProducer) checkMessage(message dto.Message) error {
+	if message.SendWindow != nil {
+		if _, err := sendwindow.Parse(*message.SendWindow); err != nil {
+			return mrmailer.ErrInternalCheckMessageSendWindowInvalid.Wrap(err, "channel", message.Channel)
+		}
+	}
+
 	var countBodies int
 
 	if message.Data.Mail != nil {
@@ -162,7 +323,60 @@ func (sv *MessageProducer) checkMessage(message dto.Message) error {
 		countBodies++
 	}
 
+	if message.Data.Webhook != nil {
+		countBodies++
+	}
+
+	if message.Data.Push != nil {
+		countBodies++
+	}
+
 	if countBodies == 1 {
+		if message.Data.Mail != nil {
+			if err := sv.checkMailRecipients(message.Channel, message.Data.Mail); err != nil {
+				return err
+			}
+
+			if _, err := mailheader.Normalize(message.Data.Mail.Headers); err != nil {
+				return mrmailer.ErrInternalCheckMessageHeadersInvalid.Wrap(err, "channel", message.Channel)
+			}
+
+			return sv.checkMailAttachments(message.Channel, message.Data.Mail.Attachments)
+		}
+
+		if message.Data.Messenger != nil {
+			if err := messengergate.Validate(message.Data.Messenger); err != nil {
+				return mrmailer.ErrInternalCheckMessageMessengerInvalid.Wrap(err, "channel", message.Channel)
+			}
+		}
+
+		if message.Data.Messenger != nil && sv.messengerSenders != nil {
+			if !sv.messengerSenders.HasSender(message.Data.Messenger.From) {
+				return mrmailer.ErrInternalCheckMessageMessengerFromUnknown.New(
+					"channel", message.Channel,
+					"from", message.Data.Messenger.From,
+				)
+			}
+		}
+
+		if message.Data.SMS != nil {
+			if err := smsgate.Validate(message.Data.SMS); err != nil {
+				return mrmailer.ErrInternalCheckMessageSMSInvalid.Wrap(err, "channel", message.Channel)
+			}
+		}
+
+		if message.Data.Webhook != nil {
+			if err := webhook.Validate(message.Data.Webhook); err != nil {
+				return mrmailer.ErrInternalCheckMessageWebhookInvalid.Wrap(err, "channel", message.Channel)
+			}
+		}
+
+		if message.Data.Push != nil {
+			if err := pushgate.Validate(message.Data.Push); err != nil {
+				return mrmailer.ErrInternalCheckMessagePushInvalid.Wrap(err, "channel", message.Channel)
+			}
+		}
+
 		return nil
 	}
 
@@ -173,6 +387,77 @@ func (sv *MessageProducer) checkMessage(message dto.Message) error {
 	return mrmailer.ErrInternalCheckMessageHasNotData.New("channel", message.Channel)
 }
 
+// checkMailRecipients - проверяет корректность адресов получателей письма и их кол-во.
+func (sv *MessageProducer) checkMailRecipients(channel string, data *dto.DataMail) error {
+	recipients, err := mailaddr.ParseRecipients(data.To, data.Cc, data.Bcc)
+	if err != nil {
+		return mrmailer.ErrInternalCheckMessageRecipientsInvalid.Wrap(err, "channel", channel)
+	}
+
+	if count := recipients.Count(); count > sv.maxRecipients {
+		return mrmailer.ErrInternalCheckMessageTooManyRecipients.New(
+			"channel", channel,
+			"count", count,
+			"maxCount", sv.maxRecipients,
+		)
+	}
+
+	return nil
+}
+
+// checkMailAttachments - проверяет корректность вложений письма и их размер.
+// Размер вложений, указанных в виде ссылки на внешнее хранилище, здесь не учитывается,
+// он проверяется при отправке письма (см. adapter.WithMaxAttachmentSize).
+func (sv *MessageProducer) checkMailAttachments(channel string, attachments []entity.MailAttachment) error {
+	var totalSize int
+
+	for _, attachment := range attachments {
+		if attachment.Name == "" && attachment.ContentID == "" {
+			return mrmailer.ErrInternalCheckMessageAttachmentInvalid.New(
+				"channel", channel,
+				"reason", "name and content id are empty",
+			)
+		}
+
+		if len(attachment.Content) == 0 && attachment.StorageRef == "" {
+			return mrmailer.ErrInternalCheckMessageAttachmentInvalid.New(
+				"channel", channel,
+				"name", attachment.Name,
+				"reason", "content and storage reference are empty",
+			)
+		}
+
+		if strings.ContainsAny(attachment.ContentID, "<> \t\r\n") {
+			return mrmailer.ErrInternalCheckMessageAttachmentInvalid.New(
+				"channel", channel,
+				"name", attachment.Name,
+				"reason", "content id contains invalid characters",
+			)
+		}
+
+		if size := len(attachment.Content); size > sv.maxAttachmentSize {
+			return mrmailer.ErrInternalCheckMessageAttachmentsTooLarge.New(
+				"channel", channel,
+				"name", attachment.Name,
+				"size", size,
+				"maxSize", sv.maxAttachmentSize,
+			)
+		}
+
+		totalSize += len(attachment.Content)
+	}
+
+	if totalSize > sv.maxAttachmentsSize {
+		return mrmailer.ErrInternalCheckMessageAttachmentsTooLarge.New(
+			"channel", channel,
+			"size", totalSize,
+			"maxSize", sv.maxAttachmentsSize,
+		)
+	}
+
+	return nil
+}
+
 func (sv *MessageProducer) prepareHeader(ctx context.Context, header map[string]string) map[string]string {
 	if header == nil {
 		header = make(map[string]string, 4)
@@ -190,19 +475,36 @@ func (sv *MessageProducer) prepareHeader(ctx context.Context, header map[string]
 		}
 	}
 
+	// контекст трассировки текущей операции размещения переносится через заголовок сообщения,
+	// заменяя ранее указанный (например, скопированный из заголовка уведомления)
+	tracing.Inject(ctx, header, "")
+	tracing.MarkEnqueued(header, "", time.Now())
+
 	return header
 }
 
-func (sv *MessageProducer) getReadyDelayed(message dto.Message) time.Duration {
-	if message.SendAfter.IsZero() {
-		return 0
+// getReadyDelayed - возвращает задержку, после которой сообщение станет доступно для отправки,
+// если для сообщения указан интервал отправки, то время отправки переносится в этот интервал.
+func (sv *MessageProducer) getReadyDelayed(message dto.Message) (time.Duration, error) {
+	sendAfter := message.SendAfter
+
+	if message.SendWindow != nil {
+		var err error
+
+		if sendAfter, err = sv.sendWindowPlanner.Plan(message.Channel, *message.SendWindow, sendAfter); err != nil {
+			return 0, err
+		}
+	}
+
+	if sendAfter.IsZero() {
+		return 0, nil
 	}
 
-	if delayPeriod := time.Until(message.SendAfter); (delayPeriod / time.Second) > sv.delayCorrection {
-		return delayPeriod
+	if delayPeriod := time.Until(sendAfter); delayPeriod > sv.delayCorrection {
+		return delayPeriod, nil
 	}
 
-	return 0
+	return 0, nil
 }
 
 func (sv *MessageProducer) getRetryAttempts(message dto.Message) int16 {
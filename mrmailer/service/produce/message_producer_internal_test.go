@@ -0,0 +1,56 @@
+package produce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/dto"
+)
+
+func TestMessageProducer_getReadyDelayed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		sendAfter time.Duration
+		want      time.Duration
+	}{
+		{
+			name: "send after is not specified",
+		},
+		{
+			name:      "send after is in the past",
+			sendAfter: -time.Hour,
+		},
+		{
+			name:      "delay within correction is ignored",
+			sendAfter: 5 * time.Second,
+		},
+		{
+			name:      "delay beyond correction is kept",
+			sendAfter: time.Hour,
+			want:      time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			producer := New(nil, nil, nil, nil, nil)
+
+			var message dto.Message
+
+			if tt.sendAfter != 0 {
+				message.SendAfter = time.Now().Add(tt.sendAfter)
+			}
+
+			got, err := producer.getReadyDelayed(message)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, float64(time.Second))
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package produce
 
-import "time"
+import (
+	"time"
+
+	"github.com/mondegor/go-core/mrtrace"
+)
 
 type (
 	// Option - настройка объекта MessageProducer.
@@ -25,3 +29,63 @@ func WithDelayCorrection(value time.Duration) Option {
 		o.sender.delayCorrection = value
 	}
 }
+
+// WithMaxRecipients - устанавливает максимальное кол-во получателей одного письма (To, Cc, Bcc вместе).
+func WithMaxRecipients(value int) Option {
+	return func(o *options) {
+		o.sender.maxRecipients = value
+	}
+}
+
+// WithMaxAttachmentSize - устанавливает максимальный размер одного вложения письма (в байтах).
+func WithMaxAttachmentSize(value int) Option {
+	return func(o *options) {
+		o.sender.maxAttachmentSize = value
+	}
+}
+
+// WithMaxAttachmentsSize - устанавливает максимальный суммарный размер всех вложений письма (в байтах).
+func WithMaxAttachmentsSize(value int) Option {
+	return func(o *options) {
+		o.sender.maxAttachmentsSize = value
+	}
+}
+
+// WithTracer - устанавливает трейсер, которому передаются спаны размещения сообщений в очереди.
+func WithTracer(value mrtrace.Tracer) Option {
+	return func(o *options) {
+		o.sender.tracer = value
+	}
+}
+
+// WithStatusStorage - устанавливает хранилище статусов доставки, в котором при размещении
+// сообщений в очереди сохраняется их статус QUEUED (например, repository.DeliveryStatusPostgres).
+func WithStatusStorage(value statusStorage) Option {
+	return func(o *options) {
+		o.sender.statusStorage = value
+	}
+}
+
+// WithSendWindowPlanner - устанавливает планировщик, переносящий время отправки сообщений
+// в указанный для получателя интервал отправки (например, sendwindow.Planner со срочными каналами).
+func WithSendWindowPlanner(value sendWindowPlanner) Option {
+	return func(o *options) {
+		o.sender.sendWindowPlanner = value
+	}
+}
+
+// WithSuppressionFilter - устанавливает фильтр, исключающий из сообщений получателей,
+// находящихся в списке подавления (например, suppress.Filter).
+func WithSuppressionFilter(value suppressionFilter) Option {
+	return func(o *options) {
+		o.sender.suppressionFilter = value
+	}
+}
+
+// WithMessengerSenders - устанавливает реестр отправителей сообщений мессенджера (например, adapter.MessengerBots),
+// по которому проверяется отправитель DataMessenger.From, сообщения неизвестных отправителей отклоняются.
+func WithMessengerSenders(value messengerSenders) Option {
+	return func(o *options) {
+		o.sender.messengerSenders = value
+	}
+}
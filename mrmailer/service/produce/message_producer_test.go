@@ -0,0 +1,164 @@
+package produce_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/dto"
+	"github.com/mondegor/go-components/mrmailer/service/produce"
+)
+
+func newMailMessage(attachments ...dto.MailAttachment) dto.Message {
+	return dto.Message{
+		Channel: "mail",
+		Data: dto.MessageData{
+			Mail: &dto.DataMail{
+				ContentType: "text/plain",
+				To:          "john@localhost",
+				Subject:     "Invoice",
+				Content:     "See attachment",
+				Attachments: attachments,
+			},
+		},
+	}
+}
+
+func TestMessageProducer_SendMessage_CheckMailAttachments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		attachments []dto.MailAttachment
+		wantErr     error
+	}{
+		{
+			name:        "empty name and content id",
+			attachments: []dto.MailAttachment{{Content: []byte("pdf")}},
+			wantErr:     mrmailer.ErrInternalCheckMessageAttachmentInvalid,
+		},
+		{
+			name:        "empty content and storage reference",
+			attachments: []dto.MailAttachment{{Name: "invoice.pdf"}},
+			wantErr:     mrmailer.ErrInternalCheckMessageAttachmentInvalid,
+		},
+		{
+			name:        "invalid content id",
+			attachments: []dto.MailAttachment{{ContentID: "<logo>", Content: []byte("png")}},
+			wantErr:     mrmailer.ErrInternalCheckMessageAttachmentInvalid,
+		},
+		{
+			name:        "attachment is too large",
+			attachments: []dto.MailAttachment{{Name: "invoice.pdf", Content: []byte(strings.Repeat("a", 11))}},
+			wantErr:     mrmailer.ErrInternalCheckMessageAttachmentsTooLarge,
+		},
+		{
+			name: "attachments are too large in total",
+			attachments: []dto.MailAttachment{
+				{Name: "invoice.pdf", Content: []byte(strings.Repeat("a", 10))},
+				{Name: "act.pdf", Content: []byte(strings.Repeat("a", 10))},
+				{Name: "contract.pdf", Content: []byte(strings.Repeat("a", 10))},
+			},
+			wantErr: mrmailer.ErrInternalCheckMessageAttachmentsTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			producer := produce.New(
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				produce.WithMaxAttachmentSize(10),
+				produce.WithMaxAttachmentsSize(25),
+			)
+
+			err := producer.SendMessage(context.Background(), newMailMessage(tt.attachments...))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+type fakeMessengerSenders map[string]bool
+
+func (s fakeMessengerSenders) HasSender(from string) bool {
+	return s[from]
+}
+
+func TestMessageProducer_SendMessage_CheckMessengerFrom(t *testing.T) {
+	t.Parallel()
+
+	producer := produce.New(
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		produce.WithMessengerSenders(fakeMessengerSenders{"shop": true}),
+	)
+
+	message := dto.Message{
+		Channel: "messenger",
+		Data: dto.MessageData{
+			Messenger: &dto.DataMessenger{
+				From:    "support",
+				ChatID:  "100500",
+				Content: "Your order is shipped",
+			},
+		},
+	}
+
+	err := producer.SendMessage(context.Background(), message)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, mrmailer.ErrInternalCheckMessageMessengerFromUnknown)
+}
+
+func TestMessageProducer_SendMessage_CheckSMS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data dto.DataSMS
+	}{
+		{
+			name: "invalid phone",
+			data: dto.DataSMS{Phone: "12345", Content: "Your code is 1234"},
+		},
+		{
+			name: "empty content",
+			data: dto.DataSMS{Phone: "+7 900 123-45-67"},
+		},
+		{
+			name: "invalid sender",
+			data: dto.DataSMS{From: "Sender name is too long", Phone: "+79001234567", Content: "Your code is 1234"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			producer := produce.New(nil, nil, nil, nil, nil)
+
+			message := dto.Message{
+				Channel: "sms",
+				Data: dto.MessageData{
+					SMS: &tt.data,
+				},
+			}
+
+			err := producer.SendMessage(context.Background(), message)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, mrmailer.ErrInternalCheckMessageSMSInvalid)
+		})
+	}
+}
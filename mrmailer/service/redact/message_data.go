@@ -0,0 +1,137 @@
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailaddr"
+	"github.com/mondegor/go-components/mrmailer/service/delivery"
+)
+
+const (
+	hashPrefix = "sha256:"
+)
+
+// MessageData - возвращает данные сообщения без его содержимого: удаляются тема, тексты, кнопки, тело запроса,
+// данные push уведомления и вложения (остаются только их типы), получатели заменяются их хешами (см. HashRecipient).
+// Сохраняются заголовок сообщения (ID корреляции, провайдер, время постановки в очередь и т.д.),
+// отправитель, тип содержимого, ID письма (Message-Id) и адрес веб-хука без параметров запроса.
+func MessageData(data entity.MessageData, hashKey []byte) entity.MessageData {
+	redacted := entity.MessageData{
+		Header: data.Header,
+	}
+
+	switch {
+	case data.Mail != nil:
+		redacted.Mail = &entity.DataMail{
+			ContentType: data.Mail.ContentType,
+			From:        data.Mail.From,
+			To:          hashAddressList(hashKey, data.Mail.To),
+			Cc:          hashAddressList(hashKey, data.Mail.Cc),
+			Bcc:         hashAddressList(hashKey, data.Mail.Bcc),
+			ReplyTo:     data.Mail.ReplyTo,
+			Headers:     mailMessageIDHeader(data.Mail.Headers),
+		}
+
+		for _, attachment := range data.Mail.Attachments {
+			redacted.Mail.Attachments = append(redacted.Mail.Attachments, entity.MailAttachment{ContentType: attachment.ContentType})
+		}
+	case data.Messenger != nil:
+		redacted.Messenger = &entity.DataMessenger{
+			From:               data.Messenger.From,
+			ChatID:             HashRecipient(hashKey, data.Messenger.ChatID),
+			ThreadID:           data.Messenger.ThreadID,
+			ParseMode:          data.Messenger.ParseMode,
+			DisableLinkPreview: data.Messenger.DisableLinkPreview,
+			Silent:             data.Messenger.Silent,
+		}
+	case data.SMS != nil:
+		redacted.SMS = &entity.DataSMS{
+			From:  data.SMS.From,
+			Phone: HashRecipient(hashKey, data.SMS.Phone),
+		}
+	case data.Webhook != nil:
+		redacted.Webhook = &entity.DataWebhook{
+			URL:    webhookURLWithoutQuery(data.Webhook.URL),
+			Method: data.Webhook.Method,
+		}
+	case data.Push != nil:
+		redacted.Push = &entity.DataPush{
+			DeviceToken: HashRecipient(hashKey, data.Push.DeviceToken),
+			TTL:         data.Push.TTL,
+			CollapseKey: data.Push.CollapseKey,
+		}
+	}
+
+	return redacted
+}
+
+// HashRecipient - возвращает хеш получателя в виде sha256:<hex>, получатель предварительно
+// приводится к виду, в котором он хранится в статусах доставки (см. delivery.NormalizeRecipient).
+// Если указан ключ hashKey, то вычисляется HMAC-SHA256, что не позволяет подобрать получателя по хешу
+// без знания ключа. По хешу можно подтвердить, что сообщение было отправлено известному получателю.
+func HashRecipient(hashKey []byte, recipient string) string {
+	if recipient == "" {
+		return ""
+	}
+
+	value := []byte(delivery.NormalizeRecipient(recipient))
+
+	if len(hashKey) == 0 {
+		sum := sha256.Sum256(value)
+
+		return hashPrefix + hex.EncodeToString(sum[:])
+	}
+
+	mac := hmac.New(sha256.New, hashKey)
+	mac.Write(value)
+
+	return hashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// hashAddressList - заменяет каждый адрес списка (RFC 5322) хешем email адреса без имени получателя.
+// Если список не разбирается, то хешируется всё значение целиком.
+func hashAddressList(hashKey []byte, value string) string {
+	list, err := mailaddr.ParseList(value)
+	if err != nil {
+		return HashRecipient(hashKey, value)
+	}
+
+	hashes := make([]string, 0, len(list))
+
+	for _, address := range list {
+		hashes = append(hashes, HashRecipient(hashKey, address.Address))
+	}
+
+	return strings.Join(hashes, ", ")
+}
+
+// mailMessageIDHeader - возвращает из дополнительных заголовков письма только Message-Id, если он указан.
+func mailMessageIDHeader(headers map[string]string) map[string]string {
+	for name, value := range headers {
+		if strings.EqualFold(name, "Message-Id") {
+			return map[string]string{name: value}
+		}
+	}
+
+	return nil
+}
+
+// webhookURLWithoutQuery - возвращает адрес веб-хука без параметров запроса и фрагмента,
+// в которых могут передаваться токены доступа.
+func webhookURLWithoutQuery(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+
+	parsed.User = nil
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+
+	return parsed.String()
+}
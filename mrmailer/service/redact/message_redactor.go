@@ -0,0 +1,89 @@
+package redact
+
+import (
+	"context"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+	"github.com/mondegor/go-core/mrstorage"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+)
+
+const (
+	defaultPeriod = 24 * time.Hour
+)
+
+type (
+	// MessageRedactor - удаляет содержимое доставленных сообщений по истечении указанного периода
+	// после их доставки (см. MessageData) и отмечает время удаления содержимого. Сами сообщения остаются
+	// в качестве подтверждения отправки и удаляются по истечении срока их хранения (см. MessageRemover).
+	MessageRedactor struct {
+		txManager mrstorage.DBTxManager
+		storage   messageStorage
+		period    time.Duration
+		hashKey   []byte
+	}
+
+	messageStorage interface {
+		FetchForRedact(ctx context.Context, period time.Duration, limit int) ([]entity.Message, error)
+		UpdateRedacted(ctx context.Context, rows []entity.Message) error
+	}
+)
+
+// New - создаёт объект MessageRedactor.
+func New(txManager mrstorage.DBTxManager, storage messageStorage, opts ...Option) *MessageRedactor {
+	o := options{
+		redactor: &MessageRedactor{
+			txManager: txManager,
+			storage:   storage,
+			period:    defaultPeriod,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.redactor
+}
+
+// Execute - удаляет пачками по limit записей содержимое сообщений, доставленных ранее указанного периода,
+// пока не будут обработаны все такие сообщения или не истечёт время контекста.
+func (r *MessageRedactor) Execute(ctx context.Context, limit int) error {
+	if limit < 1 {
+		return errors.ErrInternalIncorrectInputData.WithDetails("limit is zero or negative")
+	}
+
+	for {
+		var count int
+
+		err := r.txManager.Do(ctx, func(ctx context.Context) error {
+			rows, err := r.storage.FetchForRedact(ctx, r.period, limit)
+			if err != nil {
+				return err
+			}
+
+			if count = len(rows); count == 0 {
+				return nil
+			}
+
+			for i := range rows {
+				rows[i].Data = MessageData(rows[i].Data, r.hashKey)
+			}
+
+			return r.storage.UpdateRedacted(ctx, rows)
+		})
+		if err != nil {
+			return err
+		}
+
+		if count < limit {
+			return nil
+		}
+
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
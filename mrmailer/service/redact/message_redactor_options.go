@@ -0,0 +1,31 @@
+package redact
+
+import (
+	"time"
+)
+
+type (
+	// Option - настройка объекта MessageRedactor.
+	Option func(o *options)
+
+	options struct {
+		redactor *MessageRedactor
+	}
+)
+
+// WithPeriod - устанавливает опцию period (время после доставки сообщения, в течение которого
+// хранится его содержимое) для MessageRedactor.
+func WithPeriod(value time.Duration) Option {
+	return func(o *options) {
+		if value > 0 {
+			o.redactor.period = value
+		}
+	}
+}
+
+// WithHashKey - устанавливает опцию hashKey (ключ HMAC для хешей получателей, см. HashRecipient) для MessageRedactor.
+func WithHashKey(value []byte) Option {
+	return func(o *options) {
+		o.redactor.hashKey = value
+	}
+}
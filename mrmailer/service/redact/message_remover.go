@@ -0,0 +1,56 @@
+package redact
+
+import (
+	"context"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+)
+
+type (
+	// MessageRemover - удаляет сообщения, содержимое которых было удалено (см. MessageRedactor)
+	// ранее указанного срока хранения, отсчитываемого от времени удаления содержимого.
+	MessageRemover struct {
+		storage   messageRemoverStorage
+		retention time.Duration
+	}
+
+	messageRemoverStorage interface {
+		DeleteRedacted(ctx context.Context, retention time.Duration, limit int) (int, error)
+	}
+)
+
+// NewMessageRemover - создаёт объект MessageRemover.
+func NewMessageRemover(storage messageRemoverStorage, retention time.Duration) *MessageRemover {
+	return &MessageRemover{
+		storage:   storage,
+		retention: retention,
+	}
+}
+
+// Execute - удаляет пачками по limit записей сообщения, срок хранения которых истёк,
+// пока не будут удалены все такие сообщения или не истечёт время контекста.
+func (r *MessageRemover) Execute(ctx context.Context, limit int) error {
+	if limit < 1 {
+		return errors.ErrInternalIncorrectInputData.WithDetails("limit is zero or negative")
+	}
+
+	if r.retention <= 0 {
+		return errors.ErrInternalIncorrectInputData.WithDetails("retention is zero or negative")
+	}
+
+	for {
+		count, err := r.storage.DeleteRedacted(ctx, r.retention, limit)
+		if err != nil {
+			return err
+		}
+
+		if count < limit {
+			return nil
+		}
+
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
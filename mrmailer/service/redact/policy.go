@@ -0,0 +1,52 @@
+package redact
+
+import (
+	"time"
+
+	"github.com/mondegor/go-components/mrmailer"
+)
+
+type (
+	// Policy - политика хранения доставленных сообщений: содержимое сообщения удаляется по истечении period
+	// после его доставки, а само сообщение (подтверждение отправки) - по истечении retention
+	// после удаления содержимого. Нулевое значение означает, что удаление содержимого не используется.
+	Policy struct {
+		period    time.Duration
+		retention time.Duration
+		hashKey   []byte
+	}
+)
+
+// NewPolicy - создаёт объект Policy, hashKey - ключ HMAC для хешей получателей (см. HashRecipient).
+// Возвращается ошибка, если период или срок хранения не указаны или срок хранения не превышает период.
+func NewPolicy(period, retention time.Duration, hashKey []byte) (Policy, error) {
+	if period <= 0 || retention <= period {
+		return Policy{}, mrmailer.ErrInternalRedactRetentionTooShort.New("period", period, "retention", retention)
+	}
+
+	return Policy{
+		period:    period,
+		retention: retention,
+		hashKey:   hashKey,
+	}, nil
+}
+
+// IsEnabled - сообщает, используется ли удаление содержимого сообщений.
+func (p Policy) IsEnabled() bool {
+	return p.period > 0
+}
+
+// Period - возвращает время после доставки сообщения, в течение которого хранится его содержимое.
+func (p Policy) Period() time.Duration {
+	return p.period
+}
+
+// Retention - возвращает время после удаления содержимого сообщения, в течение которого хранится само сообщение.
+func (p Policy) Retention() time.Duration {
+	return p.retention
+}
+
+// HashKey - возвращает ключ HMAC для хешей получателей.
+func (p Policy) HashKey() []byte {
+	return p.hashKey
+}
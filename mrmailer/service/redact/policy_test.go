@@ -0,0 +1,66 @@
+package redact_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/service/redact"
+)
+
+func TestNewPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		period    time.Duration
+		retention time.Duration
+		wantErr   bool
+	}{
+		{
+			name:      "retention exceeds period",
+			period:    24 * time.Hour,
+			retention: 90 * 24 * time.Hour,
+		},
+		{
+			name:      "retention equals period",
+			period:    24 * time.Hour,
+			retention: 24 * time.Hour,
+			wantErr:   true,
+		},
+		{
+			name:      "retention is less than period",
+			period:    24 * time.Hour,
+			retention: time.Hour,
+			wantErr:   true,
+		},
+		{
+			name:      "period is not specified",
+			retention: time.Hour,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := redact.NewPolicy(tt.period, tt.retention, []byte("secret"))
+			if tt.wantErr {
+				require.ErrorIs(t, err, mrmailer.ErrInternalRedactRetentionTooShort)
+				assert.False(t, got.IsEnabled())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, got.IsEnabled())
+			assert.Equal(t, tt.period, got.Period())
+			assert.Equal(t, tt.retention, got.Retention())
+			assert.Equal(t, []byte("secret"), got.HashKey())
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package sendwindow
+
+import (
+	"time"
+
+	"github.com/mondegor/go-components/mrmailer/dto"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/routing"
+)
+
+type (
+	// Planner - вычисляет время отправки сообщения с учётом интервала отправки,
+	// заданного для получателя (тихие часы). Нулевое значение применяет интервалы к сообщениям всех каналов.
+	Planner struct {
+		urgent []func(channel string) bool
+	}
+)
+
+// NewPlanner - создаёт объект Planner.
+func NewPlanner(opts ...PlannerOption) (*Planner, error) {
+	o := plannerOptions{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := &Planner{
+		urgent: make([]func(channel string) bool, 0, len(o.urgentChannels)),
+	}
+
+	for _, pattern := range o.urgentChannels {
+		match, err := routing.CompilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		p.urgent = append(p.urgent, match)
+	}
+
+	return p, nil
+}
+
+// Plan - возвращает время, после которого сообщение указанного канала можно отправлять:
+// если sendAfter (или текущее время, когда sendAfter не задано или уже прошло) не попадает в интервал,
+// то сообщение откладывается до ближайшего начала интервала.
+// Для сообщений каналов, указанных в WithUrgentChannels, возвращается sendAfter без изменений.
+func (p *Planner) Plan(channel string, window dto.SendWindow, sendAfter time.Time) (time.Time, error) {
+	if p.isUrgent(channel) {
+		return sendAfter, nil
+	}
+
+	w, err := Parse(window)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if now := time.Now(); sendAfter.Before(now) {
+		sendAfter = now
+	}
+
+	return w.Next(sendAfter), nil
+}
+
+func (p *Planner) isUrgent(channel string) bool {
+	for _, match := range p.urgent {
+		if match(channel) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,18 @@
+package sendwindow
+
+type (
+	// PlannerOption - настройка объекта Planner.
+	PlannerOption func(o *plannerOptions)
+
+	plannerOptions struct {
+		urgentChannels []string
+	}
+)
+
+// WithUrgentChannels - устанавливает шаблоны каналов срочных сообщений (например, кодов входа),
+// которые отправляются без учёта интервала отправки (форматы шаблонов см. routing.Rule).
+func WithUrgentChannels(patterns ...string) PlannerOption {
+	return func(o *plannerOptions) {
+		o.urgentChannels = append(o.urgentChannels, patterns...)
+	}
+}
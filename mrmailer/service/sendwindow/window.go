@@ -0,0 +1,99 @@
+package sendwindow
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mondegor/go-components/mrmailer/dto"
+)
+
+const (
+	clockLayout  = "15:04"
+	secondsInDay = 24 * 60 * 60
+)
+
+// ErrWindowInvalid - интервал отправки сообщения некорректен.
+var ErrWindowInvalid = errors.New("send window is invalid")
+
+type (
+	// Window - разобранный интервал местного времени получателя, в который разрешена отправка сообщения.
+	Window struct {
+		from     int // начало интервала в секундах от полуночи
+		to       int // конец интервала в секундах от полуночи
+		location *time.Location
+	}
+)
+
+// Parse - разбирает и проверяет указанный интервал отправки сообщения.
+func Parse(value dto.SendWindow) (Window, error) {
+	from, err := parseClock(value.From)
+	if err != nil {
+		return Window{}, fmt.Errorf("%w: from: %w", ErrWindowInvalid, err)
+	}
+
+	to, err := parseClock(value.To)
+	if err != nil {
+		return Window{}, fmt.Errorf("%w: to: %w", ErrWindowInvalid, err)
+	}
+
+	if from == to {
+		return Window{}, fmt.Errorf("%w: from '%s' is equal to '%s'", ErrWindowInvalid, value.From, value.To)
+	}
+
+	location := time.UTC
+
+	if value.TimeZone != "" {
+		if location, err = time.LoadLocation(value.TimeZone); err != nil {
+			return Window{}, fmt.Errorf("%w: time zone: %w", ErrWindowInvalid, err)
+		}
+	}
+
+	return Window{
+		from:     from,
+		to:       to,
+		location: location,
+	}, nil
+}
+
+// Contains - сообщает, попадает ли указанное время в интервал.
+func (w Window) Contains(t time.Time) bool {
+	local := t.In(w.location)
+	clock := local.Hour()*3600 + local.Minute()*60 + local.Second()
+
+	if w.from < w.to {
+		return clock >= w.from && clock < w.to
+	}
+
+	// интервал, переходящий через полночь
+	return clock >= w.from || clock < w.to
+}
+
+// Next - возвращает ближайшее время не раньше указанного, которое попадает в интервал:
+// само указанное время, если оно попадает в интервал, иначе ближайшее начало интервала.
+func (w Window) Next(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+
+	local := t.In(w.location)
+	year, month, day := local.Date()
+
+	// начало интервала в текущих сутках (с учётом перехода на летнее/зимнее время)
+	start := time.Date(year, month, day, w.from/3600, w.from%3600/60, w.from%60, 0, w.location)
+
+	if !start.After(local) {
+		start = time.Date(year, month, day+1, w.from/3600, w.from%3600/60, w.from%60, 0, w.location)
+	}
+
+	return start.In(t.Location())
+}
+
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse(clockLayout, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return (clock.Hour()*3600 + clock.Minute()*60) % secondsInDay, nil
+}
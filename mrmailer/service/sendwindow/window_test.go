@@ -0,0 +1,121 @@
+package sendwindow_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/dto"
+	"github.com/mondegor/go-components/mrmailer/service/sendwindow"
+)
+
+func TestWindow_Next(t *testing.T) {
+	t.Parallel()
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		window dto.SendWindow
+		at     time.Time
+		want   time.Time
+	}{
+		{
+			name:   "inside day window",
+			window: dto.SendWindow{From: "09:00", To: "21:00", TimeZone: "Europe/Moscow"},
+			at:     time.Date(2026, 10, 19, 12, 30, 0, 0, moscow),
+			want:   time.Date(2026, 10, 19, 12, 30, 0, 0, moscow),
+		},
+		{
+			name:   "before day window",
+			window: dto.SendWindow{From: "09:00", To: "21:00", TimeZone: "Europe/Moscow"},
+			at:     time.Date(2026, 10, 19, 3, 0, 0, 0, moscow),
+			want:   time.Date(2026, 10, 19, 9, 0, 0, 0, moscow),
+		},
+		{
+			name:   "after day window",
+			window: dto.SendWindow{From: "09:00", To: "21:00", TimeZone: "Europe/Moscow"},
+			at:     time.Date(2026, 10, 19, 21, 0, 0, 0, moscow),
+			want:   time.Date(2026, 10, 20, 9, 0, 0, 0, moscow),
+		},
+		{
+			name:   "inside overnight window",
+			window: dto.SendWindow{From: "22:00", To: "06:00"},
+			at:     time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC),
+			want:   time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "outside overnight window",
+			window: dto.SendWindow{From: "22:00", To: "06:00"},
+			at:     time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			want:   time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "recipient time zone differs from sender",
+			window: dto.SendWindow{From: "09:00", To: "21:00", TimeZone: "Asia/Tokyo"},
+			at:     time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC), // 22:00 в Токио
+			want:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),  // 09:00 в Токио
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			window, err := sendwindow.Parse(tt.window)
+			require.NoError(t, err)
+
+			got := window.Next(tt.at)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	invalid := []dto.SendWindow{
+		{From: "", To: "21:00"},
+		{From: "9am", To: "21:00"},
+		{From: "09:00", To: "25:00"},
+		{From: "09:00", To: "09:00"},
+		{From: "09:00", To: "21:00", TimeZone: "Mars/Olympus"},
+	}
+
+	for _, window := range invalid {
+		_, err := sendwindow.Parse(window)
+		require.ErrorIs(t, err, sendwindow.ErrWindowInvalid, window)
+	}
+}
+
+func TestPlanner_Plan(t *testing.T) {
+	t.Parallel()
+
+	planner, err := sendwindow.NewPlanner(sendwindow.WithUrgentChannels("auth/*"))
+	require.NoError(t, err)
+
+	window := dto.SendWindow{From: "09:00", To: "21:00"}
+	sendAfter := time.Date(2100, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	got, err := planner.Plan("reminder/daily", window, sendAfter)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2100, 1, 1, 9, 0, 0, 0, time.UTC), got)
+
+	got, err = planner.Plan("auth/login-code", window, sendAfter)
+	require.NoError(t, err)
+	assert.Equal(t, sendAfter, got)
+
+	got, err = planner.Plan("reminder/daily", window, time.Time{})
+	require.NoError(t, err)
+	assert.False(t, got.Before(time.Now().Add(-time.Minute)))
+}
+
+func TestNewPlanner_InvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	_, err := sendwindow.NewPlanner(sendwindow.WithUrgentChannels("auth/["))
+	require.Error(t, err)
+}
@@ -0,0 +1,220 @@
+package suppress
+
+import (
+	"context"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/mailaddr"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/routing"
+	"github.com/mondegor/go-components/mrmailer/service/delivery"
+)
+
+type (
+	// Filter - исключает из сообщений получателей, находящихся в списке подавления.
+	// Используется при размещении сообщений в очереди и повторно перед их отправкой.
+	Filter struct {
+		storage filterStorage
+		bypass  []func(channel string) bool
+	}
+
+	filterStorage interface {
+		FetchActive(ctx context.Context, recipients []string) ([]entity.Suppression, error)
+	}
+)
+
+// NewFilter - создаёт объект Filter.
+func NewFilter(storage filterStorage, opts ...FilterOption) (*Filter, error) {
+	o := filterOptions{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	f := &Filter{
+		storage: storage,
+		bypass:  make([]func(channel string) bool, 0, len(o.bypassChannels)),
+	}
+
+	for _, pattern := range o.bypassChannels {
+		match, err := routing.CompilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		f.bypass = append(f.bypass, match)
+	}
+
+	return f, nil
+}
+
+// Apply - возвращает сообщение, из которого исключены получатели, находящиеся в списке подавления
+// (см. ApplyBatch), и непустую причину отказа от отправки, если сообщение больше некому отправлять.
+func (f *Filter) Apply(ctx context.Context, message entity.Message) (filtered entity.Message, cause string, err error) {
+	messages, causes, err := f.ApplyBatch(ctx, []entity.Message{message})
+	if err != nil {
+		return entity.Message{}, "", err
+	}
+
+	return messages[0], causes[0], nil
+}
+
+// ApplyBatch - возвращает сообщения, из которых исключены получатели, находящиеся в списке подавления,
+// и для каждого сообщения причину отказа от его отправки (пустую, если сообщение отправляется).
+// Записи списка подавления всех получателей сообщений запрашиваются из хранилища одним запросом.
+// Если подавлены все получатели To письма, то получателем To становится первый оставшийся получатель Cc,
+// а если не осталось и их, то письмо не отправляется (получатели Bcc не переносятся в To,
+// т.к. тогда их адреса станут видны другим получателям скрытой копии).
+// Сообщение также не отправляется, если подавлен получатель SMS, мессенджера, webhook или push уведомления.
+// Сообщения каналов, указанных в WithBypassChannels, возвращаются без изменений.
+func (f *Filter) ApplyBatch(ctx context.Context, messages []entity.Message) (filtered []entity.Message, causes []string, err error) {
+	filtered = make([]entity.Message, len(messages))
+	causes = make([]string, len(messages))
+	recipients := make([][]string, len(messages))
+
+	var all []string
+
+	for i := range messages {
+		filtered[i] = messages[i]
+
+		if !f.isBypassed(messages[i].Channel) {
+			recipients[i] = delivery.Recipients(messages[i].Data)
+			all = append(all, recipients[i]...)
+		}
+	}
+
+	if len(all) == 0 {
+		return filtered, causes, nil
+	}
+
+	items, err := f.storage.FetchActive(ctx, uniqueValues(all))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(items) == 0 {
+		return filtered, causes, nil
+	}
+
+	for i := range messages {
+		suppressed := channelSuppressions(items, messages[i].Channel, recipients[i])
+		if len(suppressed) == 0 {
+			continue
+		}
+
+		if messages[i].Data.Mail == nil {
+			causes[i] = makeCause(recipients[i], suppressed)
+
+			continue
+		}
+
+		if filtered[i], causes[i], err = f.applyToMail(messages[i], recipients[i], suppressed); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return filtered, causes, nil
+}
+
+func (f *Filter) applyToMail(
+	message entity.Message,
+	recipients []string,
+	suppressed map[string]entity.Suppression,
+) (entity.Message, string, error) {
+	// адреса уже были проверены при получении recipients
+	r, err := mailaddr.ParseRecipients(message.Data.Mail.To, message.Data.Mail.Cc, message.Data.Mail.Bcc)
+	if err != nil {
+		return entity.Message{}, "", err
+	}
+
+	to := excludeAddresses(r.To, suppressed)
+	cc := excludeAddresses(r.Cc, suppressed)
+
+	if len(to) == 0 {
+		if len(cc) == 0 {
+			return message, makeCause(recipients, suppressed), nil
+		}
+
+		to, cc = cc[:1], cc[1:]
+	}
+
+	data := *message.Data.Mail
+	data.To = mailaddr.FormatList(to)
+	data.Cc = mailaddr.FormatList(cc)
+	data.Bcc = mailaddr.FormatList(excludeAddresses(r.Bcc, suppressed))
+
+	message.Data.Mail = &data
+
+	return message, "", nil
+}
+
+func (f *Filter) isBypassed(channel string) bool {
+	for _, match := range f.bypass {
+		if match(channel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// channelSuppressions - возвращает записи списка подавления указанных получателей,
+// которые относятся к указанному каналу или ко всем каналам.
+func channelSuppressions(items []entity.Suppression, channel string, recipients []string) map[string]entity.Suppression {
+	var suppressed map[string]entity.Suppression
+
+	for _, item := range items {
+		if item.Channel != "" && item.Channel != channel {
+			continue
+		}
+
+		if !slices.Contains(recipients, item.Recipient) {
+			continue
+		}
+
+		if suppressed == nil {
+			suppressed = make(map[string]entity.Suppression)
+		}
+
+		// запись конкретного канала приоритетнее записи для всех каналов
+		if _, ok := suppressed[item.Recipient]; !ok || item.Channel != "" {
+			suppressed[item.Recipient] = item
+		}
+	}
+
+	return suppressed
+}
+
+func uniqueValues(values []string) []string {
+	slices.Sort(values)
+
+	return slices.Compact(values)
+}
+
+func excludeAddresses(list []*mail.Address, suppressed map[string]entity.Suppression) []*mail.Address {
+	filtered := make([]*mail.Address, 0, len(list))
+
+	for _, addr := range list {
+		if _, ok := suppressed[strings.ToLower(addr.Address)]; !ok {
+			filtered = append(filtered, addr)
+		}
+	}
+
+	return filtered
+}
+
+// makeCause - формирует причину отказа от отправки сообщения
+// в виде списка подавленных получателей с указанием причин подавления.
+func makeCause(recipients []string, suppressed map[string]entity.Suppression) string {
+	values := make([]string, 0, len(suppressed))
+
+	for _, recipient := range recipients {
+		if item, ok := suppressed[recipient]; ok {
+			values = append(values, recipient+" ("+item.Reason.String()+")")
+		}
+	}
+
+	return "recipients are suppressed: " + strings.Join(values, ", ")
+}
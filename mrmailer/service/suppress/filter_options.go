@@ -0,0 +1,18 @@
+package suppress
+
+type (
+	// FilterOption - настройка объекта Filter.
+	FilterOption func(o *filterOptions)
+
+	filterOptions struct {
+		bypassChannels []string
+	}
+)
+
+// WithBypassChannels - устанавливает шаблоны каналов (например, транзакционных писем),
+// сообщения которых отправляются без учёта списка подавления (форматы шаблонов см. routing.Rule).
+func WithBypassChannels(patterns ...string) FilterOption {
+	return func(o *filterOptions) {
+		o.bypassChannels = append(o.bypassChannels, patterns...)
+	}
+}
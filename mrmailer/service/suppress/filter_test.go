@@ -0,0 +1,148 @@
+package suppress_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/enum/suppressreason"
+	"github.com/mondegor/go-components/mrmailer/service/suppress"
+)
+
+type fakeStorage struct {
+	items []entity.Suppression
+	calls int
+}
+
+func (s *fakeStorage) FetchActive(_ context.Context, recipients []string) ([]entity.Suppression, error) {
+	s.calls++
+
+	rows := make([]entity.Suppression, 0)
+
+	for _, item := range s.items {
+		for _, recipient := range recipients {
+			if item.Recipient == recipient {
+				rows = append(rows, item)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func newMailMessage(channel, to, cc, bcc string) entity.Message {
+	return entity.Message{
+		ID:      1,
+		Channel: channel,
+		Data: entity.MessageData{
+			Mail: &entity.DataMail{To: to, Cc: cc, Bcc: bcc},
+		},
+	}
+}
+
+func TestFilter_Apply(t *testing.T) {
+	t.Parallel()
+
+	storage := &fakeStorage{
+		items: []entity.Suppression{
+			{Recipient: "bounce@localhost", Reason: suppressreason.HardBounce},
+			{Recipient: "unsub@localhost", Channel: "email/marketing", Reason: suppressreason.Unsubscribe},
+			{Recipient: "+79001234567", Reason: suppressreason.Complaint},
+		},
+	}
+
+	filter, err := suppress.NewFilter(storage)
+	require.NoError(t, err)
+
+	// подавленные получатели исключаются из письма
+	message := newMailMessage("email/marketing", "ivan@localhost, Bounce@Localhost", "unsub@localhost", "boss@localhost")
+	filtered, cause, err := filter.Apply(context.Background(), message)
+	require.NoError(t, err)
+	assert.Empty(t, cause)
+	assert.Equal(t, "<ivan@localhost>", filtered.Data.Mail.To)
+	assert.Empty(t, filtered.Data.Mail.Cc)
+	assert.Equal(t, "<boss@localhost>", filtered.Data.Mail.Bcc)
+	assert.Equal(t, "ivan@localhost, Bounce@Localhost", message.Data.Mail.To, "source message must not be changed")
+
+	// запись, относящаяся к другому каналу, не учитывается
+	message = newMailMessage("email/notifier", "unsub@localhost", "", "")
+	filtered, cause, err = filter.Apply(context.Background(), message)
+	require.NoError(t, err)
+	assert.Empty(t, cause)
+	assert.Equal(t, message, filtered)
+
+	// все основные получатели подавлены, поэтому основным получателем становится первый получатель копии
+	message = newMailMessage("email/marketing", "bounce@localhost", "unsub@localhost, ivan@localhost, petr@localhost", "boss@localhost")
+	filtered, cause, err = filter.Apply(context.Background(), message)
+	require.NoError(t, err)
+	assert.Empty(t, cause)
+	assert.Equal(t, "<ivan@localhost>", filtered.Data.Mail.To)
+	assert.Equal(t, "<petr@localhost>", filtered.Data.Mail.Cc)
+	assert.Equal(t, "<boss@localhost>", filtered.Data.Mail.Bcc)
+
+	// не осталось получателей To и Cc, получатели Bcc в To не переносятся
+	message = newMailMessage("email/marketing", "bounce@localhost", "unsub@localhost", "boss@localhost")
+	_, cause, err = filter.Apply(context.Background(), message)
+	require.NoError(t, err)
+	assert.Equal(t, "recipients are suppressed: bounce@localhost (HARD_BOUNCE), unsub@localhost (UNSUBSCRIBE)", cause)
+
+	// подавлен получатель SMS
+	message = entity.Message{ID: 2, Channel: "sms/code", Data: entity.MessageData{SMS: &entity.DataSMS{Phone: "+79001234567"}}}
+	_, cause, err = filter.Apply(context.Background(), message)
+	require.NoError(t, err)
+	assert.Equal(t, "recipients are suppressed: +79001234567 (COMPLAINT)", cause)
+}
+
+func TestFilter_ApplyBatch(t *testing.T) {
+	t.Parallel()
+
+	storage := &fakeStorage{
+		items: []entity.Suppression{
+			{Recipient: "bounce@localhost", Reason: suppressreason.HardBounce},
+			{Recipient: "unsub@localhost", Channel: "email/marketing", Reason: suppressreason.Unsubscribe},
+		},
+	}
+
+	filter, err := suppress.NewFilter(storage)
+	require.NoError(t, err)
+
+	messages := []entity.Message{
+		newMailMessage("email/marketing", "ivan@localhost, bounce@localhost", "", ""),
+		newMailMessage("email/marketing", "unsub@localhost", "", ""),
+		newMailMessage("email/notifier", "unsub@localhost", "", ""),
+	}
+
+	filtered, causes, err := filter.ApplyBatch(context.Background(), messages)
+	require.NoError(t, err)
+	assert.Equal(t, 1, storage.calls, "suppressions must be fetched once per batch")
+	require.Len(t, filtered, 3)
+	assert.Equal(t, []string{"", "recipients are suppressed: unsub@localhost (UNSUBSCRIBE)", ""}, causes)
+	assert.Equal(t, "<ivan@localhost>", filtered[0].Data.Mail.To)
+	assert.Equal(t, messages[2], filtered[2])
+}
+
+func TestFilter_ApplyBypassChannels(t *testing.T) {
+	t.Parallel()
+
+	storage := &fakeStorage{
+		items: []entity.Suppression{
+			{Recipient: "bounce@localhost", Reason: suppressreason.HardBounce},
+		},
+	}
+
+	filter, err := suppress.NewFilter(storage, suppress.WithBypassChannels("email/transactional/**"))
+	require.NoError(t, err)
+
+	message := newMailMessage("email/transactional/ResetPassword", "bounce@localhost", "", "")
+	filtered, cause, err := filter.Apply(context.Background(), message)
+	require.NoError(t, err)
+	assert.Empty(t, cause)
+	assert.Equal(t, message, filtered)
+	assert.Equal(t, 0, storage.calls)
+
+	_, err = suppress.NewFilter(storage, suppress.WithBypassChannels("email/[*"))
+	require.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package suppress
+
+import (
+	"context"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/service/delivery"
+)
+
+type (
+	// SuppressionService - объект для управления списком подавления отправки сообщений.
+	SuppressionService struct {
+		storage      suppressionStorage
+		errorWrapper errors.Wrapper
+	}
+
+	suppressionStorage interface {
+		FetchByRecipient(ctx context.Context, recipient string) ([]entity.Suppression, error)
+		Save(ctx context.Context, row entity.Suppression) error
+		Delete(ctx context.Context, recipient, channel string) error
+	}
+)
+
+// NewSuppressionService - создаёт объект SuppressionService.
+func NewSuppressionService(storage suppressionStorage) *SuppressionService {
+	return &SuppressionService{
+		storage:      storage,
+		errorWrapper: errors.NewServiceOperationFailedWrapper(),
+	}
+}
+
+// GetList - возвращает все записи списка подавления указанного получателя.
+func (sv *SuppressionService) GetList(ctx context.Context, recipient string) ([]entity.Suppression, error) {
+	recipient = delivery.NormalizeRecipient(recipient)
+
+	if recipient == "" {
+		return nil, errors.ErrInternalIncorrectInputData.WithDetails("recipient is empty")
+	}
+
+	items, err := sv.storage.FetchByRecipient(ctx, recipient)
+	if err != nil {
+		return nil, sv.errorWrapper.Wrap(err, "recipient", recipient)
+	}
+
+	return items, nil
+}
+
+// Add - добавляет получателя в список подавления указанного канала (или всех каналов, если канал не указан),
+// если запись уже существует, то обновляются её причина и срок действия.
+func (sv *SuppressionService) Add(ctx context.Context, item entity.Suppression) error {
+	item.Recipient = delivery.NormalizeRecipient(item.Recipient)
+
+	if item.Recipient == "" {
+		return errors.ErrInternalIncorrectInputData.WithDetails("recipient is empty")
+	}
+
+	if item.Reason == 0 {
+		return errors.ErrInternalIncorrectInputData.WithDetails("reason is not specified")
+	}
+
+	if err := sv.storage.Save(ctx, item); err != nil {
+		return sv.errorWrapper.Wrap(err, "recipient", item.Recipient, "channel", item.Channel)
+	}
+
+	return nil
+}
+
+// Remove - удаляет запись списка подавления получателя для указанного канала
+// (при пустом канале удаляется запись, действующая для всех каналов).
+func (sv *SuppressionService) Remove(ctx context.Context, recipient, channel string) error {
+	recipient = delivery.NormalizeRecipient(recipient)
+
+	if recipient == "" {
+		return errors.ErrInternalIncorrectInputData.WithDetails("recipient is empty")
+	}
+
+	if err := sv.storage.Delete(ctx, recipient, channel); err != nil {
+		return sv.errorWrapper.Wrap(err, "recipient", recipient, "channel", channel)
+	}
+
+	return nil
+}
@@ -0,0 +1,3 @@
+-- --------------------------------------------------------------------------------------------------
+
+DROP INDEX sample_schema.ix_notifier_notices_sealed_kid;
@@ -0,0 +1,5 @@
+-- --------------------------------------------------------------------------------------------------
+
+-- OPTIONAL
+-- for reseal task (WithSealer): search of records that are not sealed by the current key
+CREATE INDEX ix_notifier_notices_sealed_kid ON sample_schema.notifier_notices ((notice_data->'_sealed'->>'kid'));
@@ -7,6 +7,7 @@ import (
 	"github.com/mondegor/go-core/mrtrace"
 
 	"github.com/mondegor/go-components/mrnotifier/notifier/dto"
+	"github.com/mondegor/go-components/mrqueue/tracing"
 )
 
 const (
@@ -22,6 +23,15 @@ const (
 	// HeaderCorrelationID - название переменной заголовка, содержащего CorrelationID.
 	HeaderCorrelationID = HeaderPrefix + mrtrace.KeyCorrelationID
 
+	// HeaderTraceParent - название переменной заголовка, содержащего W3C traceparent.
+	HeaderTraceParent = HeaderPrefix + tracing.KeyTraceParent
+
+	// HeaderTraceState - название переменной заголовка, содержащего W3C tracestate.
+	HeaderTraceState = HeaderPrefix + tracing.KeyTraceState
+
+	// HeaderEnqueuedAt - название переменной заголовка, содержащего время размещения уведомления в очереди.
+	HeaderEnqueuedAt = HeaderPrefix + tracing.KeyEnqueuedAt
+
 	// FieldFromName - имя отправителя (адрес подставится тот, с которого произойдёт отправка письма).
 	FieldFromName = "fromName"
 
@@ -26,12 +26,20 @@ type (
 )
 
 // NewBuildManager - создаёт объект BuildManager.
-func NewBuildManager(noticeRenderer noticeRenderer) *BuildManager {
-	return &BuildManager{
-		mailBuilder:      newMailBuilder(noticeRenderer, channelEmail),
-		messengerBuilder: newMessengerBuilder(noticeRenderer, channelMessenger),
-		smsBuilder:       newSMSBuilder(noticeRenderer, channelSMS),
+func NewBuildManager(noticeRenderer noticeRenderer, opts ...Option) *BuildManager {
+	o := options{
+		manager: &BuildManager{
+			mailBuilder:      newMailBuilder(noticeRenderer, channelEmail),
+			messengerBuilder: newMessengerBuilder(noticeRenderer, channelMessenger),
+			smsBuilder:       newSMSBuilder(noticeRenderer, channelSMS),
+		},
 	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o.manager
 }
 
 // Build - возвращает подготовленные уведомления на основе переданных переменных и данных из шаблона уведомления.
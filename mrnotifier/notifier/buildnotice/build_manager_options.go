@@ -0,0 +1,26 @@
+package buildnotice
+
+type (
+	// Option - настройка объекта BuildManager.
+	Option func(o *options)
+
+	options struct {
+		manager *BuildManager
+	}
+)
+
+// WithObserversAsCc - наблюдатели письма (ObserverEmails) добавляются в получатели копии (Cc)
+// исходного письма вместо отправки им отдельных писем с пометкой [Copy].
+func WithObserversAsCc() Option {
+	return func(o *options) {
+		o.manager.mailBuilder.observerMode = observerModeCc
+	}
+}
+
+// WithObserversAsBcc - наблюдатели письма (ObserverEmails) добавляются в получатели скрытой копии (Bcc)
+// исходного письма вместо отправки им отдельных писем с пометкой [Copy].
+func WithObserversAsBcc() Option {
+	return func(o *options) {
+		o.manager.mailBuilder.observerMode = observerModeBcc
+	}
+}
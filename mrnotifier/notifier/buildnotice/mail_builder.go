@@ -1,6 +1,8 @@
 package buildnotice
 
 import (
+	"strings"
+
 	"github.com/mondegor/go-core/errors"
 
 	"github.com/mondegor/go-components/mrnotifier"
@@ -8,13 +10,23 @@ import (
 	templateentity "github.com/mondegor/go-components/mrnotifier/template/entity"
 )
 
+const (
+	observerModeCopy observerMode = iota
+	observerModeCc
+	observerModeBcc
+)
+
 type (
 	// MailBuilder - собирает уведомление/уведомления с использованием шаблона
 	// в email письмо для отправки получателям.
 	MailBuilder struct {
 		noticeRenderer noticeRenderer
 		channel        string
+		observerMode   observerMode
 	}
+
+	// observerMode - способ отправки копии письма наблюдателям.
+	observerMode uint8
 )
 
 // newMailBuilder - создаёт объект MailBuilder.
@@ -25,6 +37,7 @@ func newMailBuilder(
 	return &MailBuilder{
 		noticeRenderer: noticeRenderer,
 		channel:        channel,
+		observerMode:   observerModeCopy,
 	}
 }
 
@@ -46,9 +59,7 @@ func (b *MailBuilder) Build(vars map[string]string, templMail *templateentity.Da
 		return nil, errors.WrapInternalError(err, "content rendering failed")
 	}
 
-	notices := make([]dto.Notice, len(templMail.ObserverEmails)+1)
-
-	notices[0] = dto.Notice{
+	notice := dto.Notice{
 		Channel: b.channel,
 		Data: dto.NoticeData{
 			Mail: &dto.DataMail{
@@ -62,24 +73,55 @@ func (b *MailBuilder) Build(vars map[string]string, templMail *templateentity.Da
 		},
 	}
 
-	if notices[0].Data.Mail.To == "" {
+	if notice.Data.Mail.To == "" {
 		return nil, errors.NewInternalError("field 'to' is empty")
 	}
 
-	for i := 1; i <= len(templMail.ObserverEmails); i++ {
-		if templMail.ObserverEmails[i-1] == "" {
+	switch b.observerMode {
+	case observerModeCc:
+		notice.Data.Mail.Cc = b.joinObservers(templMail.ObserverEmails)
+	case observerModeBcc:
+		notice.Data.Mail.Bcc = b.joinObservers(templMail.ObserverEmails)
+	default:
+		return b.makeObserverCopies(notice, templMail.ObserverEmails), nil
+	}
+
+	return []dto.Notice{notice}, nil
+}
+
+// makeObserverCopies - возвращает уведомление и его копии для каждого из наблюдателей,
+// которые отправляются отдельными письмами с пометкой [Copy] в теме письма.
+func (b *MailBuilder) makeObserverCopies(notice dto.Notice, observerEmails []string) []dto.Notice {
+	notices := make([]dto.Notice, 1, len(observerEmails)+1)
+	notices[0] = notice
+
+	for _, email := range observerEmails {
+		if email == "" {
 			continue
 		}
 
-		notices[i] = notices[0]
+		noticeCopy := notice
+		bodyCopy := *notice.Data.Mail // копируется уведомление
+		noticeCopy.Data.Mail = &bodyCopy
+		noticeCopy.Data.Mail.Subject = "[Copy] " + notice.Data.Mail.Subject + " (" + notice.Data.Mail.To + ")"
+		noticeCopy.Data.Mail.To = email // заменяется получатель
+
+		notices = append(notices, noticeCopy)
+	}
+
+	return notices
+}
+
+func (b *MailBuilder) joinObservers(observerEmails []string) string {
+	emails := make([]string, 0, len(observerEmails))
 
-		bodyCopy := *notices[0].Data.Mail // копируется уведомление
-		notices[i].Data.Mail = &bodyCopy
-		notices[i].Data.Mail.Subject = "[Copy] " + notices[0].Data.Mail.Subject + " (" + notices[0].Data.Mail.To + ")"
-		notices[i].Data.Mail.To = templMail.ObserverEmails[i-1] // заменяется получатель
+	for _, email := range observerEmails {
+		if email != "" {
+			emails = append(emails, email)
+		}
 	}
 
-	return notices, nil
+	return strings.Join(emails, ", ")
 }
 
 func (b *MailBuilder) emailAddressName(varName string, vars map[string]string, defaultAddressName string) string {
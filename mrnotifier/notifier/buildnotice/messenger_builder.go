@@ -6,6 +6,7 @@ import (
 
 	"github.com/mondegor/go-core/errors"
 
+	"github.com/mondegor/go-components/mrmailer/sendmessage/messengergate"
 	"github.com/mondegor/go-components/mrnotifier/notifier/dto"
 	templateentity "github.com/mondegor/go-components/mrnotifier/template/entity"
 )
@@ -27,6 +28,7 @@ var (
 		"*", "\\*",
 	)
 	errInternalValueNotMatchRegexpPattern = errors.NewInternalProto("specified value does not match regexp pattern")
+	errInternalMessengerParseModeUnknown  = errors.NewInternalProto("messenger parse mode is unknown")
 )
 
 func newMessengerBuilder(
@@ -40,30 +42,76 @@ func newMessengerBuilder(
 }
 
 // Build - возвращает подготовленные уведомления на основе переданных переменных и данных из шаблона уведомления.
+// Если в шаблоне указана разметка, то значения переменных, теги и тема экранируются в соответствии с ней,
+// иначе уведомление собирается в прежнем формате (тема выделяется разметкой Markdown).
 func (b *messengerBuilder) Build(vars map[string]string, messenger *templateentity.DataMessenger) ([]dto.Notice, error) {
 	if messenger.Content == "" {
 		return nil, errors.NewInternalError("messenger.Content is empty")
 	}
 
+	if !messengergate.IsKnownParseMode(messenger.ParseMode) {
+		return nil, errInternalMessengerParseModeUnknown.New("parseMode", messenger.ParseMode)
+	}
+
+	if messenger.ParseMode == "" {
+		return b.buildLegacy(vars, messenger)
+	}
+
 	var contentBilder strings.Builder
 
-	for i, tag := range messenger.Tags {
-		if !regexpMessengerTag.MatchString(tag) {
-			return nil, errInternalValueNotMatchRegexpPattern.New(
-				"value", tag,
-				"pattern", regexpMessengerTag.String(),
-			)
-		}
+	if err := b.writeTags(&contentBilder, messenger.ParseMode, messenger.Tags); err != nil {
+		return nil, err
+	}
 
-		if i > 0 {
-			contentBilder.WriteByte(' ')
+	if messenger.Subject != "" {
+		// тема является текстом без разметки, поэтому экранируется целиком после подстановки переменных
+		subject, err := b.noticeRenderer.Render(messenger.Subject, vars)
+		if err != nil {
+			return nil, errors.WrapInternalError(err, "subject rendering failed")
 		}
 
-		contentBilder.WriteString(tag)
+		contentBilder.WriteString(boldMessengerText(messenger.ParseMode, messengergate.Escape(messenger.ParseMode, subject)))
+		contentBilder.WriteByte('\n')
 	}
 
-	if len(messenger.Tags) > 0 {
-		contentBilder.WriteByte('\n')
+	content, err := b.noticeRenderer.Render(messenger.Content, escapeMessengerVars(messenger.ParseMode, vars))
+	if err != nil {
+		return nil, errors.WrapInternalError(err, "content rendering failed")
+	}
+
+	contentBilder.WriteString(content)
+
+	buttons, err := b.renderButtons(vars, messenger.Buttons)
+	if err != nil {
+		return nil, err
+	}
+
+	return []dto.Notice{
+		{
+			Channel: b.channel,
+			Data: dto.NoticeData{
+				Messenger: &dto.DataMessenger{
+					ChatID:             messenger.ChatID,
+					ThreadID:           messenger.ThreadID,
+					Content:            contentBilder.String(),
+					ParseMode:          messenger.ParseMode,
+					Buttons:            buttons,
+					DisableLinkPreview: messenger.DisableLinkPreview,
+					Silent:             messenger.Silent,
+				},
+			},
+		},
+	}, nil
+}
+
+// buildLegacy - собирает уведомление в прежнем формате: тема выделяется разметкой Markdown,
+// значения переменных подставляются как есть. Режим разметки не указывается, чтобы уведомление,
+// как и прежде, могло быть отправлено клиентом, передающим только текст (см. messengergate.IsPlain).
+func (b *messengerBuilder) buildLegacy(vars map[string]string, messenger *templateentity.DataMessenger) ([]dto.Notice, error) {
+	var contentBilder strings.Builder
+
+	if err := b.writeTags(&contentBilder, "", messenger.Tags); err != nil {
+		return nil, err
 	}
 
 	if messenger.Subject != "" {
@@ -79,15 +127,80 @@ func (b *messengerBuilder) Build(vars map[string]string, messenger *templateenti
 		return nil, errors.WrapInternalError(err, "content rendering failed")
 	}
 
+	buttons, err := b.renderButtons(vars, messenger.Buttons)
+	if err != nil {
+		return nil, err
+	}
+
 	return []dto.Notice{
 		{
 			Channel: b.channel,
 			Data: dto.NoticeData{
 				Messenger: &dto.DataMessenger{
-					ChatID:  messenger.ChatID,
-					Content: content,
+					ChatID:             messenger.ChatID,
+					ThreadID:           messenger.ThreadID,
+					Content:            content,
+					Buttons:            buttons,
+					DisableLinkPreview: messenger.DisableLinkPreview,
+					Silent:             messenger.Silent,
 				},
 			},
 		},
 	}, nil
 }
+
+// writeTags - записывает строку тегов (@name) в начало сообщения.
+func (b *messengerBuilder) writeTags(contentBilder *strings.Builder, parseMode string, tags []string) error {
+	for i, tag := range tags {
+		if !regexpMessengerTag.MatchString(tag) {
+			return errInternalValueNotMatchRegexpPattern.New(
+				"value", tag,
+				"pattern", regexpMessengerTag.String(),
+			)
+		}
+
+		if i > 0 {
+			contentBilder.WriteByte(' ')
+		}
+
+		contentBilder.WriteString(messengergate.Escape(parseMode, tag))
+	}
+
+	if len(tags) > 0 {
+		contentBilder.WriteByte('\n')
+	}
+
+	return nil
+}
+
+// renderButtons - подставляет значения переменных в текст и ссылки кнопок
+// (текст кнопок не содержит разметки, поэтому значения переменных в нём не экранируются,
+// а в ссылках значения переменных кодируются, см. escapeURLVars).
+func (b *messengerBuilder) renderButtons(vars map[string]string, rows [][]templateentity.MessengerButton) ([][]dto.MessengerButton, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	buttons := make([][]dto.MessengerButton, len(rows))
+	urlVars := escapeURLVars(vars)
+
+	for i, row := range rows {
+		buttons[i] = make([]dto.MessengerButton, len(row))
+
+		for j, button := range row {
+			text, err := b.noticeRenderer.Render(button.Text, vars)
+			if err != nil {
+				return nil, errors.WrapInternalError(err, "button text rendering failed")
+			}
+
+			url, err := b.noticeRenderer.Render(button.URL, urlVars)
+			if err != nil {
+				return nil, errors.WrapInternalError(err, "button url rendering failed")
+			}
+
+			buttons[i][j] = dto.MessengerButton{Text: text, URL: url}
+		}
+	}
+
+	return buttons, nil
+}
@@ -0,0 +1,138 @@
+package buildnotice_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrnotifier/notifier/buildnotice"
+	"github.com/mondegor/go-components/mrnotifier/notifier/dto"
+	templateentity "github.com/mondegor/go-components/mrnotifier/template/entity"
+)
+
+// varsRenderer - подставляет значения переменных вида {{name}}.
+type varsRenderer struct{}
+
+func (varsRenderer) Render(notice string, data map[string]string) (string, error) {
+	for name, value := range data {
+		notice = strings.ReplaceAll(notice, "{{"+name+"}}", value)
+	}
+
+	return notice, nil
+}
+
+func TestBuildManager_Messenger(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{"name": "John_Doe <admin>", "orderId": "1.5"}
+
+	tests := []struct {
+		name      string
+		parseMode string
+		expected  string
+	}{
+		{
+			name:     "legacy format",
+			expected: "@ops_team\n*Order\\_1.5*\nHello, John_Doe <admin>!",
+		},
+		{
+			name:      "plain",
+			parseMode: "plain",
+			expected:  "@ops_team\nOrder_1.5\nHello, John_Doe <admin>!",
+		},
+		{
+			name:      "markdown",
+			parseMode: "Markdown",
+			expected:  "@ops\\_team\n*Order\\_1.5*\nHello, John\\_Doe <admin>!",
+		},
+		{
+			name:      "markdown v2",
+			parseMode: "MarkdownV2",
+			expected:  "@ops\\_team\n*Order\\_1\\.5*\nHello, John\\_Doe <admin\\>!",
+		},
+		{
+			name:      "html",
+			parseMode: "HTML",
+			expected:  "@ops_team\n<b>Order_1.5</b>\nHello, John_Doe &lt;admin&gt;!",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			notices, err := buildnotice.NewBuildManager(varsRenderer{}).Build(
+				vars,
+				templateentity.TemplateData{
+					Messenger: &templateentity.DataMessenger{
+						ChatID:    "100500",
+						ThreadID:  "7",
+						Tags:      []string{"@ops_team"},
+						Subject:   "Order_{{orderId}}",
+						Content:   "Hello, {{name}}!",
+						ParseMode: tt.parseMode,
+						Buttons:   [][]templateentity.MessengerButton{{{Text: "Order {{orderId}}", URL: "https://localhost/orders/{{orderId}}"}}},
+						Silent:    true,
+					},
+				},
+			)
+			require.NoError(t, err)
+			require.Len(t, notices, 1)
+
+			assert.Equal(
+				t,
+				&dto.DataMessenger{
+					ChatID:    "100500",
+					ThreadID:  "7",
+					Content:   tt.expected,
+					ParseMode: tt.parseMode,
+					Buttons:   [][]dto.MessengerButton{{{Text: "Order 1.5", URL: "https://localhost/orders/1.5"}}},
+					Silent:    true,
+				},
+				notices[0].Data.Messenger,
+			)
+		})
+	}
+}
+
+func TestBuildManager_MessengerParseModeUnknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := buildnotice.NewBuildManager(varsRenderer{}).Build(
+		nil,
+		templateentity.TemplateData{
+			Messenger: &templateentity.DataMessenger{ChatID: "100500", Content: "text", ParseMode: "BBCode"},
+		},
+	)
+	require.Error(t, err)
+}
+
+func TestBuildManager_MessengerButtonURLEscaped(t *testing.T) {
+	t.Parallel()
+
+	notices, err := buildnotice.NewBuildManager(varsRenderer{}).Build(
+		map[string]string{"query": "a b&c=d/e?f"},
+		templateentity.TemplateData{
+			Messenger: &templateentity.DataMessenger{
+				ChatID:    "100500",
+				Content:   "Search: {{query}}",
+				ParseMode: "plain",
+				Buttons: [][]templateentity.MessengerButton{
+					{{Text: "Find {{query}}", URL: "https://localhost/search/{{query}}?q={{query}}"}},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, notices, 1)
+
+	assert.Equal(
+		t,
+		[][]dto.MessengerButton{
+			{{Text: "Find a b&c=d/e?f", URL: "https://localhost/search/a%20b%26c%3Dd%2Fe%3Ff?q=a%20b%26c%3Dd%2Fe%3Ff"}},
+		},
+		notices[0].Data.Messenger.Buttons,
+	)
+}
@@ -0,0 +1,45 @@
+package buildnotice
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/mondegor/go-components/mrmailer/sendmessage/messengergate"
+)
+
+// boldMessengerText - выделяет жирным уже экранированный текст с помощью указанной разметки.
+func boldMessengerText(parseMode, escaped string) string {
+	switch parseMode {
+	case messengergate.ParseModeMarkdown, messengergate.ParseModeMarkdownV2:
+		return "*" + escaped + "*"
+	case messengergate.ParseModeHTML:
+		return "<b>" + escaped + "</b>"
+	default:
+		return escaped
+	}
+}
+
+// escapeMessengerVars - возвращает копию переменных, значения которых экранированы для указанной разметки
+// (см. messengergate.Escape).
+func escapeMessengerVars(parseMode string, vars map[string]string) map[string]string {
+	escaped := make(map[string]string, len(vars))
+
+	for name, value := range vars {
+		escaped[name] = messengergate.Escape(parseMode, value)
+	}
+
+	return escaped
+}
+
+// escapeURLVars - возвращает копию переменных, значения которых закодированы для подстановки в ссылку
+// (в путь или параметры запроса), чтобы они не изменяли её структуру.
+func escapeURLVars(vars map[string]string) map[string]string {
+	escaped := make(map[string]string, len(vars))
+
+	for name, value := range vars {
+		// пробел кодируется как %20, т.к. + в пути ссылки означает сам символ
+		escaped[name] = strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+	}
+
+	return escaped
+}
@@ -27,7 +27,9 @@ type (
 	DataMail struct {
 		ContentType string
 		From        string // name | email | name <email>
-		To          string
+		To          string // список адресов через запятую (RFC 5322)
+		Cc          string // список адресов через запятую (RFC 5322)
+		Bcc         string // список адресов через запятую (RFC 5322)
 		ReplyTo     string
 		Subject     string
 		Content     string
@@ -35,9 +37,20 @@ type (
 
 	// DataMessenger - тип уведомления, которое отправляется в виде текста в Messenger сервис.
 	DataMessenger struct {
-		From    string
-		ChatID  string
-		Content string
+		From               string
+		ChatID             string
+		ThreadID           string
+		Content            string
+		ParseMode          string // plain, Markdown, MarkdownV2, HTML
+		Buttons            [][]MessengerButton
+		DisableLinkPreview bool
+		Silent             bool
+	}
+
+	// MessengerButton - кнопка со ссылкой под сообщением мессенджера.
+	MessengerButton struct {
+		Text string
+		URL  string
 	}
 
 	// DataSMS - тип уведомления, которое отправляется в виде короткого сообщения на телефон.
@@ -2,12 +2,21 @@ package handler
 
 import (
 	"context"
+	"time"
 
+	"github.com/mondegor/go-core/mrtrace"
 	tracectx "github.com/mondegor/go-core/mrtrace/context"
 
 	"github.com/mondegor/go-components/mrnotifier"
 	"github.com/mondegor/go-components/mrnotifier/notifier/dto"
 	"github.com/mondegor/go-components/mrnotifier/notifier/entity"
+	"github.com/mondegor/go-components/mrqueue/tracing"
+)
+
+const (
+	spanNameDequeue = "mrnotifier.dequeue"
+	spanNameBuild   = "mrnotifier.build"
+	spanNameSend    = "mrnotifier.send"
 )
 
 type (
@@ -16,6 +25,7 @@ type (
 	SendNotice struct {
 		noticeBuilder noticeBuilder
 		noticeSender  mrnotifier.NoticeSender
+		tracer        mrtrace.Tracer // OPTIONAL
 	}
 
 	// noticeBuilder - собирает уведомление в форматированный вид для отправки их получателю.
@@ -28,11 +38,20 @@ type (
 func NewSendNotice(
 	noticeBuilder noticeBuilder,
 	noticeSender mrnotifier.NoticeSender,
+	opts ...Option,
 ) *SendNotice {
-	return &SendNotice{
-		noticeBuilder: noticeBuilder,
-		noticeSender:  noticeSender,
+	o := options{
+		handler: &SendNotice{
+			noticeBuilder: noticeBuilder,
+			noticeSender:  noticeSender,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
 	}
+
+	return o.handler
 }
 
 // Execute - обрабатывает уведомления собирая их в готовые сообщения,
@@ -40,15 +59,39 @@ func NewSendNotice(
 func (h *SendNotice) Execute(ctx context.Context, message entity.Note) (commit func(ctx context.Context) error, err error) {
 	ctx = h.withCorrelationIDContext(ctx, message.Data)
 
+	// восстанавливается контекст трассировки, с которым уведомление было размещено в очереди
+	ctx = tracing.Extract(ctx, message.Data, mrnotifier.HeaderPrefix)
+	parentSpan, hasParentSpan := tracing.SpanContextFromContext(ctx)
+
+	if enqueuedAt, ok := tracing.EnqueuedAt(message.Data, mrnotifier.HeaderPrefix); ok {
+		tracing.Trace(tracing.WithChildSpan(ctx), h.tracer, spanNameDequeue, enqueuedAt, nil, "noteId", message.ID)
+	}
+
+	buildCtx := tracing.WithChildSpan(ctx)
+	buildStartedAt := time.Now()
+
 	// формируется заранее, чтобы транзакция при коммите выполнилась быстрее
-	notices, err := h.noticeBuilder.Execute(ctx, message)
+	notices, err := h.noticeBuilder.Execute(buildCtx, message)
+	tracing.Trace(buildCtx, h.tracer, spanNameBuild, buildStartedAt, err, "noteId", message.ID, "key", message.Key)
+
 	if err != nil {
 		return nil, err
 	}
 
-	return func(ctx context.Context) error {
+	return func(ctx context.Context) (err error) {
 		ctx = h.withCorrelationIDContext(ctx, message.Data)
 
+		if hasParentSpan {
+			ctx = tracing.WithSpanContext(ctx, parentSpan)
+		}
+
+		ctx = tracing.WithChildSpan(ctx)
+		startedAt := time.Now()
+
+		defer func() {
+			tracing.Trace(ctx, h.tracer, spanNameSend, startedAt, err, "noteId", message.ID, "count", len(notices))
+		}()
+
 		return h.noticeSender.Send(ctx, notices)
 	}, nil
 }
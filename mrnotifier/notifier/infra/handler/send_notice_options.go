@@ -0,0 +1,19 @@
+package handler
+
+import "github.com/mondegor/go-core/mrtrace"
+
+type (
+	// Option - настройка объекта SendNotice.
+	Option func(o *options)
+
+	options struct {
+		handler *SendNotice
+	}
+)
+
+// WithTracer - устанавливает трейсер, которому передаются спаны ожидания, сборки и отправки уведомлений.
+func WithTracer(value mrtrace.Tracer) Option {
+	return func(o *options) {
+		o.handler.tracer = value
+	}
+}
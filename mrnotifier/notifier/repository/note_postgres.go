@@ -2,18 +2,28 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/mondegor/go-core/errors"
 	"github.com/mondegor/go-core/mrstorage"
 	"github.com/mondegor/go-core/mrstorage/mrsql"
 
 	"github.com/mondegor/go-components/mrnotifier/notifier/entity"
+	"github.com/mondegor/go-components/mrqueue/sealdata"
 )
 
 type (
 	// NotePostgres - репозиторий для хранения уведомлений.
+	// Если установлен sealdata.Sealer, то данные уведомления хранятся в запечатанном виде.
 	NotePostgres struct {
 		client mrstorage.DBConnManager
 		table  mrsql.DBTableInfo
+		sealer *sealdata.Sealer
+	}
+
+	// sealedNoteData - запечатанные данные уведомления в том виде, в котором они хранятся в БД.
+	sealedNoteData struct {
+		Sealed *sealdata.Envelope `json:"_sealed"`
 	}
 )
 
@@ -21,11 +31,20 @@ type (
 func NewNotePostgres(
 	client mrstorage.DBConnManager,
 	table mrsql.DBTableInfo,
+	opts ...NoteOption,
 ) *NotePostgres {
-	return &NotePostgres{
-		client: client,
-		table:  table,
+	o := noteOptions{
+		repository: &NotePostgres{
+			client: client,
+			table:  table,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
 	}
+
+	return o.repository
 }
 
 // FetchByIDs - возвращает список уведомлений по их указанным ID.
@@ -40,35 +59,40 @@ func (re *NotePostgres) FetchByIDs(ctx context.Context, rowsIDs []uint64) ([]ent
 		WHERE
 			` + re.table.PrimaryKey + ` = ANY($1);`
 
-	cursor, err := re.client.Conn(ctx).Query(
-		ctx,
-		sql,
-		rowsIDs,
-	)
+	return re.fetch(ctx, sql, len(rowsIDs), rowsIDs)
+}
+
+// FetchForReseal - возвращает и блокирует до конца транзакции уведомления, данные которых
+// не запечатаны текущим ключом (в том числе хранимые в открытом виде).
+// Если sealdata.Sealer не установлен, то возвращается пустой список.
+func (re *NotePostgres) FetchForReseal(ctx context.Context, limit int) ([]entity.Note, error) {
+	if re.sealer == nil {
+		return nil, nil
+	}
+
+	currentKeyID, err := re.sealer.CurrentKeyID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	defer cursor.Close()
-
-	rows := make([]entity.Note, 0, len(rowsIDs))
-
-	for cursor.Next() {
-		var row entity.Note
-
-		err = cursor.Scan(
-			&row.ID,
-			&row.Key,
-			&row.Data,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		rows = append(rows, row)
-	}
+	// условие записано через сравнения, а не IS DISTINCT FROM, чтобы использовался индекс по ID ключа
+	sql := `
+		SELECT
+			` + re.table.PrimaryKey + `,
+			notice_key,
+			notice_data
+		FROM
+			` + re.table.Name + `
+		WHERE
+			notice_data->'` + sealdata.FieldName + `'->>'kid' IS NULL OR
+			notice_data->'` + sealdata.FieldName + `'->>'kid' < $1 OR
+			notice_data->'` + sealdata.FieldName + `'->>'kid' > $1
+		ORDER BY
+			` + re.table.PrimaryKey + ` ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED;`
 
-	return rows, cursor.Err()
+	return re.fetch(ctx, sql, limit, currentKeyID, limit)
 }
 
 // Insert - вставляет новое уведомление.
@@ -83,12 +107,54 @@ func (re *NotePostgres) Insert(ctx context.Context, row entity.Note) error {
 		VALUES
 			($1, $2, $3);`
 
+	data, err := re.storedData(ctx, row)
+	if err != nil {
+		return err
+	}
+
 	return re.client.Conn(ctx).Exec(
 		ctx,
 		sql,
 		row.ID,
 		row.Key,
-		row.Data,
+		data,
+	)
+}
+
+// UpdateSealed - запечатывает заново текущим ключом данные указанных уведомлений.
+func (re *NotePostgres) UpdateSealed(ctx context.Context, rows []entity.Note) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(rows))
+	datas := make([]json.RawMessage, 0, len(rows))
+
+	for _, row := range rows {
+		data, err := re.storedData(ctx, row)
+		if err != nil {
+			return err
+		}
+
+		ids = append(ids, row.ID)
+		datas = append(datas, data)
+	}
+
+	sql := `
+		UPDATE
+			` + re.table.Name + ` t
+		SET
+			notice_data = d.notice_data
+		FROM
+			UNNEST($1::int8[], $2::jsonb[]) as d(id, notice_data)
+		WHERE
+			t.` + re.table.PrimaryKey + ` = d.id;`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+		ids,
+		datas,
 	)
 }
 
@@ -111,3 +177,91 @@ func (re *NotePostgres) DeleteByIDs(ctx context.Context, rowsIDs []uint64) error
 
 	return nil
 }
+
+func (re *NotePostgres) fetch(ctx context.Context, sql string, capacity int, args ...any) ([]entity.Note, error) {
+	cursor, err := re.client.Conn(ctx).Query(
+		ctx,
+		sql,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close()
+
+	rows := make([]entity.Note, 0, capacity)
+
+	for cursor.Next() {
+		var (
+			row  entity.Note
+			data []byte
+		)
+
+		err = cursor.Scan(
+			&row.ID,
+			&row.Key,
+			&data,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if row.Data, err = re.openData(ctx, row.ID, data); err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, cursor.Err()
+}
+
+// storedData - возвращает JSON документ с данными уведомления в том виде, в котором он хранится в БД.
+func (re *NotePostgres) storedData(ctx context.Context, row entity.Note) (json.RawMessage, error) {
+	var value any = row.Data
+
+	if re.sealer != nil {
+		envelope, err := re.sealer.SealJSON(ctx, row.Data, sealdata.RowAAD(row.ID))
+		if err != nil {
+			return nil, err
+		}
+
+		value = sealedNoteData{Sealed: envelope}
+	}
+
+	document, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.WrapInternalError(err, "marshal notice data failed", "id", row.ID)
+	}
+
+	return document, nil
+}
+
+// openData - возвращает данные уведомления из хранимого в БД JSON документа.
+func (re *NotePostgres) openData(ctx context.Context, rowID uint64, document []byte) (map[string]string, error) {
+	envelope, err := sealdata.Unwrap(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]string
+
+	if envelope == nil {
+		if err = json.Unmarshal(document, &data); err != nil {
+			return nil, errors.WrapInternalError(err, "unmarshal notice data failed", "id", rowID)
+		}
+
+		return data, nil
+	}
+
+	if re.sealer == nil {
+		return nil, sealdata.ErrInternalSealerNotSpecified.New()
+	}
+
+	if err = re.sealer.OpenJSON(ctx, envelope, sealdata.RowAAD(rowID), &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/mondegor/go-components/mrqueue/sealdata"
+)
+
+type (
+	// NoteOption - настройка объекта NotePostgres.
+	NoteOption func(o *noteOptions)
+
+	noteOptions struct {
+		repository *NotePostgres
+	}
+)
+
+// WithNoteSealer - устанавливает опцию sealer (запечатывание данных уведомлений при хранении) для NotePostgres.
+func WithNoteSealer(value *sealdata.Sealer) NoteOption {
+	return func(o *noteOptions) {
+		o.repository.sealer = value
+	}
+}
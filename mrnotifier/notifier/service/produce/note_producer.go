@@ -2,6 +2,7 @@ package produce
 
 import (
 	"context"
+	"time"
 
 	"github.com/mondegor/go-core/errors"
 	"github.com/mondegor/go-core/mrstorage"
@@ -12,10 +13,13 @@ import (
 	"github.com/mondegor/go-components/mrnotifier/notifier/entity"
 	"github.com/mondegor/go-components/mrqueue"
 	mrqueuedto "github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/tracing"
 )
 
 const (
 	defaultRetryAttempts = 3
+
+	spanNameEnqueue = "mrnotifier.enqueue"
 )
 
 type (
@@ -27,6 +31,7 @@ type (
 		serviceQueue      mrqueue.Producer
 		errorWrapper      errors.Wrapper
 		traceManager      mrtrace.ContextManager
+		tracer            mrtrace.Tracer // OPTIONAL
 		retryAttempts     int16
 	}
 
@@ -71,11 +76,18 @@ func New(
 //   - fromName (mrnotifier.FieldFromName) - адрес отправителя;
 //   - to (mrnotifier.FieldTo) - адрес получателя;
 //   - replyTo (mrnotifier.FieldReplyTo) - адрес для ответа на уведомление;
-func (sv *NoteProducer) Send(ctx context.Context, key string, props map[string]any) error {
+func (sv *NoteProducer) Send(ctx context.Context, key string, props map[string]any) (err error) {
 	if key == "" {
 		return errors.ErrInternalIncorrectInputData.WithDetails("key is empty")
 	}
 
+	ctx = tracing.WithChildSpan(ctx)
+	startedAt := time.Now()
+
+	defer func() {
+		tracing.Trace(ctx, sv.tracer, spanNameEnqueue, startedAt, err, "key", key)
+	}()
+
 	data := sv.prepareData(ctx, props)
 
 	nextID, err := sv.sequenceGenerator.Next(ctx)
@@ -127,5 +139,8 @@ func (sv *NoteProducer) prepareData(ctx context.Context, props map[string]any) m
 	// 	data[mrnotifier.HeaderLang] = mrlang.Ctx(ctx).LangCode()
 	// }
 
+	tracing.Inject(ctx, data, mrnotifier.HeaderPrefix)
+	tracing.MarkEnqueued(data, mrnotifier.HeaderPrefix, time.Now())
+
 	return data
 }
@@ -1,5 +1,7 @@
 package produce
 
+import "github.com/mondegor/go-core/mrtrace"
+
 type (
 	// Option - настройка объекта NoteProducer.
 	Option func(o *options)
@@ -15,3 +17,10 @@ func WithRetryAttempts(value int16) Option {
 		o.producer.retryAttempts = value
 	}
 }
+
+// WithTracer - устанавливает трейсер, которому передаются спаны размещения уведомлений в очереди.
+func WithTracer(value mrtrace.Tracer) Option {
+	return func(o *options) {
+		o.producer.tracer = value
+	}
+}
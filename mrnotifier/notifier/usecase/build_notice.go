@@ -48,7 +48,6 @@ func New(
 	o := options{
 		builder: &BuildNotice{
 			serviceTemplate: serviceTemplate,
-			noticeBuilder:   buildnotice.NewBuildManager(templater.NewTemplater("{{", "}}")),
 			channelPrefix:   defaultChannelPrefix,
 			errorWrapper:    errors.NewServiceRecordNotFoundWrapper(),
 		},
@@ -58,6 +57,8 @@ func New(
 		opt(&o)
 	}
 
+	o.builder.noticeBuilder = buildnotice.NewBuildManager(templater.NewTemplater("{{", "}}"), o.buildManagerOpts...)
+
 	return o.builder
 }
 
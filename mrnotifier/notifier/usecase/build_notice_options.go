@@ -1,11 +1,14 @@
 package usecase
 
+import "github.com/mondegor/go-components/mrnotifier/notifier/buildnotice"
+
 type (
 	// Option - настройка объекта BuildNotice.
 	Option func(o *options)
 
 	options struct {
-		builder *BuildNotice
+		builder          *BuildNotice
+		buildManagerOpts []buildnotice.Option
 	}
 )
 
@@ -15,3 +18,10 @@ func WithChannelPrefix(value string) Option {
 		o.builder.channelPrefix = value
 	}
 }
+
+// WithBuildManagerOpts - устанавливает опции сборщика уведомлений buildnotice.BuildManager.
+func WithBuildManagerOpts(value ...buildnotice.Option) Option {
+	return func(o *options) {
+		o.buildManagerOpts = append(o.buildManagerOpts, value...)
+	}
+}
@@ -42,12 +42,26 @@ type (
 	}
 
 	// DataMessenger - тип уведомления, которое отправляется в виде текста в Messenger сервис.
+	// ParseMode - разметка Content: plain, Markdown, MarkdownV2, HTML, значения переменных при этом
+	// экранируются в соответствии с разметкой; если не указана, то тема выделяется разметкой Markdown,
+	// а значения переменных подставляются как есть.
 	DataMessenger struct {
-		ChatID     string   `json:"chat_id"`
-		Tags       []string `json:"tags,omitempty"`
-		Subject    string   `json:"subject,omitempty"`
-		Content    string   `json:"content"`
-		IsDisabled bool     `json:"is_disabled,omitempty"`
+		ChatID             string              `json:"chat_id"`
+		ThreadID           string              `json:"thread_id,omitempty"` // ID темы (топика) чата
+		Tags               []string            `json:"tags,omitempty"`
+		Subject            string              `json:"subject,omitempty"`
+		Content            string              `json:"content"`
+		ParseMode          string              `json:"parse_mode,omitempty"`
+		Buttons            [][]MessengerButton `json:"buttons,omitempty"` // ряды кнопок со ссылками под сообщением
+		DisableLinkPreview bool                `json:"disable_link_preview,omitempty"`
+		Silent             bool                `json:"silent,omitempty"` // доставка без звукового оповещения
+		IsDisabled         bool                `json:"is_disabled,omitempty"`
+	}
+
+	// MessengerButton - кнопка со ссылкой под сообщением мессенджера (в Text и URL можно использовать переменные).
+	MessengerButton struct {
+		Text string `json:"text"`
+		URL  string `json:"url"`
 	}
 
 	// DataSMS - тип уведомления, которое отправляется в виде короткого сообщения на телефон.
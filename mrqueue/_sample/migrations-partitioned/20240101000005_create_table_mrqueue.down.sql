@@ -0,0 +1,11 @@
+-- --------------------------------------------------------------------------------------------------
+
+DROP TABLE sample_schema.mrqueue_completed;
+DROP TABLE sample_schema.mrqueue_errors;
+DROP TABLE sample_schema.mrqueue;
+
+DROP SEQUENCE sample_schema.mrqueue_item_id_seq;
+
+DROP SCHEMA sample_schema;
+
+
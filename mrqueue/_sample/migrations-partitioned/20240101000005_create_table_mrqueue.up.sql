@@ -0,0 +1,55 @@
+-- --------------------------------------------------------------------------------------------------
+
+-- Вариант таблиц очереди, в котором таблицы успешно обработанных элементов и журнала ошибок
+-- секционированы по диапазону времени. Секции создаются и удаляются задачей
+-- Task/ManagePartitions (см. опцию WithPartitionedQueueTables планировщика модуля).
+
+CREATE SCHEMA sample_schema AUTHORIZATION user_pg;
+
+-- --------------------------------------------------------------------------------------------------
+
+-- sequence name = table_name + "_" + primary_key_name + "_seq"
+CREATE SEQUENCE sample_schema.mrqueue_item_id_seq START 1;
+
+-- --------------------------------------------------------------------------------------------------
+
+-- for select, insert, update, delete
+CREATE TABLE sample_schema.mrqueue (
+    item_id int8 NOT NULL CONSTRAINT pk_mrqueue PRIMARY KEY,
+    remaining_attempts int2 NOT NULL CHECK(remaining_attempts >= 0), -- кол-во оставшихся попыток отправки сообщения
+    item_status int2 NOT NULL, -- 1=READY, 2=PROCESSING, 3=RETRY
+    updated_at timestamp with time zone NOT NULL DEFAULT NOW() -- item with status = READY and updated_at > NOW() = delayed
+);
+
+CREATE INDEX ix_mrqueue_item_status ON sample_schema.mrqueue  (item_status, updated_at);
+
+-- --------------------------------------------------------------------------------------------------
+
+-- OPTIONAL
+-- for select, insert, drop partition (in background)
+CREATE TABLE sample_schema.mrqueue_errors (
+    item_id int8 NOT NULL,
+    error_message text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT NOW()
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX ix_mrqueue_errors_item_id ON sample_schema.mrqueue_errors (item_id);
+
+-- секция для записей, не попавших в диапазоны созданных секций (иначе их вставка завершается ошибкой),
+-- записи этой секции не удаляются задачей Task/ManagePartitions
+CREATE TABLE sample_schema.mrqueue_errors_default PARTITION OF sample_schema.mrqueue_errors DEFAULT;
+
+-- --------------------------------------------------------------------------------------------------
+
+-- OPTIONAL
+-- for select, insert, drop partition (in background)
+-- ключ секционирования обязан входить в первичный ключ
+CREATE TABLE sample_schema.mrqueue_completed (
+    item_id int8 NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT pk_mrqueue_completed PRIMARY KEY (item_id, updated_at)
+) PARTITION BY RANGE (updated_at);
+
+-- секция для записей, не попавших в диапазоны созданных секций (иначе их вставка завершается ошибкой),
+-- записи этой секции не удаляются задачей Task/ManagePartitions
+CREATE TABLE sample_schema.mrqueue_completed_default PARTITION OF sample_schema.mrqueue_completed DEFAULT;
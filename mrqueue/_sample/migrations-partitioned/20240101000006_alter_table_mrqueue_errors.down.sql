@@ -0,0 +1,10 @@
+-- --------------------------------------------------------------------------------------------------
+
+DROP INDEX sample_schema.ix_mrqueue_errors_item_id;
+CREATE INDEX ix_mrqueue_errors_item_id ON sample_schema.mrqueue_errors (item_id);
+
+ALTER TABLE sample_schema.mrqueue_errors
+    DROP COLUMN attempt_number,
+    DROP COLUMN error_kind,
+    DROP COLUMN error_code,
+    DROP COLUMN worker_instance;
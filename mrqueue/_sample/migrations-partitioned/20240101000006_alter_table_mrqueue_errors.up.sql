@@ -0,0 +1,15 @@
+-- --------------------------------------------------------------------------------------------------
+
+-- существующим записям журнала назначается первая попытка и вид ошибки SYSTEM
+ALTER TABLE sample_schema.mrqueue_errors
+    ADD COLUMN attempt_number int2 NOT NULL DEFAULT 1 CHECK(attempt_number > 0), -- порядковый номер неудачной попытки обработки элемента
+    ADD COLUMN error_kind int2 NOT NULL DEFAULT 1, -- 1=SYSTEM, 2=USER, 3=TIMEOUT
+    ADD COLUMN error_code character varying(64) NOT NULL DEFAULT '',
+    ADD COLUMN worker_instance character varying(128) NOT NULL DEFAULT ''; -- экземпляр обработчика, на котором возникла ошибка
+
+ALTER TABLE sample_schema.mrqueue_errors
+    ALTER COLUMN attempt_number DROP DEFAULT,
+    ALTER COLUMN error_kind DROP DEFAULT;
+
+DROP INDEX sample_schema.ix_mrqueue_errors_item_id;
+CREATE INDEX ix_mrqueue_errors_item_id ON sample_schema.mrqueue_errors (item_id, attempt_number);
@@ -0,0 +1,4 @@
+-- --------------------------------------------------------------------------------------------------
+
+ALTER TABLE sample_schema.mrqueue
+    DROP COLUMN failed_attempts;
@@ -0,0 +1,17 @@
+-- --------------------------------------------------------------------------------------------------
+
+ALTER TABLE sample_schema.mrqueue
+    ADD COLUMN failed_attempts int2 NOT NULL DEFAULT 0 CHECK(failed_attempts >= 0); -- кол-во неудачных попыток обработки элемента
+
+-- счётчик элементов, уже находящихся в очереди, продолжает нумерацию их журнала ошибок
+UPDATE sample_schema.mrqueue t1
+SET
+    failed_attempts = t2.attempt_number
+FROM
+    (
+        SELECT item_id, MAX(attempt_number) as attempt_number
+        FROM sample_schema.mrqueue_errors
+        GROUP BY item_id
+    ) t2
+WHERE
+    t1.item_id = t2.item_id;
@@ -0,0 +1,9 @@
+package dto
+
+type (
+	// ItemAttempt - элемент очереди с порядковым номером его неудачной попытки обработки.
+	ItemAttempt struct {
+		ID      uint64
+		Attempt uint16
+	}
+)
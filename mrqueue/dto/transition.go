@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+
+type (
+	// Transition - событие перехода элемента очереди из одного статуса в другой.
+	Transition struct {
+		ItemID uint64
+		From   itemstatus.Enum
+		To     itemstatus.Enum
+		Cause  string // причина перехода (текст ошибки или описание события), может быть пустой
+	}
+)
@@ -1,9 +1,20 @@
 package entity
 
+import (
+	"time"
+
+	"github.com/mondegor/go-components/mrqueue/enum/errorkind"
+)
+
 type (
 	// CrashedItem - сломанный элемент очереди с причиной ошибки.
 	CrashedItem struct {
-		ID    uint64
-		Cause string
+		ID             uint64
+		Attempt        uint16 // порядковый номер неудачной попытки (берётся из счётчика элемента очереди)
+		ErrorKind      errorkind.Enum
+		ErrorCode      string
+		WorkerInstance string
+		Cause          string
+		CreatedAt      time.Time
 	}
 )
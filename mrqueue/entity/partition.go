@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type (
+	// Partition - секция таблицы, секционированной по диапазону времени [From, To).
+	Partition struct {
+		Name string
+		From time.Time
+		To   time.Time
+	}
+)
@@ -0,0 +1,111 @@
+package errorkind
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Виды ошибок обработки элементов очереди.
+const (
+	System  Enum = iota + 1 // системная ошибка (элемент будет обработан повторно)
+	User                    // пользовательская ошибка (элемент удаляется из очереди)
+	Timeout                 // обработка элемента не завершилась за отведённое время
+)
+
+const (
+	enumLast = uint8(Timeout)
+	enumName = "ErrorKind"
+)
+
+type (
+	// Enum - вид ошибки обработки элемента очереди.
+	Enum uint8
+)
+
+//nolint:gochecknoglobals
+var (
+	enumKeys = map[Enum]string{
+		System:  "SYSTEM",
+		User:    "USER",
+		Timeout: "TIMEOUT",
+	}
+
+	enumValues = map[string]Enum{
+		"SYSTEM":  System,
+		"USER":    User,
+		"TIMEOUT": Timeout,
+	}
+)
+
+// Set - устанавливает указанное значение, если оно является enum значением.
+func (e *Enum) Set(value uint8) error {
+	if value > 0 && value <= enumLast {
+		*e = Enum(value)
+
+		return nil
+	}
+
+	return fmt.Errorf("value '%d' is not found in enum set '%s'", value, enumName)
+}
+
+// String - возвращает значение в виде строки.
+func (e Enum) String() string {
+	if v, ok := enumKeys[e]; ok {
+		return v
+	}
+
+	return "UNKNOWN"
+}
+
+// MarshalJSON - переводит enum значение в строковое представление.
+func (e Enum) MarshalJSON() ([]byte, error) {
+	bytes, err := json.Marshal(e.String())
+	if err != nil {
+		return nil, fmt.Errorf("marshal error (source='%s'): %w", enumName, err)
+	}
+
+	return bytes, nil
+}
+
+// UnmarshalJSON - переводит строковое значение в enum представление.
+func (e *Enum) UnmarshalJSON(data []byte) error {
+	var value string
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("unmarshal error (source='%s'): %w", enumName, err)
+	}
+
+	val, err := Parse(value)
+	if err != nil {
+		return err
+	}
+
+	*e = val
+
+	return nil
+}
+
+// Scan implements the Scanner interface.
+func (e *Enum) Scan(value any) error {
+	if val, ok := value.(int64); ok && val >= 0 && val <= math.MaxUint8 {
+		return e.Set(uint8(val))
+	}
+
+	return fmt.Errorf("invalid type assertion (type='%s', value='%+v')", enumName, value)
+}
+
+// Value implements the driver.Valuer interface.
+func (e Enum) Value() (driver.Value, error) {
+	return uint8(e), nil
+}
+
+// Parse - парсит указанное значение и если оно валидно, то устанавливает его числовое значение.
+func Parse(value string) (Enum, error) {
+	if parsedValue, ok := enumValues[value]; ok {
+		return parsedValue, nil
+	}
+
+	return 0, fmt.Errorf("key is not found in source (source='%s', key='%s')", enumName, value)
+}
@@ -12,11 +12,19 @@ const (
 	Ready      Enum = iota + 1 // элемент очереди готов для обработки
 	Processing                 // элемент очереди находится в обработке
 	Retry                      // элемент очереди завершился с ошибкой и ожидает повторной обработки
+
+	// Следующие статусы в таблице очереди не хранятся и используются
+	// только для описания переходов элементов (dto.Transition).
+
+	Completed // элемент успешно обработан и перенесён в список выполненных
+	Crashed   // элемент завершился ошибкой без возможности повторной обработки (или записан в журнал ошибок)
+	Removed   // элемент (или его история) окончательно удалён
 )
 
 const (
-	enumLast = uint8(Retry)
-	enumName = "ItemStatus"
+	enumLast       = uint8(Retry)
+	transitionLast = uint8(Removed)
+	enumName       = "ItemStatus"
 )
 
 type (
@@ -30,6 +38,9 @@ var (
 		Ready:      "READY",
 		Processing: "PROCESSING",
 		Retry:      "RETRY",
+		Completed:  "COMPLETED",
+		Crashed:    "CRASHED",
+		Removed:    "REMOVED",
 	}
 
 	enumValues = map[string]Enum{
@@ -50,6 +61,12 @@ func (e *Enum) Set(value uint8) error {
 	return fmt.Errorf("value '%d' is not found in enum set '%s'", value, enumName)
 }
 
+// IsTransitionTarget - сообщает, может ли значение быть указано в событии перехода элемента (dto.Transition),
+// в том числе статусы, которые в таблице очереди не хранятся (Completed, Crashed, Removed).
+func (e Enum) IsTransitionTarget() bool {
+	return e > 0 && uint8(e) <= transitionLast
+}
+
 // String - возвращает значение в виде строки.
 func (e Enum) String() string {
 	if v, ok := enumKeys[e]; ok {
@@ -0,0 +1,54 @@
+package itemstatus_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+)
+
+func TestEnum_StoredStatuses(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []itemstatus.Enum{itemstatus.Ready, itemstatus.Processing, itemstatus.Retry} {
+		var value itemstatus.Enum
+
+		require.NoError(t, value.Set(uint8(status)))
+		assert.Equal(t, status, value)
+
+		require.NoError(t, value.Scan(int64(status)))
+		assert.Equal(t, status, value)
+
+		parsed, err := itemstatus.Parse(status.String())
+		require.NoError(t, err)
+		assert.Equal(t, status, parsed)
+		assert.True(t, status.IsTransitionTarget())
+	}
+}
+
+func TestEnum_TransitionOnlyStatuses(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []itemstatus.Enum{itemstatus.Completed, itemstatus.Crashed, itemstatus.Removed} {
+		var value itemstatus.Enum
+
+		assert.Error(t, value.Set(uint8(status)), status.String())
+		assert.Error(t, value.Scan(int64(status)), status.String())
+
+		_, err := itemstatus.Parse(status.String())
+		assert.Error(t, err, status.String())
+
+		assert.True(t, status.IsTransitionTarget(), status.String())
+		assert.NotEqual(t, "UNKNOWN", status.String())
+
+		data, err := json.Marshal(status)
+		require.NoError(t, err)
+		assert.JSONEq(t, `"`+status.String()+`"`, string(data))
+	}
+
+	assert.False(t, itemstatus.Enum(0).IsTransitionTarget())
+	assert.False(t, (itemstatus.Removed + 1).IsTransitionTarget())
+}
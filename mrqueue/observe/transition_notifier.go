@@ -0,0 +1,199 @@
+package observe
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrqueue"
+	"github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+)
+
+const (
+	defaultQueueSize    = 1000
+	defaultWorkersCount = 1
+)
+
+type (
+	// Notifier - оповещает наблюдателей о переходах элементов очереди между статусами
+	// (используется компонентами очереди, реализуется TransitionNotifier).
+	Notifier interface {
+		NotifyInTx(ctx context.Context, transitions []dto.Transition) error
+		NotifyAfterCommit(ctx context.Context, transitions []dto.Transition)
+	}
+
+	// TransitionNotifier - оповещает наблюдателей о переходах элементов очереди между статусами.
+	// Наблюдатели бывают двух видов:
+	//   - вызываемые внутри транзакции перехода (их ошибка отменяет весь переход);
+	//   - вызываемые асинхронно после успешной фиксации транзакции (их ошибки передаются в errorFunc).
+	// Асинхронные оповещения помещаются в ограниченную очередь queueSize, которую разбирают
+	// workersCount обработчиков. Перед завершением работы приложения необходимо вызвать Shutdown,
+	// чтобы оповестить наблюдателей о всех уже зафиксированных переходах.
+	TransitionNotifier struct {
+		inTx         []mrqueue.TransitionObserver
+		afterCommit  []mrqueue.TransitionObserver
+		errorFunc    func(ctx context.Context, err error)
+		queueSize    int
+		workersCount int
+
+		mu     sync.RWMutex
+		queue  chan afterCommitJob
+		closed bool
+		wg     sync.WaitGroup
+	}
+
+	afterCommitJob struct {
+		ctx         context.Context //nolint:containedctx
+		transitions []dto.Transition
+	}
+)
+
+var (
+	errInternalTransitionTargetInvalid = errors.NewInternalProto("transition target status is invalid")
+	errSystemNotificationSkipped       = errors.NewSystemProto("after commit notification of transitions is skipped")
+)
+
+// New - создаёт объект TransitionNotifier.
+// Если указаны наблюдатели, вызываемые после фиксации транзакции, то запускаются их обработчики.
+func New(opts ...Option) *TransitionNotifier {
+	o := options{
+		notifier: &TransitionNotifier{
+			queueSize:    defaultQueueSize,
+			workersCount: defaultWorkersCount,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	n := o.notifier
+
+	if n.errorFunc == nil {
+		n.errorFunc = func(_ context.Context, _ error) {}
+	}
+
+	if n.queueSize < 1 {
+		n.queueSize = defaultQueueSize
+	}
+
+	if n.workersCount < 1 {
+		n.workersCount = defaultWorkersCount
+	}
+
+	if len(n.afterCommit) > 0 {
+		n.queue = make(chan afterCommitJob, n.queueSize)
+
+		for i := 0; i < n.workersCount; i++ {
+			n.wg.Add(1)
+
+			go n.worker()
+		}
+	}
+
+	return n
+}
+
+// NotifyInTx - оповещает наблюдателей, работающих внутри транзакции перехода.
+// Должен вызываться внутри этой транзакции.
+// Если в одном из переходов указан статус, не являющийся статусом перехода, то возвращается ошибка.
+func (n *TransitionNotifier) NotifyInTx(ctx context.Context, transitions []dto.Transition) error {
+	for _, transition := range transitions {
+		if !transition.From.IsTransitionTarget() || !transition.To.IsTransitionTarget() {
+			return errInternalTransitionTargetInvalid.New("itemId", transition.ItemID, "from", transition.From, "to", transition.To)
+		}
+	}
+
+	for _, observer := range n.inTx {
+		if err := observer.OnTransition(ctx, transitions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NotifyAfterCommit - ставит в очередь асинхронное оповещение наблюдателей, ожидающих фиксации транзакции перехода.
+// Должен вызываться после успешного завершения этой транзакции.
+// Если очередь заполнена, то вызов ожидает освобождения места в ней или отмены ctx,
+// а если оповещатель уже остановлен, то переходы передаются в errorFunc в виде ошибки.
+func (n *TransitionNotifier) NotifyAfterCommit(ctx context.Context, transitions []dto.Transition) {
+	if len(n.afterCommit) == 0 || len(transitions) == 0 {
+		return
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if n.closed {
+		n.errorFunc(ctx, errSystemNotificationSkipped.New("cause", "notifier is closed", "count", len(transitions)))
+
+		return
+	}
+
+	select {
+	case n.queue <- afterCommitJob{ctx: context.WithoutCancel(ctx), transitions: transitions}:
+	case <-ctx.Done():
+		n.errorFunc(ctx, errSystemNotificationSkipped.Wrap(ctx.Err(), "count", len(transitions)))
+	}
+}
+
+// Shutdown - прекращает приём новых оповещений и ожидает, пока обработчики оповестят наблюдателей
+// о переходах, уже находящихся в очереди. Если ctx завершится раньше, то возвращается его ошибка.
+func (n *TransitionNotifier) Shutdown(ctx context.Context) error {
+	n.mu.Lock()
+
+	if !n.closed {
+		n.closed = true
+
+		if n.queue != nil {
+			close(n.queue)
+		}
+	}
+
+	n.mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *TransitionNotifier) worker() {
+	defer n.wg.Done()
+
+	for job := range n.queue {
+		for _, observer := range n.afterCommit {
+			if err := observer.OnTransition(job.ctx, job.transitions); err != nil {
+				n.errorFunc(job.ctx, err)
+			}
+		}
+	}
+}
+
+// Transitions - формирует одинаковые события перехода для указанного списка элементов.
+func Transitions(itemsIDs []uint64, from, to itemstatus.Enum, cause string) []dto.Transition {
+	transitions := make([]dto.Transition, len(itemsIDs))
+
+	for i, itemID := range itemsIDs {
+		transitions[i] = dto.Transition{
+			ItemID: itemID,
+			From:   from,
+			To:     to,
+			Cause:  cause,
+		}
+	}
+
+	return transitions
+}
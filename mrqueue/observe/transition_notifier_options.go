@@ -0,0 +1,51 @@
+package observe
+
+import (
+	"context"
+
+	"github.com/mondegor/go-components/mrqueue"
+)
+
+type (
+	// Option - настройка объекта TransitionNotifier.
+	Option func(o *options)
+
+	options struct {
+		notifier *TransitionNotifier
+	}
+)
+
+// WithInTxObservers - добавляет наблюдателей, вызываемых внутри транзакции перехода.
+func WithInTxObservers(value ...mrqueue.TransitionObserver) Option {
+	return func(o *options) {
+		o.notifier.inTx = append(o.notifier.inTx, value...)
+	}
+}
+
+// WithAfterCommitObservers - добавляет наблюдателей, вызываемых асинхронно после фиксации транзакции перехода.
+func WithAfterCommitObservers(value ...mrqueue.TransitionObserver) Option {
+	return func(o *options) {
+		o.notifier.afterCommit = append(o.notifier.afterCommit, value...)
+	}
+}
+
+// WithErrorFunc - устанавливает функцию обработки ошибок наблюдателей, вызываемых после фиксации транзакции.
+func WithErrorFunc(value func(ctx context.Context, err error)) Option {
+	return func(o *options) {
+		o.notifier.errorFunc = value
+	}
+}
+
+// WithQueueSize - устанавливает опцию queueSize (размер очереди асинхронных оповещений) для TransitionNotifier.
+func WithQueueSize(value int) Option {
+	return func(o *options) {
+		o.notifier.queueSize = value
+	}
+}
+
+// WithWorkersCount - устанавливает опцию workersCount (кол-во обработчиков асинхронных оповещений) для TransitionNotifier.
+func WithWorkersCount(value int) Option {
+	return func(o *options) {
+		o.notifier.workersCount = value
+	}
+}
@@ -0,0 +1,148 @@
+package observe_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrqueue"
+	"github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
+)
+
+type recorder struct {
+	mu          sync.Mutex
+	transitions []dto.Transition
+	errs        []error
+}
+
+func (r *recorder) observer(err error) mrqueue.TransitionObserver {
+	return mrqueue.TransitionObserverFunc(func(_ context.Context, transitions []dto.Transition) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.transitions = append(r.transitions, transitions...)
+
+		return err
+	})
+}
+
+func (r *recorder) errorFunc(_ context.Context, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errs = append(r.errs, err)
+}
+
+func TestTransitionNotifier_NotifyInTx(t *testing.T) {
+	t.Parallel()
+
+	observerErr := errors.New("observer failed")
+	first := &recorder{}
+	second := &recorder{}
+
+	notifier := observe.New(observe.WithInTxObservers(first.observer(observerErr), second.observer(nil)))
+	transitions := observe.Transitions([]uint64{1, 2}, itemstatus.Processing, itemstatus.Completed, "")
+
+	err := notifier.NotifyInTx(context.Background(), transitions)
+	require.ErrorIs(t, err, observerErr)
+	assert.Equal(t, transitions, first.transitions)
+	assert.Empty(t, second.transitions, "observers after the failed one are not called")
+}
+
+func TestTransitionNotifier_NotifyInTx_InvalidTarget(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{}
+	notifier := observe.New(observe.WithInTxObservers(rec.observer(nil)))
+
+	tests := []dto.Transition{
+		{ItemID: 1, From: itemstatus.Processing, To: 0},
+		{ItemID: 1, From: itemstatus.Removed + 1, To: itemstatus.Removed},
+	}
+
+	for _, transition := range tests {
+		require.Error(t, notifier.NotifyInTx(context.Background(), []dto.Transition{transition}))
+	}
+
+	assert.Empty(t, rec.transitions)
+}
+
+func TestTransitionNotifier_NotifyAfterCommit_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	observerErr := errors.New("observer failed")
+	rec := &recorder{}
+
+	notifier := observe.New(
+		observe.WithAfterCommitObservers(rec.observer(observerErr)),
+		observe.WithErrorFunc(rec.errorFunc),
+		observe.WithQueueSize(2),
+		observe.WithWorkersCount(2),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	for i := uint64(1); i <= 10; i++ {
+		notifier.NotifyAfterCommit(ctx, observe.Transitions([]uint64{i}, itemstatus.Retry, itemstatus.Ready, ""))
+	}
+
+	// отмена контекста вызывающего не отменяет уже поставленные в очередь оповещения
+	cancel()
+
+	require.NoError(t, notifier.Shutdown(context.Background()))
+
+	rec.mu.Lock()
+	assert.Len(t, rec.transitions, 10)
+	assert.Len(t, rec.errs, 10)
+	rec.mu.Unlock()
+
+	// после остановки оповещения не выполняются, а переходы передаются в errorFunc
+	notifier.NotifyAfterCommit(context.Background(), observe.Transitions([]uint64{11}, itemstatus.Retry, itemstatus.Ready, ""))
+
+	rec.mu.Lock()
+	assert.Len(t, rec.transitions, 10)
+	assert.Len(t, rec.errs, 11)
+	rec.mu.Unlock()
+
+	require.NoError(t, notifier.Shutdown(context.Background()), "repeated shutdown is allowed")
+}
+
+func TestTransitionNotifier_Shutdown_Timeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	blocking := mrqueue.TransitionObserverFunc(func(_ context.Context, _ []dto.Transition) error {
+		<-release
+
+		return nil
+	})
+
+	notifier := observe.New(observe.WithAfterCommitObservers(blocking))
+	notifier.NotifyAfterCommit(context.Background(), observe.Transitions([]uint64{1}, itemstatus.Retry, itemstatus.Ready, ""))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, notifier.Shutdown(ctx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, notifier.Shutdown(context.Background()))
+}
+
+func TestTransitionNotifier_WithoutObservers(t *testing.T) {
+	t.Parallel()
+
+	notifier := observe.New()
+	transitions := observe.Transitions([]uint64{1}, itemstatus.Completed, itemstatus.Removed, "expired")
+
+	require.NoError(t, notifier.NotifyInTx(context.Background(), transitions))
+	notifier.NotifyAfterCommit(context.Background(), transitions)
+	require.NoError(t, notifier.Shutdown(context.Background()))
+}
@@ -19,4 +19,18 @@ type (
 		Commit(ctx context.Context, itemID uint64) error
 		Reject(ctx context.Context, itemID uint64, causeErr error) error
 	}
+
+	// TransitionObserver - наблюдатель за переходами элементов очереди между статусами.
+	// Если наблюдатель вызывается внутри транзакции, то возвращённая им ошибка отменяет переход.
+	TransitionObserver interface {
+		OnTransition(ctx context.Context, transitions []dto.Transition) error
+	}
+
+	// TransitionObserverFunc - функция-адаптер для использования обычной функции в качестве TransitionObserver.
+	TransitionObserverFunc func(ctx context.Context, transitions []dto.Transition) error
 )
+
+// OnTransition - вызывает f(ctx, transitions).
+func (f TransitionObserverFunc) OnTransition(ctx context.Context, transitions []dto.Transition) error {
+	return f(ctx, transitions)
+}
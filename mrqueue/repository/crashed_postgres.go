@@ -8,6 +8,7 @@ import (
 	"github.com/mondegor/go-core/mrstorage/mrsql"
 
 	"github.com/mondegor/go-components/mrqueue/entity"
+	"github.com/mondegor/go-components/mrqueue/enum/errorkind"
 )
 
 type (
@@ -27,16 +28,25 @@ func NewCrashedPostgres(client mrstorage.DBConnManager, table mrsql.DBTableInfo)
 }
 
 // Insert - добавляет указанный список записей в журнал ошибок.
+// Номер попытки каждой записи берётся из счётчика неудачных попыток элемента очереди.
 func (re *CrashedPostgres) Insert(ctx context.Context, rows []entity.CrashedItem) error {
 	if len(rows) == 0 {
 		return nil
 	}
 
 	ids := make([]uint64, 0, len(rows))
+	attempts := make([]int16, 0, len(rows))
+	errorKinds := make([]errorkind.Enum, 0, len(rows))
+	errorCodes := make([]string, 0, len(rows))
+	workerInstances := make([]string, 0, len(rows))
 	causes := make([]string, 0, len(rows))
 
 	for _, row := range rows {
 		ids = append(ids, row.ID)
+		attempts = append(attempts, int16(row.Attempt)) //nolint:gosec
+		errorKinds = append(errorKinds, row.ErrorKind)
+		errorCodes = append(errorCodes, row.ErrorCode)
+		workerInstances = append(workerInstances, row.WorkerInstance)
 		causes = append(causes, row.Cause)
 	}
 
@@ -44,17 +54,25 @@ func (re *CrashedPostgres) Insert(ctx context.Context, rows []entity.CrashedItem
 		INSERT INTO ` + re.table.Name + `
 			(
 				` + re.table.PrimaryKey + `,
+				attempt_number,
+				error_kind,
+				error_code,
+				worker_instance,
 				error_message
 			)
-		SELECT *
+		SELECT id, attempt_number, error_kind, error_code, worker_instance, error_message
 		FROM
-			UNNEST($1::int8[], $2::text[])
-			as t(id, error_message);`
+			UNNEST($1::int8[], $2::int2[], $3::int2[], $4::text[], $5::text[], $6::text[])
+			as t(id, attempt_number, error_kind, error_code, worker_instance, error_message);`
 
 	return re.client.Conn(ctx).Exec(
 		ctx,
 		sql,
 		ids,
+		attempts,
+		errorKinds,
+		errorCodes,
+		workerInstances,
 		causes,
 	)
 }
@@ -64,6 +82,59 @@ func (re *CrashedPostgres) InsertOne(ctx context.Context, row entity.CrashedItem
 	return re.Insert(ctx, []entity.CrashedItem{row})
 }
 
+// FetchByItemID - возвращает историю неудачных попыток обработки указанного элемента в порядке их возникновения.
+func (re *CrashedPostgres) FetchByItemID(ctx context.Context, itemID uint64) ([]entity.CrashedItem, error) {
+	sql := `
+		SELECT
+			` + re.table.PrimaryKey + `,
+			attempt_number,
+			error_kind,
+			error_code,
+			worker_instance,
+			error_message,
+			created_at
+		FROM
+			` + re.table.Name + `
+		WHERE
+			` + re.table.PrimaryKey + ` = $1
+		ORDER BY
+			attempt_number ASC, created_at ASC;`
+
+	cursor, err := re.client.Conn(ctx).Query(
+		ctx,
+		sql,
+		itemID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close()
+
+	rows := make([]entity.CrashedItem, 0)
+
+	for cursor.Next() {
+		var row entity.CrashedItem
+
+		err = cursor.Scan(
+			&row.ID,
+			&row.Attempt,
+			&row.ErrorKind,
+			&row.ErrorCode,
+			&row.WorkerInstance,
+			&row.Cause,
+			&row.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, cursor.Err()
+}
+
 // Delete - удаляет ограниченный список записей из журнала ошибок.
 // Возвращает ID записей, которые были удалены.
 func (re *CrashedPostgres) Delete(ctx context.Context, expiry time.Duration, limit int) (rowsIDs []uint64, err error) {
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mondegor/go-core/mrstorage"
+	"github.com/mondegor/go-core/mrstorage/mrsql"
+
+	"github.com/mondegor/go-components/mrqueue/entity"
+)
+
+type (
+	// PartitionPostgres - репозиторий для управления секциями таблицы, секционированной
+	// по диапазону времени (например, таблиц успешно обработанных записей и журнала ошибок).
+	PartitionPostgres struct {
+		client mrstorage.DBConnManager
+		table  mrsql.DBTableInfo
+	}
+)
+
+// NewPartitionPostgres - создаёт объект PartitionPostgres.
+func NewPartitionPostgres(client mrstorage.DBConnManager, table mrsql.DBTableInfo) *PartitionPostgres {
+	return &PartitionPostgres{
+		client: client,
+		table:  table,
+	}
+}
+
+// TableName - возвращает название секционированной таблицы.
+func (re *PartitionPostgres) TableName() string {
+	return re.table.Name
+}
+
+// FetchPartitions - возвращает список секций таблицы с границами их диапазонов
+// в порядке возрастания нижней границы (секция DEFAULT в список не попадает).
+func (re *PartitionPostgres) FetchPartitions(ctx context.Context) ([]entity.Partition, error) {
+	sql := `
+		SELECT
+			partition_name,
+			substring(partition_bound from 'FROM \(''([^'']+)''\)')::timestamptz as range_from,
+			substring(partition_bound from 'TO \(''([^'']+)''\)')::timestamptz as range_to
+		FROM
+			(
+				SELECT
+					pn.nspname || '.' || pc.relname as partition_name,
+					pg_get_expr(pc.relpartbound, pc.oid) as partition_bound
+				FROM
+					pg_inherits pi
+				JOIN
+					pg_class pc ON pc.oid = pi.inhrelid
+				JOIN
+					pg_namespace pn ON pn.oid = pc.relnamespace
+				WHERE
+					pi.inhparent = $1::regclass
+			) t
+		WHERE
+			partition_bound LIKE 'FOR VALUES FROM%'
+		ORDER BY
+			range_from ASC;`
+
+	cursor, err := re.client.Conn(ctx).Query(
+		ctx,
+		sql,
+		re.table.Name,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close()
+
+	rows := make([]entity.Partition, 0)
+
+	for cursor.Next() {
+		var row entity.Partition
+
+		err = cursor.Scan(
+			&row.Name,
+			&row.From,
+			&row.To,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, cursor.Err()
+}
+
+// Create - создаёт секцию таблицы с указанным названием для диапазона [from, to),
+// если такая секция ещё не существует.
+func (re *PartitionPostgres) Create(ctx context.Context, row entity.Partition) error {
+	// DDL не поддерживает параметры запроса, поэтому границы подставляются литералами,
+	// значения которых формируются только внутри компонента
+	sql := `
+		CREATE TABLE IF NOT EXISTS ` + row.Name + `
+		PARTITION OF ` + re.table.Name + `
+		FOR VALUES FROM (` + re.timeLiteral(row.From) + `) TO (` + re.timeLiteral(row.To) + `);`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+	)
+}
+
+// FetchItemsIDs - возвращает ограниченный список уникальных ID записей указанной секции,
+// которые больше afterID, в порядке их возрастания (для постраничного обхода секции).
+func (re *PartitionPostgres) FetchItemsIDs(ctx context.Context, partitionName string, afterID uint64, limit int) (rowsIDs []uint64, err error) {
+	sql := `
+		SELECT DISTINCT
+			` + re.table.PrimaryKey + `
+		FROM
+			` + partitionName + `
+		WHERE
+			` + re.table.PrimaryKey + ` > $1
+		ORDER BY
+			` + re.table.PrimaryKey + ` ASC
+		` + mrstorage.NonZeroLimit(limit) + `;`
+
+	return fetchRowsIDs(
+		ctx,
+		re.client,
+		sql,
+		limit,
+		afterID,
+	)
+}
+
+// Drop - удаляет указанную секцию таблицы вместе со всеми её записями.
+func (re *PartitionPostgres) Drop(ctx context.Context, partitionName string) error {
+	sql := `
+		DROP TABLE IF EXISTS ` + partitionName + `;`
+
+	return re.client.Conn(ctx).Exec(
+		ctx,
+		sql,
+	)
+}
+
+func (re *PartitionPostgres) timeLiteral(value time.Time) string {
+	return "'" + strings.ReplaceAll(value.UTC().Format(time.RFC3339), "'", "") + "'"
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/mondegor/go-core/mrstorage"
@@ -128,29 +129,39 @@ func (re *QueuePostgres) UpdateStatusProcessingToReady(ctx context.Context, rows
 
 // UpdateStatusProcessingToRetry - переводит указанную запись из статуса PROCESSING в статус RETRY,
 // с уменьшением кол-ва попыток (например, в случае возникновения ошибки при обработке этой записи).
-func (re *QueuePostgres) UpdateStatusProcessingToRetry(ctx context.Context, rowID uint64) error {
+// Если указана задержка delay, то время нахождения записи в статусе RETRY (см. UpdateStatusRetryToReady)
+// начинает отсчитываться только после неё. Возвращает порядковый номер зафиксированной неудачной попытки.
+func (re *QueuePostgres) UpdateStatusProcessingToRetry(ctx context.Context, rowID uint64, delay time.Duration) (attempt uint16, err error) {
 	sql := `
 		UPDATE
 			` + re.table.Name + `
 		SET
 			item_status = $3,
 			remaining_attempts = remaining_attempts - 1,
-			updated_at = NOW()
+			failed_attempts = failed_attempts + 1,
+			updated_at = NOW() + INTERVAL '1 second' * $4
 		WHERE
-			` + re.table.PrimaryKey + ` = $1 AND item_status = $2;`
+			` + re.table.PrimaryKey + ` = $1 AND item_status = $2
+		RETURNING
+			failed_attempts;`
 
-	return re.client.Conn(ctx).ExecRow(
+	err = re.client.Conn(ctx).QueryRow(
 		ctx,
 		sql,
 		rowID,
 		itemstatus.Processing,
 		itemstatus.Retry,
+		uint32(math.Ceil(delay.Seconds())),
+	).Scan(
+		&attempt,
 	)
+
+	return attempt, err
 }
 
 // UpdateStatusProcessingToRetryByTimeout - переводит ограниченный список записей из статуса PROCESSING в статус RETRY находящихся там долгое время
-// (например, в случае если обработка записи подвисла).
-func (re *QueuePostgres) UpdateStatusProcessingToRetryByTimeout(ctx context.Context, timeout time.Duration, limit int) (rowIDs []uint64, err error) {
+// (например, в случае если обработка записи подвисла). Возвращает ID записей с порядковыми номерами зафиксированных неудачных попыток.
+func (re *QueuePostgres) UpdateStatusProcessingToRetryByTimeout(ctx context.Context, timeout time.Duration, limit int) (rows []dto.ItemAttempt, err error) {
 	sql := `
 		WITH processing_to_retry as (
 			SELECT
@@ -168,23 +179,46 @@ func (re *QueuePostgres) UpdateStatusProcessingToRetryByTimeout(ctx context.Cont
 			` + re.table.Name + ` t1
 		SET
 			item_status = $3,
+			failed_attempts = t1.failed_attempts + 1,
 			updated_at = NOW()
 	   	FROM
 			processing_to_retry ptr
 		WHERE
 			t1.` + re.table.PrimaryKey + ` = ptr.item_id
 		RETURNING
-			ptr.item_id;`
+			ptr.item_id,
+			t1.failed_attempts;`
 
-	return fetchRowsIDs(
+	cursor, err := re.client.Conn(ctx).Query(
 		ctx,
-		re.client,
 		sql,
-		limit,
 		itemstatus.Processing,
 		uint32(timeout.Seconds()),
 		itemstatus.Retry,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close()
+
+	rows = make([]dto.ItemAttempt, 0, limit)
+
+	for cursor.Next() {
+		var row dto.ItemAttempt
+
+		err = cursor.Scan(
+			&row.ID,
+			&row.Attempt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, cursor.Err()
 }
 
 // UpdateStatusRetryToReady - переводит ограниченный список записей из статуса RETRY в статус READY
@@ -276,3 +310,47 @@ func (re *QueuePostgres) Delete(ctx context.Context, rowID uint64, status itemst
 		status,
 	)
 }
+
+// DeleteFailed - удаляет запись из очереди по указанному rowID и находящеюся в указанном статусе
+// в связи с неудачной обработкой. Возвращает порядковый номер этой неудачной попытки.
+func (re *QueuePostgres) DeleteFailed(ctx context.Context, rowID uint64, status itemstatus.Enum) (attempt uint16, err error) {
+	sql := `
+		DELETE FROM
+			` + re.table.Name + `
+		WHERE
+			` + re.table.PrimaryKey + ` = $1 AND item_status = $2
+		RETURNING
+			failed_attempts + 1;`
+
+	err = re.client.Conn(ctx).QueryRow(
+		ctx,
+		sql,
+		rowID,
+		status,
+	).Scan(
+		&attempt,
+	)
+
+	return attempt, err
+}
+
+// FetchFailedAttempts - возвращает кол-во зафиксированных неудачных попыток обработки указанной записи.
+func (re *QueuePostgres) FetchFailedAttempts(ctx context.Context, rowID uint64) (attempts uint16, err error) {
+	sql := `
+		SELECT
+			failed_attempts
+		FROM
+			` + re.table.Name + `
+		WHERE
+			` + re.table.PrimaryKey + ` = $1;`
+
+	err = re.client.Conn(ctx).QueryRow(
+		ctx,
+		sql,
+		rowID,
+	).Scan(
+		&attempts,
+	)
+
+	return attempts, err
+}
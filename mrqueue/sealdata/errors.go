@@ -0,0 +1,19 @@
+package sealdata
+
+import (
+	"github.com/mondegor/go-core/errors"
+)
+
+var (
+	// ErrInternalKeyNotFound - sealing key is not found (attr: keyId).
+	ErrInternalKeyNotFound = errors.NewInternalProto("sealing key is not found")
+
+	// ErrInternalKeyInvalid - sealing key is invalid, AES-256 key of 32 bytes is expected (attrs: keyId, size).
+	ErrInternalKeyInvalid = errors.NewInternalProto("sealing key is invalid")
+
+	// ErrInternalDataNotOpened - sealed data cannot be opened: data or key is corrupted (attr: keyId).
+	ErrInternalDataNotOpened = errors.NewInternalProto("sealed data cannot be opened")
+
+	// ErrInternalSealerNotSpecified - stored data is sealed, but sealer is not specified.
+	ErrInternalSealerNotSpecified = errors.NewInternalProto("stored data is sealed, but sealer is not specified")
+)
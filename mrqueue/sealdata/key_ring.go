@@ -0,0 +1,69 @@
+package sealdata
+
+import (
+	"context"
+	"maps"
+)
+
+const (
+	// KeySize - размер ключа шифрования ключей (AES-256).
+	KeySize = 32
+)
+
+type (
+	// KeyProvider - поставщик ключей, которыми шифруются ключи данных (KEK).
+	// Каждый ключ имеет ID, который сохраняется вместе с запечатанными данными,
+	// поэтому после ротации данные, запечатанные прежними ключами, можно открыть,
+	// пока эти ключи возвращаются поставщиком.
+	KeyProvider interface {
+		CurrentKey(ctx context.Context) (Key, error)
+		KeyByID(ctx context.Context, keyID string) (Key, error)
+	}
+
+	// Key - ключ шифрования ключей данных.
+	Key struct {
+		ID     string
+		Secret []byte // ключ AES-256 (KeySize байт)
+	}
+
+	// KeyRing - статическая связка ключей с указанием текущего ключа,
+	// которым запечатываются новые данные (реализация KeyProvider).
+	KeyRing struct {
+		currentID string
+		keys      map[string][]byte
+	}
+)
+
+// NewKeyRing - создаёт объект KeyRing, где keys - ключи по их ID (включая прежние ключи для открытия данных),
+// currentID - ID ключа, которым запечатываются новые данные.
+func NewKeyRing(currentID string, keys map[string][]byte) (*KeyRing, error) {
+	for keyID, secret := range keys {
+		if keyID == "" || len(secret) != KeySize {
+			return nil, ErrInternalKeyInvalid.New("keyId", keyID, "size", len(secret))
+		}
+	}
+
+	if _, ok := keys[currentID]; !ok {
+		return nil, ErrInternalKeyNotFound.New("keyId", currentID)
+	}
+
+	return &KeyRing{
+		currentID: currentID,
+		keys:      maps.Clone(keys),
+	}, nil
+}
+
+// CurrentKey - возвращает ключ, которым запечатываются новые данные.
+func (r *KeyRing) CurrentKey(_ context.Context) (Key, error) {
+	return Key{ID: r.currentID, Secret: r.keys[r.currentID]}, nil
+}
+
+// KeyByID - возвращает ключ по его ID.
+func (r *KeyRing) KeyByID(_ context.Context, keyID string) (Key, error) {
+	secret, ok := r.keys[keyID]
+	if !ok {
+		return Key{}, ErrInternalKeyNotFound.New("keyId", keyID)
+	}
+
+	return Key{ID: keyID, Secret: secret}, nil
+}
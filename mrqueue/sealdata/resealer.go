@@ -0,0 +1,64 @@
+package sealdata
+
+import (
+	"context"
+
+	"github.com/mondegor/go-core/mrstorage"
+)
+
+type (
+	// Resealer - переводит хранимые данные на текущий ключ: данные, запечатанные прежними ключами,
+	// а также данные, хранимые в открытом виде, запечатываются заново текущим ключом.
+	Resealer[T any] struct {
+		txManager mrstorage.DBTxManager
+		storage   ResealStorage[T]
+	}
+
+	// ResealStorage - хранилище записей, данные которых требуется запечатать заново текущим ключом.
+	ResealStorage[T any] interface {
+		FetchForReseal(ctx context.Context, limit int) ([]T, error)
+		UpdateSealed(ctx context.Context, rows []T) error
+	}
+)
+
+// NewResealer - создаёт объект Resealer.
+func NewResealer[T any](txManager mrstorage.DBTxManager, storage ResealStorage[T]) *Resealer[T] {
+	return &Resealer[T]{
+		txManager: txManager,
+		storage:   storage,
+	}
+}
+
+// Execute - запечатывает текущим ключом записи пакетами по batchSize записей,
+// пока не будут обработаны все записи или не истечёт время контекста.
+func (r *Resealer[T]) Execute(ctx context.Context, batchSize int) error {
+	for {
+		var count int
+
+		err := r.txManager.Do(ctx, func(ctx context.Context) error {
+			rows, err := r.storage.FetchForReseal(ctx, batchSize)
+			if err != nil {
+				return err
+			}
+
+			count = len(rows)
+
+			if count == 0 {
+				return nil
+			}
+
+			return r.storage.UpdateSealed(ctx, rows)
+		})
+		if err != nil {
+			return err
+		}
+
+		if count < batchSize {
+			return nil
+		}
+
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package sealdata
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"strconv"
+
+	"github.com/mondegor/go-core/errors"
+)
+
+const (
+	// FieldName - название поля JSON документа, хранимого в БД, в котором находятся запечатанные данные.
+	FieldName = "_sealed"
+
+	dataKeySize = 32
+)
+
+type (
+	// Sealer - запечатывает (шифрует) данные, хранимые в БД, по схеме envelope encryption:
+	// данные шифруются случайным ключом данных (AES-256-GCM), который в свою очередь шифруется
+	// текущим ключом поставщика ключей и сохраняется вместе с данными и ID этого ключа.
+	Sealer struct {
+		keys KeyProvider
+	}
+
+	// Envelope - запечатанные данные вместе с зашифрованным ключом данных.
+	Envelope struct {
+		KeyID   string `json:"kid"`  // ID ключа, которым зашифрован ключ данных
+		DataKey []byte `json:"key"`  // зашифрованный ключ данных (nonce + ciphertext)
+		Data    []byte `json:"data"` // зашифрованные данные (nonce + ciphertext)
+	}
+)
+
+// NewSealer - создаёт объект Sealer.
+func NewSealer(keys KeyProvider) *Sealer {
+	return &Sealer{
+		keys: keys,
+	}
+}
+
+// CurrentKeyID - возвращает ID ключа, которым запечатываются новые данные.
+func (s *Sealer) CurrentKeyID(ctx context.Context) (string, error) {
+	key, err := s.keys.CurrentKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return key.ID, nil
+}
+
+// Seal - запечатывает данные текущим ключом, aad - связанные с данными сведения (например, ID записи),
+// без которых данные не могут быть открыты (защищает от подмены данных между записями).
+func (s *Sealer) Seal(ctx context.Context, plaintext, aad []byte) (*Envelope, error) {
+	key, err := s.keys.CurrentKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, dataKeySize)
+
+	if _, err = rand.Read(dataKey); err != nil {
+		return nil, errors.WrapInternalError(err, "generating data key failed")
+	}
+
+	data, err := seal(dataKey, plaintext, aad)
+	if err != nil {
+		return nil, errors.WrapInternalError(err, "sealing data failed")
+	}
+
+	wrappedKey, err := seal(key.Secret, dataKey, []byte(key.ID))
+	if err != nil {
+		return nil, ErrInternalKeyInvalid.Wrap(err, "keyId", key.ID, "size", len(key.Secret))
+	}
+
+	return &Envelope{
+		KeyID:   key.ID,
+		DataKey: wrappedKey,
+		Data:    data,
+	}, nil
+}
+
+// Open - открывает данные, запечатанные через Seal с теми же связанными сведениями aad.
+func (s *Sealer) Open(ctx context.Context, envelope *Envelope, aad []byte) ([]byte, error) {
+	key, err := s.keys.KeyByID(ctx, envelope.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := open(key.Secret, envelope.DataKey, []byte(envelope.KeyID))
+	if err != nil {
+		return nil, ErrInternalDataNotOpened.Wrap(err, "keyId", envelope.KeyID)
+	}
+
+	plaintext, err := open(dataKey, envelope.Data, aad)
+	if err != nil {
+		return nil, ErrInternalDataNotOpened.Wrap(err, "keyId", envelope.KeyID)
+	}
+
+	return plaintext, nil
+}
+
+// SealJSON - запечатывает указанное значение в виде JSON.
+func (s *Sealer) SealJSON(ctx context.Context, value any, aad []byte) (*Envelope, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.WrapInternalError(err, "marshal data for sealing failed")
+	}
+
+	return s.Seal(ctx, plaintext, aad)
+}
+
+// OpenJSON - открывает данные, запечатанные через SealJSON, и декодирует их в target.
+func (s *Sealer) OpenJSON(ctx context.Context, envelope *Envelope, aad []byte, target any) error {
+	plaintext, err := s.Open(ctx, envelope, aad)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(plaintext, target); err != nil {
+		return errors.WrapInternalError(err, "unmarshal opened data failed", "keyId", envelope.KeyID)
+	}
+
+	return nil
+}
+
+// Unwrap - возвращает запечатанные данные из поля FieldName JSON документа, хранимого в БД,
+// или nil, если документ хранится в открытом виде.
+func Unwrap(document []byte) (*Envelope, error) {
+	if !bytes.Contains(document, []byte(`"`+FieldName+`"`)) {
+		return nil, nil //nolint:nilnil
+	}
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(document, &fields); err != nil {
+		return nil, errors.WrapInternalError(err, "unmarshal stored document failed")
+	}
+
+	value := fields[FieldName]
+	if len(value) == 0 || value[0] != '{' {
+		return nil, nil //nolint:nilnil
+	}
+
+	var envelope Envelope
+
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return nil, errors.WrapInternalError(err, "unmarshal sealed envelope failed")
+	}
+
+	return &envelope, nil
+}
+
+// RowAAD - возвращает связанные сведения для данных записи с указанным ID.
+func RowAAD(rowID uint64) []byte {
+	return strconv.AppendUint(nil, rowID, 10)
+}
+
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.ErrInternalIncorrectInputData.WithDetails("sealed data is too short")
+	}
+
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,100 @@
+package sealdata_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrqueue/sealdata"
+)
+
+func newKeyRing(t *testing.T, currentID string) *sealdata.KeyRing {
+	t.Helper()
+
+	ring, err := sealdata.NewKeyRing(
+		currentID,
+		map[string][]byte{
+			"2026-01": bytes.Repeat([]byte{1}, sealdata.KeySize),
+			"2026-10": bytes.Repeat([]byte{2}, sealdata.KeySize),
+		},
+	)
+	require.NoError(t, err)
+
+	return ring
+}
+
+func TestSealer_SealOpen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sealer := sealdata.NewSealer(newKeyRing(t, "2026-10"))
+
+	envelope, err := sealer.Seal(ctx, []byte("login code 123456"), sealdata.RowAAD(42))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10", envelope.KeyID)
+	assert.NotContains(t, string(envelope.Data), "123456")
+
+	plaintext, err := sealer.Open(ctx, envelope, sealdata.RowAAD(42))
+	require.NoError(t, err)
+	assert.Equal(t, "login code 123456", string(plaintext))
+
+	// данные одной записи нельзя открыть как данные другой записи
+	_, err = sealer.Open(ctx, envelope, sealdata.RowAAD(43))
+	require.Error(t, err)
+}
+
+func TestSealer_Rotation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	envelope, err := sealdata.NewSealer(newKeyRing(t, "2026-01")).SealJSON(ctx, map[string]string{"code": "1"}, nil)
+	require.NoError(t, err)
+
+	// после ротации данные, запечатанные прежним ключом, открываются, пока ключ есть в связке
+	var got map[string]string
+
+	require.NoError(t, sealdata.NewSealer(newKeyRing(t, "2026-10")).OpenJSON(ctx, envelope, nil, &got))
+	assert.Equal(t, map[string]string{"code": "1"}, got)
+
+	ring, err := sealdata.NewKeyRing("2026-10", map[string][]byte{"2026-10": bytes.Repeat([]byte{2}, sealdata.KeySize)})
+	require.NoError(t, err)
+
+	require.Error(t, sealdata.NewSealer(ring).OpenJSON(ctx, envelope, nil, &got))
+}
+
+func TestUnwrap(t *testing.T) {
+	t.Parallel()
+
+	envelope, err := sealdata.NewSealer(newKeyRing(t, "2026-10")).Seal(context.Background(), []byte("data"), nil)
+	require.NoError(t, err)
+
+	document, err := json.Marshal(map[string]any{"header": map[string]string{"k": "v"}, sealdata.FieldName: envelope})
+	require.NoError(t, err)
+
+	got, err := sealdata.Unwrap(document)
+	require.NoError(t, err)
+	assert.Equal(t, envelope, got)
+
+	got, err = sealdata.Unwrap([]byte(`{"header":{"k":"v"},"mail":{"to":"user@localhost"}}`))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = sealdata.Unwrap([]byte(`{"_sealed":"plain value"}`))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestNewKeyRing_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := sealdata.NewKeyRing("2026-10", map[string][]byte{"2026-10": []byte("short")})
+	require.Error(t, err)
+
+	_, err = sealdata.NewKeyRing("2026-11", map[string][]byte{"2026-10": bytes.Repeat([]byte{2}, sealdata.KeySize)})
+	require.Error(t, err)
+}
@@ -2,13 +2,18 @@ package consume
 
 import (
 	"context"
+	"time"
 
 	"github.com/mondegor/go-core/errors"
 	"github.com/mondegor/go-core/errors/kind"
 	"github.com/mondegor/go-core/mrstorage"
 
+	"github.com/mondegor/go-components/mrqueue"
+	"github.com/mondegor/go-components/mrqueue/dto"
 	"github.com/mondegor/go-components/mrqueue/entity"
+	"github.com/mondegor/go-components/mrqueue/enum/errorkind"
 	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
 )
 
 type (
@@ -18,14 +23,19 @@ type (
 		storage          itemStorage
 		storageCompleted completedItemStorage // OPTIONAL
 		storageCrashed   crashedItemStorage   // OPTIONAL
+		notifier         observe.Notifier     // OPTIONAL
 		errorWrapper     errors.Wrapper
+		workerInstance   string
+		maxRetryDelay    time.Duration
 	}
 
 	itemStorage interface {
 		FetchAndUpdateStatusReadyToProcessing(ctx context.Context, limit int) (rowsIDs []uint64, err error)
 		UpdateStatusProcessingToReady(ctx context.Context, rowsIDs []uint64) error
-		UpdateStatusProcessingToRetry(ctx context.Context, rowID uint64) error
+		UpdateStatusProcessingToRetry(ctx context.Context, rowID uint64, delay time.Duration) (attempt uint16, err error)
+		FetchFailedAttempts(ctx context.Context, rowID uint64) (attempts uint16, err error)
 		Delete(ctx context.Context, rowID uint64, status itemstatus.Enum) error
+		DeleteFailed(ctx context.Context, rowID uint64, status itemstatus.Enum) (attempt uint16, err error)
 	}
 
 	completedItemStorage interface {
@@ -35,6 +45,22 @@ type (
 	crashedItemStorage interface {
 		InsertOne(ctx context.Context, row entity.CrashedItem) error
 	}
+
+	// errorCoder - ошибка, содержащая код, который фиксируется в журнале ошибок.
+	errorCoder interface {
+		Code() string
+	}
+
+	// retryDelayer - ошибка, содержащая время, раньше которого повторять обработку элемента
+	// не имеет смысла (например, из заголовка Retry-After ответа провайдера).
+	retryDelayer interface {
+		RetryDelay() time.Duration
+	}
+)
+
+const (
+	maxErrorCodeLen      = 64
+	defaultMaxRetryDelay = time.Hour
 )
 
 var errSystemNoProcessingRowFound = errors.NewSystemProto("no processing row found")
@@ -47,9 +73,11 @@ func NewQueueConsumer(
 ) *QueueConsumer {
 	o := options{
 		consumer: &QueueConsumer{
-			txManager:    txManager,
-			storage:      storage,
-			errorWrapper: errors.NewServiceOperationFailedWrapper(),
+			txManager:      txManager,
+			storage:        storage,
+			errorWrapper:   errors.NewServiceOperationFailedWrapper(),
+			workerInstance: mrqueue.DefaultWorkerInstance(),
+			maxRetryDelay:  defaultMaxRetryDelay,
 		},
 	}
 
@@ -57,6 +85,8 @@ func NewQueueConsumer(
 		opt(&o)
 	}
 
+	o.consumer.workerInstance = mrqueue.TruncateWorkerInstance(o.consumer.workerInstance)
+
 	return o.consumer
 }
 
@@ -82,14 +112,25 @@ func (sv *QueueConsumer) CancelItems(ctx context.Context, itemsIDs []uint64) err
 		return nil
 	}
 
-	if err := sv.storage.UpdateStatusProcessingToReady(ctx, itemsIDs); err != nil {
-		if errors.Is(err, errors.ErrEventStorageRecordsNotAffected) {
-			return nil
+	if sv.notifier == nil {
+		return sv.cancelItems(ctx, itemsIDs)
+	}
+
+	transitions := observe.Transitions(itemsIDs, itemstatus.Processing, itemstatus.Ready, "")
+
+	err := sv.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := sv.cancelItems(ctx, itemsIDs); err != nil {
+			return err
 		}
 
-		return sv.errorWrapper.Wrap(err)
+		return sv.notifier.NotifyInTx(ctx, transitions)
+	})
+	if err != nil {
+		return err
 	}
 
+	sv.notifier.NotifyAfterCommit(ctx, transitions)
+
 	return nil
 }
 
@@ -100,7 +141,15 @@ func (sv *QueueConsumer) Commit(ctx context.Context, itemID uint64) error {
 		return errors.ErrInternalIncorrectInputData.WithDetails("itemID is zero")
 	}
 
-	return sv.txManager.Do(ctx, func(ctx context.Context) error {
+	transitions := []dto.Transition{
+		{
+			ItemID: itemID,
+			From:   itemstatus.Processing,
+			To:     itemstatus.Completed,
+		},
+	}
+
+	err := sv.txManager.Do(ctx, func(ctx context.Context) error {
 		if err := sv.storage.Delete(ctx, itemID, itemstatus.Processing); err != nil {
 			return sv.errorWrapper.Wrap(err)
 		}
@@ -111,45 +160,183 @@ func (sv *QueueConsumer) Commit(ctx context.Context, itemID uint64) error {
 			}
 		}
 
-		return nil
+		return sv.notifyInTx(ctx, transitions)
 	})
+	if err != nil {
+		return err
+	}
+
+	sv.notifyAfterCommit(ctx, transitions)
+
+	return nil
 }
 
 // Reject - отклоняет результат обработки указанного элемента очереди с указанием причины ошибки.
-// Если причина ошибки типа System, то элемент переводится в статус RETRY с фиксацией ошибки в журнале.
+// Если причина ошибки типа System, то элемент переводится в статус RETRY с фиксацией ошибки в журнале,
+// при этом если причина содержит время, раньше которого повторять обработку не имеет смысла (RetryDelay),
+// то следующая попытка откладывается на это время (но не более maxRetryDelay).
 // Иначе элемент удаляется из очереди с фиксацией уточнённой ошибки в журнале.
+// Если элемент уже не находится в статусе PROCESSING (например, переведён в RETRY по таймауту),
+// то ошибка фиксируется в журнале под номером последней неудачной попытки этого элемента.
 func (sv *QueueConsumer) Reject(ctx context.Context, itemID uint64, causeErr error) error {
 	if itemID == 0 {
 		return errors.ErrInternalIncorrectInputData.WithDetails("itemID is zero")
 	}
 
-	return sv.txManager.Do(ctx, func(ctx context.Context) error {
+	var transitions []dto.Transition
+
+	err := sv.txManager.Do(ctx, func(ctx context.Context) error {
+		transitions = nil
+
+		var (
+			attempt uint16
+			err     error
+		)
+
 		switch kind.Extract(causeErr) {
 		case kind.System:
-			if err := sv.storage.UpdateStatusProcessingToRetry(ctx, itemID); err != nil {
+			if attempt, err = sv.storage.UpdateStatusProcessingToRetry(ctx, itemID, sv.retryDelay(causeErr)); err != nil {
 				if !errors.Is(err, errors.ErrEventStorageNoRecordFound) {
 					return sv.errorWrapper.Wrap(err)
 				}
 
 				causeErr = errSystemNoProcessingRowFound.Wrap(causeErr)
+
+				if attempt, err = sv.lastFailedAttempt(ctx, itemID); err != nil {
+					return err
+				}
+			} else {
+				transitions = sv.rejectTransitions(itemID, itemstatus.Retry, causeErr)
 			}
 		default:
-			if err := sv.storage.Delete(ctx, itemID, itemstatus.Processing); err != nil {
+			if attempt, err = sv.storage.DeleteFailed(ctx, itemID, itemstatus.Processing); err != nil {
 				return sv.errorWrapper.Wrap(err)
 			}
+
+			transitions = sv.rejectTransitions(itemID, itemstatus.Crashed, causeErr)
 		}
 
-		if sv.storageCrashed != nil {
+		// если attempt == 0, то элемента уже нет в очереди и ошибку отнести не к чему
+		if sv.storageCrashed != nil && attempt > 0 {
 			crashedItem := entity.CrashedItem{
-				ID:    itemID,
-				Cause: causeErr.Error(),
+				ID:             itemID,
+				Attempt:        attempt,
+				ErrorKind:      sv.errorKind(causeErr),
+				ErrorCode:      sv.errorCode(causeErr),
+				WorkerInstance: sv.workerInstance,
+				Cause:          causeErr.Error(),
 			}
 
-			if err := sv.storageCrashed.InsertOne(ctx, crashedItem); err != nil {
+			if err = sv.storageCrashed.InsertOne(ctx, crashedItem); err != nil {
 				return sv.errorWrapper.Wrap(err)
 			}
 		}
 
-		return nil
+		return sv.notifyInTx(ctx, transitions)
 	})
+	if err != nil {
+		return err
+	}
+
+	sv.notifyAfterCommit(ctx, transitions)
+
+	return nil
+}
+
+// lastFailedAttempt - возвращает номер последней неудачной попытки элемента
+// или 0, если элемента уже нет в очереди или у него нет неудачных попыток.
+func (sv *QueueConsumer) lastFailedAttempt(ctx context.Context, itemID uint64) (uint16, error) {
+	if sv.storageCrashed == nil {
+		return 0, nil
+	}
+
+	attempts, err := sv.storage.FetchFailedAttempts(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, errors.ErrEventStorageNoRecordFound) {
+			return 0, nil
+		}
+
+		return 0, sv.errorWrapper.Wrap(err)
+	}
+
+	return attempts, nil
+}
+
+func (sv *QueueConsumer) cancelItems(ctx context.Context, itemsIDs []uint64) error {
+	if err := sv.storage.UpdateStatusProcessingToReady(ctx, itemsIDs); err != nil {
+		if errors.Is(err, errors.ErrEventStorageRecordsNotAffected) {
+			return nil
+		}
+
+		return sv.errorWrapper.Wrap(err)
+	}
+
+	return nil
+}
+
+func (sv *QueueConsumer) rejectTransitions(itemID uint64, to itemstatus.Enum, causeErr error) []dto.Transition {
+	return []dto.Transition{
+		{
+			ItemID: itemID,
+			From:   itemstatus.Processing,
+			To:     to,
+			Cause:  causeErr.Error(),
+		},
+	}
+}
+
+func (sv *QueueConsumer) notifyInTx(ctx context.Context, transitions []dto.Transition) error {
+	if sv.notifier == nil || len(transitions) == 0 {
+		return nil
+	}
+
+	return sv.notifier.NotifyInTx(ctx, transitions)
+}
+
+func (sv *QueueConsumer) notifyAfterCommit(ctx context.Context, transitions []dto.Transition) {
+	if sv.notifier == nil || len(transitions) == 0 {
+		return
+	}
+
+	sv.notifier.NotifyAfterCommit(ctx, transitions)
+}
+
+func (sv *QueueConsumer) errorKind(err error) errorkind.Enum {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errorkind.Timeout
+	}
+
+	if kind.Extract(err) == kind.System {
+		return errorkind.System
+	}
+
+	return errorkind.User
+}
+
+func (sv *QueueConsumer) retryDelay(err error) time.Duration {
+	var delayer retryDelayer
+
+	if !errors.As(err, &delayer) {
+		return 0
+	}
+
+	if delay := delayer.RetryDelay(); delay > 0 {
+		return min(delay, sv.maxRetryDelay)
+	}
+
+	return 0
+}
+
+func (sv *QueueConsumer) errorCode(err error) string {
+	var coder errorCoder
+
+	if !errors.As(err, &coder) {
+		return ""
+	}
+
+	if code := coder.Code(); len(code) > maxErrorCodeLen {
+		return code[:maxErrorCodeLen]
+	}
+
+	return coder.Code()
 }
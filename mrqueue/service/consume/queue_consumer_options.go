@@ -1,5 +1,11 @@
 package consume
 
+import (
+	"time"
+
+	"github.com/mondegor/go-components/mrqueue/observe"
+)
+
 type (
 	// Option - настройка объекта QueueConsumer.
 	Option func(o *options)
@@ -22,3 +28,26 @@ func WithStorageCrashed(value crashedItemStorage) Option {
 		o.consumer.storageCrashed = value
 	}
 }
+
+// WithWorkerInstance - устанавливает опцию workerInstance для QueueConsumer.
+func WithWorkerInstance(value string) Option {
+	return func(o *options) {
+		o.consumer.workerInstance = value
+	}
+}
+
+// WithMaxRetryDelay - устанавливает опцию maxRetryDelay (максимальное время, на которое откладывается
+// следующая попытка обработки элемента по просьбе провайдера, например по заголовку Retry-After) для QueueConsumer.
+// Если указан 0, то такие просьбы не учитываются.
+func WithMaxRetryDelay(value time.Duration) Option {
+	return func(o *options) {
+		o.consumer.maxRetryDelay = value
+	}
+}
+
+// WithTransitionNotifier - устанавливает опцию notifier (оповещение наблюдателей о переходах элементов) для QueueConsumer.
+func WithTransitionNotifier(value observe.Notifier) Option {
+	return func(o *options) {
+		o.consumer.notifier = value
+	}
+}
@@ -0,0 +1,298 @@
+package consume_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrqueue/entity"
+	"github.com/mondegor/go-components/mrqueue/enum/errorkind"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/service/consume"
+)
+
+const testWorkerInstance = "worker-1:100"
+
+type (
+	fakeTxManager struct{}
+
+	fakeItemStorage struct {
+		failedAttempts uint16
+		retryErr       error
+		fetchErr       error
+		retried        []uint64
+		retryDelays    []time.Duration
+		deleted        []uint64
+	}
+
+	fakeCrashedStorage struct {
+		rows []entity.CrashedItem
+	}
+
+	codedError struct {
+		code string
+	}
+
+	retryAfterError struct {
+		delay time.Duration
+	}
+)
+
+func (fakeTxManager) Do(ctx context.Context, job func(ctx context.Context) error) error {
+	return job(ctx)
+}
+
+func (s *fakeItemStorage) FetchAndUpdateStatusReadyToProcessing(_ context.Context, _ int) ([]uint64, error) {
+	return nil, nil
+}
+
+func (s *fakeItemStorage) UpdateStatusProcessingToReady(_ context.Context, _ []uint64) error {
+	return nil
+}
+
+func (s *fakeItemStorage) UpdateStatusProcessingToRetry(_ context.Context, rowID uint64, delay time.Duration) (uint16, error) {
+	if s.retryErr != nil {
+		return 0, s.retryErr
+	}
+
+	s.retried = append(s.retried, rowID)
+	s.retryDelays = append(s.retryDelays, delay)
+	s.failedAttempts++
+
+	return s.failedAttempts, nil
+}
+
+func (s *fakeItemStorage) FetchFailedAttempts(_ context.Context, _ uint64) (uint16, error) {
+	if s.fetchErr != nil {
+		return 0, s.fetchErr
+	}
+
+	return s.failedAttempts, nil
+}
+
+func (s *fakeItemStorage) Delete(_ context.Context, rowID uint64, _ itemstatus.Enum) error {
+	s.deleted = append(s.deleted, rowID)
+
+	return nil
+}
+
+func (s *fakeItemStorage) DeleteFailed(_ context.Context, rowID uint64, _ itemstatus.Enum) (uint16, error) {
+	s.deleted = append(s.deleted, rowID)
+
+	return s.failedAttempts + 1, nil
+}
+
+func (s *fakeCrashedStorage) InsertOne(_ context.Context, row entity.CrashedItem) error {
+	s.rows = append(s.rows, row)
+
+	return nil
+}
+
+func (e codedError) Error() string {
+	return "coded error " + e.code
+}
+
+func (e codedError) Code() string {
+	return e.code
+}
+
+func (e retryAfterError) Error() string {
+	return "retry after " + e.delay.String()
+}
+
+func (e retryAfterError) RetryDelay() time.Duration {
+	return e.delay
+}
+
+func TestQueueConsumer_Reject_InsertsCrashedItem(t *testing.T) {
+	t.Parallel()
+
+	errSystem := errors.NewSystemProto("service unavailable")
+
+	tests := []struct {
+		name           string
+		causeErr       error
+		failedAttempts uint16
+		retryErr       error
+		wantRetried    bool
+		wantDeleted    bool
+		wantAttempt    uint16
+		wantKind       errorkind.Enum
+		wantCode       string
+		wantNotFound   bool
+	}{
+		{
+			name:        "system error is retried",
+			causeErr:    errSystem.New(),
+			wantRetried: true,
+			wantAttempt: 1,
+			wantKind:    errorkind.System,
+		},
+		{
+			name:           "attempt continues item counter",
+			causeErr:       errSystem.New(),
+			failedAttempts: 2,
+			wantRetried:    true,
+			wantAttempt:    3,
+			wantKind:       errorkind.System,
+		},
+		{
+			name:        "deadline exceeded is timeout",
+			causeErr:    errSystem.Wrap(context.DeadlineExceeded),
+			wantRetried: true,
+			wantAttempt: 1,
+			wantKind:    errorkind.Timeout,
+		},
+		{
+			name:        "user error is removed with its code",
+			causeErr:    codedError{code: "RecipientRejected"},
+			wantDeleted: true,
+			wantAttempt: 1,
+			wantKind:    errorkind.User,
+			wantCode:    "RecipientRejected",
+		},
+		{
+			name:        "long code is truncated",
+			causeErr:    codedError{code: strings.Repeat("A", 100)},
+			wantDeleted: true,
+			wantAttempt: 1,
+			wantKind:    errorkind.User,
+			wantCode:    strings.Repeat("A", 64),
+		},
+		{
+			name:           "item is not processing",
+			causeErr:       errSystem.New(),
+			failedAttempts: 2,
+			retryErr:       errors.ErrEventStorageNoRecordFound.New(),
+			wantAttempt:    2,
+			wantKind:       errorkind.System,
+			wantNotFound:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage := &fakeItemStorage{
+				failedAttempts: tt.failedAttempts,
+				retryErr:       tt.retryErr,
+			}
+			storageCrashed := &fakeCrashedStorage{}
+
+			consumer := consume.NewQueueConsumer(
+				fakeTxManager{},
+				storage,
+				consume.WithStorageCrashed(storageCrashed),
+				consume.WithWorkerInstance(testWorkerInstance),
+			)
+
+			require.NoError(t, consumer.Reject(context.Background(), 7, tt.causeErr))
+			assert.Equal(t, tt.wantRetried, len(storage.retried) == 1)
+			assert.Equal(t, tt.wantDeleted, len(storage.deleted) == 1)
+
+			require.Len(t, storageCrashed.rows, 1)
+			row := storageCrashed.rows[0]
+			assert.Equal(t, uint64(7), row.ID)
+			assert.Equal(t, tt.wantAttempt, row.Attempt)
+			assert.Equal(t, tt.wantKind, row.ErrorKind)
+			assert.Equal(t, tt.wantCode, row.ErrorCode)
+			assert.Equal(t, testWorkerInstance, row.WorkerInstance)
+			assert.Contains(t, row.Cause, tt.causeErr.Error())
+
+			if tt.wantNotFound {
+				assert.Contains(t, row.Cause, "no processing row found")
+			}
+		})
+	}
+}
+
+func TestQueueConsumer_Reject_ItemIsGone(t *testing.T) {
+	t.Parallel()
+
+	storage := &fakeItemStorage{
+		retryErr: errors.ErrEventStorageNoRecordFound.New(),
+		fetchErr: errors.ErrEventStorageNoRecordFound.New(),
+	}
+	storageCrashed := &fakeCrashedStorage{}
+	consumer := consume.NewQueueConsumer(fakeTxManager{}, storage, consume.WithStorageCrashed(storageCrashed))
+
+	require.NoError(t, consumer.Reject(context.Background(), 7, errors.NewSystemProto("failed").New()))
+	assert.Empty(t, storageCrashed.rows)
+}
+
+func TestQueueConsumer_Reject_TruncatesWorkerInstance(t *testing.T) {
+	t.Parallel()
+
+	storageCrashed := &fakeCrashedStorage{}
+	consumer := consume.NewQueueConsumer(
+		fakeTxManager{},
+		&fakeItemStorage{},
+		consume.WithStorageCrashed(storageCrashed),
+		consume.WithWorkerInstance(strings.Repeat("w", 200)),
+	)
+
+	require.NoError(t, consumer.Reject(context.Background(), 7, errors.NewSystemProto("failed").New()))
+	require.Len(t, storageCrashed.rows, 1)
+	assert.Equal(t, strings.Repeat("w", 128), storageCrashed.rows[0].WorkerInstance)
+}
+
+func TestQueueConsumer_Reject_RetryDelay(t *testing.T) {
+	t.Parallel()
+
+	errSystem := errors.NewSystemProto("provider unavailable")
+
+	tests := []struct {
+		name     string
+		causeErr error
+		opts     []consume.Option
+		want     time.Duration
+	}{
+		{
+			name:     "without retry delay",
+			causeErr: errSystem.New(),
+		},
+		{
+			name:     "retry delay of wrapped cause",
+			causeErr: errSystem.Wrap(retryAfterError{delay: 2 * time.Minute}),
+			want:     2 * time.Minute,
+		},
+		{
+			name:     "retry delay is limited",
+			causeErr: errSystem.Wrap(retryAfterError{delay: 48 * time.Hour}),
+			want:     time.Hour,
+		},
+		{
+			name:     "retry delay is ignored",
+			causeErr: errSystem.Wrap(retryAfterError{delay: 2 * time.Minute}),
+			opts:     []consume.Option{consume.WithMaxRetryDelay(0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage := &fakeItemStorage{}
+			consumer := consume.NewQueueConsumer(fakeTxManager{}, storage, tt.opts...)
+
+			require.NoError(t, consumer.Reject(context.Background(), 7, tt.causeErr))
+			assert.Equal(t, []time.Duration{tt.want}, storage.retryDelays)
+		})
+	}
+}
+
+func TestQueueConsumer_Reject_ZeroItemID(t *testing.T) {
+	t.Parallel()
+
+	storageCrashed := &fakeCrashedStorage{}
+	consumer := consume.NewQueueConsumer(fakeTxManager{}, &fakeItemStorage{}, consume.WithStorageCrashed(storageCrashed))
+
+	require.Error(t, consumer.Reject(context.Background(), 0, errors.NewSystemProto("failed").New()))
+	assert.Empty(t, storageCrashed.rows)
+}
@@ -0,0 +1,222 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	// KeyTraceParent - название заголовка W3C Trace Context с идентификаторами трассировки и родительского спана.
+	KeyTraceParent = "traceparent"
+
+	// KeyTraceState - название заголовка W3C Trace Context с данными трассировки конкретных вендоров.
+	KeyTraceState = "tracestate"
+
+	// KeyEnqueuedAt - название заголовка со временем размещения элемента в очереди
+	// (используется для учёта времени ожидания элемента в очереди).
+	KeyEnqueuedAt = "enqueued_at"
+
+	traceParentVersion = "00"
+	traceParentLen     = 55 // 00-{32}-{16}-{2}
+	flagSampled        = 0x01
+)
+
+type (
+	// SpanContext - контекст трассировки в формате W3C Trace Context,
+	// который переносится через заголовки элементов очереди.
+	SpanContext struct {
+		TraceID [16]byte
+		SpanID  [8]byte
+		Flags   byte
+		State   string
+
+		parentID [8]byte // не переносится через заголовки
+	}
+
+	ctxSpanContextKey struct{}
+)
+
+// ParseTraceParent - разбирает значение заголовка traceparent.
+// Поддерживается версия 00, значения других версий разбираются по её правилам.
+func ParseTraceParent(value string) (sc SpanContext, ok bool) {
+	value = strings.TrimSpace(value)
+
+	if len(value) < traceParentLen || value[2] != '-' || value[35] != '-' || value[52] != '-' {
+		return SpanContext{}, false
+	}
+
+	// версия ff запрещена, а у версии 00 не может быть дополнительных полей
+	if value[:2] == "ff" || (len(value) > traceParentLen && (value[:2] == traceParentVersion || value[traceParentLen] != '-')) {
+		return SpanContext{}, false
+	}
+
+	if !isLowerHex(value[:2]) || !isLowerHex(value[3:35]) || !isLowerHex(value[36:52]) || !isLowerHex(value[53:55]) {
+		return SpanContext{}, false
+	}
+
+	var flags [1]byte
+
+	if _, err := hex.Decode(sc.TraceID[:], []byte(value[3:35])); err != nil {
+		return SpanContext{}, false
+	}
+
+	if _, err := hex.Decode(sc.SpanID[:], []byte(value[36:52])); err != nil {
+		return SpanContext{}, false
+	}
+
+	if _, err := hex.Decode(flags[:], []byte(value[53:55])); err != nil {
+		return SpanContext{}, false
+	}
+
+	sc.Flags = flags[0]
+
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+
+	return sc, true
+}
+
+// IsValid - проверяет, что идентификаторы трассировки и спана не нулевые.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// IsSampled - сообщает, установлен ли флаг записи трассировки.
+func (sc SpanContext) IsSampled() bool {
+	return sc.Flags&flagSampled != 0
+}
+
+// TraceParent - возвращает значение для заголовка traceparent.
+func (sc SpanContext) TraceParent() string {
+	var buf strings.Builder
+
+	buf.Grow(traceParentLen)
+	buf.WriteString(traceParentVersion)
+	buf.WriteByte('-')
+	buf.WriteString(hex.EncodeToString(sc.TraceID[:]))
+	buf.WriteByte('-')
+	buf.WriteString(hex.EncodeToString(sc.SpanID[:]))
+	buf.WriteByte('-')
+	buf.WriteString(hex.EncodeToString([]byte{sc.Flags}))
+
+	return buf.String()
+}
+
+// TraceIDString - возвращает идентификатор трассировки в виде hex строки.
+func (sc SpanContext) TraceIDString() string {
+	return hex.EncodeToString(sc.TraceID[:])
+}
+
+// SpanIDString - возвращает идентификатор спана в виде hex строки.
+func (sc SpanContext) SpanIDString() string {
+	return hex.EncodeToString(sc.SpanID[:])
+}
+
+// ParentSpanIDString - возвращает идентификатор родительского спана в виде hex строки
+// (пустая строка, если спан начинает трассировку или получен из заголовка).
+func (sc SpanContext) ParentSpanIDString() string {
+	if sc.parentID == [8]byte{} {
+		return ""
+	}
+
+	return hex.EncodeToString(sc.parentID[:])
+}
+
+// WithSpanContext - возвращает контекст с указанным контекстом трассировки.
+// Используется приложением для связи с внешней трассировкой (например, с входящим HTTP запросом).
+func WithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+
+	return context.WithValue(ctx, ctxSpanContextKey{}, sc)
+}
+
+// SpanContextFromContext - возвращает контекст трассировки, если он был ранее сохранён в контексте.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(ctxSpanContextKey{}).(SpanContext)
+
+	return sc, ok
+}
+
+// Inject - записывает контекст трассировки из ctx в заголовок под ключами с указанным префиксом.
+func Inject(ctx context.Context, header map[string]string, prefix string) {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	header[prefix+KeyTraceParent] = sc.TraceParent()
+
+	if sc.State != "" {
+		header[prefix+KeyTraceState] = sc.State
+	} else {
+		delete(header, prefix+KeyTraceState)
+	}
+}
+
+// Extract - восстанавливает контекст трассировки из заголовка (ключи с указанным префиксом).
+// Если заголовок не содержит корректного traceparent, то возвращается исходный контекст.
+func Extract(ctx context.Context, header map[string]string, prefix string) context.Context {
+	sc, ok := ParseTraceParent(header[prefix+KeyTraceParent])
+	if !ok {
+		return ctx
+	}
+
+	sc.State = header[prefix+KeyTraceState]
+
+	return WithSpanContext(ctx, sc)
+}
+
+// MarkEnqueued - записывает в заголовок время размещения элемента в очереди.
+func MarkEnqueued(header map[string]string, prefix string, enqueuedAt time.Time) {
+	header[prefix+KeyEnqueuedAt] = enqueuedAt.UTC().Format(time.RFC3339Nano)
+}
+
+// EnqueuedAt - возвращает время размещения элемента в очереди, если оно было указано в заголовке.
+func EnqueuedAt(header map[string]string, prefix string) (time.Time, bool) {
+	value, ok := header[prefix+KeyEnqueuedAt]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	enqueuedAt, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return enqueuedAt, true
+}
+
+func newChildSpanContext(parent SpanContext, hasParent bool) SpanContext {
+	sc := SpanContext{
+		Flags: flagSampled,
+	}
+
+	if hasParent {
+		sc.TraceID = parent.TraceID
+		sc.Flags = parent.Flags
+		sc.State = parent.State
+		sc.parentID = parent.SpanID
+	} else {
+		_, _ = rand.Read(sc.TraceID[:])
+	}
+
+	_, _ = rand.Read(sc.SpanID[:])
+
+	return sc
+}
+
+func isLowerHex(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
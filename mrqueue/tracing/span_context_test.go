@@ -0,0 +1,83 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrqueue/tracing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "valid", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", want: true},
+		{name: "future version with extra fields", value: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", want: true},
+		{name: "version ff", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", want: false},
+		{name: "version 00 with extra fields", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", want: false},
+		{name: "zero trace id", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", want: false},
+		{name: "zero span id", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", want: false},
+		{name: "upper case", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", want: false},
+		{name: "empty", value: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, ok := tracing.ParseTraceParent(tt.value)
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	t.Parallel()
+
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	ctx := tracing.Extract(
+		context.Background(),
+		map[string]string{
+			"header.traceparent": traceParent,
+			"header.tracestate":  "vendor=value",
+		},
+		"header.",
+	)
+
+	parent, ok := tracing.SpanContextFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, traceParent, parent.TraceParent())
+
+	ctx = tracing.WithChildSpan(ctx)
+	tracing.Trace(ctx, nil, "test", time.Now(), nil)
+
+	header := make(map[string]string)
+	tracing.Inject(ctx, header, "")
+
+	child, ok := tracing.ParseTraceParent(header[tracing.KeyTraceParent])
+	require.True(t, ok)
+	assert.Equal(t, parent.TraceID, child.TraceID)
+	assert.NotEqual(t, parent.SpanID, child.SpanID)
+	assert.Empty(t, child.ParentSpanIDString())
+	assert.Equal(t, "vendor=value", header[tracing.KeyTraceState])
+
+	current, ok := tracing.SpanContextFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, parent.SpanIDString(), current.ParentSpanIDString())
+
+	enqueuedAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	tracing.MarkEnqueued(header, "", enqueuedAt)
+
+	got, ok := tracing.EnqueuedAt(header, "")
+	require.True(t, ok)
+	assert.True(t, enqueuedAt.Equal(got))
+}
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/mondegor/go-core/mrtrace"
+)
+
+// WithChildSpan - возвращает контекст с новым спаном операции, дочерним по отношению
+// к спану из ctx (или с новой трассировкой, если в контексте её нет).
+// Именно этот спан переносится дальше через заголовки элемента очереди (см. Inject).
+func WithChildSpan(ctx context.Context) context.Context {
+	parent, hasParent := SpanContextFromContext(ctx)
+
+	return WithSpanContext(ctx, newChildSpanContext(parent, hasParent))
+}
+
+// Trace - передаёт в mrtrace.Tracer сведения о завершённой операции с указанным названием
+// вместе с идентификаторами трассировки из ctx, временем начала, длительностью и ошибкой операции.
+// Если tracer не указан или трассировка не отмечена для записи, то ничего не передаётся.
+func Trace(ctx context.Context, tracer mrtrace.Tracer, name string, startedAt time.Time, err error, args ...any) {
+	if tracer == nil {
+		return
+	}
+
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok || !sc.IsSampled() {
+		return
+	}
+
+	traceArgs := make([]any, 0, len(args)+14)
+	traceArgs = append(
+		traceArgs,
+		"span", name,
+		"traceId", sc.TraceIDString(),
+		"spanId", sc.SpanIDString(),
+		"parentSpanId", sc.ParentSpanIDString(),
+		"startedAt", startedAt,
+		"duration", time.Since(startedAt),
+	)
+
+	if err != nil {
+		traceArgs = append(traceArgs, "error", err)
+	}
+
+	tracer.Trace(ctx, append(traceArgs, args...)...)
+}
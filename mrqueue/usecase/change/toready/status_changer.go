@@ -5,6 +5,11 @@ import (
 	"time"
 
 	"github.com/mondegor/go-core/errors"
+	"github.com/mondegor/go-core/mrstorage"
+
+	"github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
 )
 
 const (
@@ -14,7 +19,9 @@ const (
 type (
 	// RetryToReadyChanger - объект изменяющий статусы сломавшихся элементов, находящихся в очереди.
 	RetryToReadyChanger struct {
+		txManager    mrstorage.DBTxManager
 		storage      ItemStorage
+		notifier     observe.Notifier // OPTIONAL
 		errorWrapper errors.Wrapper
 		retryDelayed time.Duration
 	}
@@ -27,11 +34,13 @@ type (
 
 // New - создаёт объект RetryToReadyChanger.
 func New(
+	txManager mrstorage.DBTxManager,
 	storage ItemStorage,
 	opts ...Option,
 ) *RetryToReadyChanger {
 	o := options{
 		changer: &RetryToReadyChanger{
+			txManager:    txManager,
 			storage:      storage,
 			errorWrapper: errors.NewServiceRecordNotFoundWrapper(),
 			retryDelayed: defaultRetryDelayed,
@@ -52,10 +61,35 @@ func (uc *RetryToReadyChanger) Execute(ctx context.Context, limit int) (count in
 		return 0, errors.ErrInternalIncorrectInputData.WithDetails("limit is zero or negative")
 	}
 
-	itemsIDs, err := uc.storage.UpdateStatusRetryToReady(ctx, uc.retryDelayed, limit)
+	var transitions []dto.Transition
+
+	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+		itemsIDs, err := uc.storage.UpdateStatusRetryToReady(ctx, uc.retryDelayed, limit)
+		if err != nil {
+			return uc.errorWrapper.Wrap(err)
+		}
+
+		if count = len(itemsIDs); count == 0 {
+			return nil
+		}
+
+		if uc.notifier != nil {
+			transitions = observe.Transitions(itemsIDs, itemstatus.Retry, itemstatus.Ready, "")
+
+			if err = uc.notifier.NotifyInTx(ctx, transitions); err != nil {
+				return uc.errorWrapper.Wrap(err)
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return 0, uc.errorWrapper.Wrap(err)
+		return 0, err
+	}
+
+	if len(transitions) > 0 {
+		uc.notifier.NotifyAfterCommit(ctx, transitions)
 	}
 
-	return len(itemsIDs), nil
+	return count, nil
 }
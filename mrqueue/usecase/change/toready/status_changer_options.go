@@ -2,6 +2,8 @@ package toready
 
 import (
 	"time"
+
+	"github.com/mondegor/go-components/mrqueue/observe"
 )
 
 type (
@@ -19,3 +21,10 @@ func WithRetryDelayed(value time.Duration) Option {
 		o.changer.retryDelayed = value
 	}
 }
+
+// WithTransitionNotifier - устанавливает опцию notifier (оповещение наблюдателей о переходах элементов) для RetryToReadyChanger.
+func WithTransitionNotifier(value observe.Notifier) Option {
+	return func(o *options) {
+		o.changer.notifier = value
+	}
+}
@@ -0,0 +1,108 @@
+package toready_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrqueue"
+	"github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
+	"github.com/mondegor/go-components/mrqueue/usecase/change/toready"
+)
+
+type (
+	fakeTxManager struct {
+		rolledBack bool
+	}
+
+	fakeStorage struct {
+		itemsIDs []uint64
+	}
+)
+
+func (m *fakeTxManager) Do(ctx context.Context, job func(ctx context.Context) error) error {
+	err := job(ctx)
+	m.rolledBack = err != nil
+
+	return err
+}
+
+func (s *fakeStorage) UpdateStatusRetryToReady(_ context.Context, _ time.Duration, _ int) ([]uint64, error) {
+	return s.itemsIDs, nil
+}
+
+func TestRetryToReadyChanger_Execute_Transitions(t *testing.T) {
+	t.Parallel()
+
+	var inTx []dto.Transition
+
+	afterCommit := make(chan []dto.Transition, 1)
+
+	notifier := observe.New(
+		observe.WithInTxObservers(
+			mrqueue.TransitionObserverFunc(func(_ context.Context, transitions []dto.Transition) error {
+				inTx = transitions
+
+				return nil
+			}),
+		),
+		observe.WithAfterCommitObservers(
+			mrqueue.TransitionObserverFunc(func(_ context.Context, transitions []dto.Transition) error {
+				afterCommit <- transitions
+
+				return nil
+			}),
+		),
+	)
+
+	changer := toready.New(&fakeTxManager{}, &fakeStorage{itemsIDs: []uint64{3, 4}}, toready.WithTransitionNotifier(notifier))
+
+	count, err := changer.Execute(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	expected := observe.Transitions([]uint64{3, 4}, itemstatus.Retry, itemstatus.Ready, "")
+	assert.Equal(t, expected, inTx)
+
+	require.NoError(t, notifier.Shutdown(context.Background()))
+	assert.Equal(t, expected, <-afterCommit)
+}
+
+func TestRetryToReadyChanger_Execute_ObserverErrorRollsBack(t *testing.T) {
+	t.Parallel()
+
+	observerErr := errors.New("observer failed")
+	afterCommitCalled := false
+
+	notifier := observe.New(
+		observe.WithInTxObservers(
+			mrqueue.TransitionObserverFunc(func(_ context.Context, _ []dto.Transition) error {
+				return observerErr
+			}),
+		),
+		observe.WithAfterCommitObservers(
+			mrqueue.TransitionObserverFunc(func(_ context.Context, _ []dto.Transition) error {
+				afterCommitCalled = true
+
+				return nil
+			}),
+		),
+	)
+
+	txManager := &fakeTxManager{}
+	changer := toready.New(txManager, &fakeStorage{itemsIDs: []uint64{3}}, toready.WithTransitionNotifier(notifier))
+
+	count, err := changer.Execute(context.Background(), 10)
+	require.Error(t, err)
+	assert.Zero(t, count)
+	assert.True(t, txManager.rolledBack)
+
+	require.NoError(t, notifier.Shutdown(context.Background()))
+	assert.False(t, afterCommitCalled)
+}
@@ -7,7 +7,12 @@ import (
 	"github.com/mondegor/go-core/errors"
 	"github.com/mondegor/go-core/mrstorage"
 
+	"github.com/mondegor/go-components/mrqueue"
+	"github.com/mondegor/go-components/mrqueue/dto"
 	"github.com/mondegor/go-components/mrqueue/entity"
+	"github.com/mondegor/go-components/mrqueue/enum/errorkind"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
 )
 
 const (
@@ -21,13 +26,15 @@ type (
 		txManager      mrstorage.DBTxManager
 		storage        ItemStorage
 		storageCrashed crashedItemStorage // OPTIONAL
+		notifier       observe.Notifier   // OPTIONAL
 		errorWrapper   errors.Wrapper
 		retryTimeout   time.Duration
+		workerInstance string
 	}
 
 	// ItemStorage - для перевода списка записей из статуса PROCESSING в статус RETRY, которые находятся там долгое время.
 	ItemStorage interface {
-		UpdateStatusProcessingToRetryByTimeout(ctx context.Context, timeout time.Duration, limit int) (rowIDs []uint64, err error)
+		UpdateStatusProcessingToRetryByTimeout(ctx context.Context, timeout time.Duration, limit int) (rows []dto.ItemAttempt, err error)
 	}
 
 	crashedItemStorage interface {
@@ -43,10 +50,11 @@ func New(
 ) *ProcessingToRetryChanger {
 	o := options{
 		changer: &ProcessingToRetryChanger{
-			txManager:    txManager,
-			storage:      storage,
-			errorWrapper: errors.NewServiceRecordNotFoundWrapper(),
-			retryTimeout: defaultRetryTimeout,
+			txManager:      txManager,
+			storage:        storage,
+			errorWrapper:   errors.NewServiceRecordNotFoundWrapper(),
+			retryTimeout:   defaultRetryTimeout,
+			workerInstance: mrqueue.DefaultWorkerInstance(),
 		},
 	}
 
@@ -54,6 +62,8 @@ func New(
 		opt(&o)
 	}
 
+	o.changer.workerInstance = mrqueue.TruncateWorkerInstance(o.changer.workerInstance)
+
 	return o.changer
 }
 
@@ -64,21 +74,29 @@ func (uc *ProcessingToRetryChanger) Execute(ctx context.Context, limit int) (cou
 		return 0, errors.ErrInternalIncorrectInputData.WithDetails("limit is zero or negative")
 	}
 
+	var transitions []dto.Transition
+
 	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
-		itemsIDs, err := uc.storage.UpdateStatusProcessingToRetryByTimeout(ctx, uc.retryTimeout, limit)
+		rows, err := uc.storage.UpdateStatusProcessingToRetryByTimeout(ctx, uc.retryTimeout, limit)
 		if err != nil {
 			return uc.errorWrapper.Wrap(err)
 		}
 
-		if count = len(itemsIDs); count == 0 {
+		if count = len(rows); count == 0 {
 			return nil
 		}
 
 		if uc.storageCrashed != nil {
 			items := make([]entity.CrashedItem, count)
 
-			for i := range itemsIDs {
-				items[i].Cause = causeProcessingToRetryByTimeout
+			for i, row := range rows {
+				items[i] = entity.CrashedItem{
+					ID:             row.ID,
+					Attempt:        row.Attempt,
+					ErrorKind:      errorkind.Timeout,
+					WorkerInstance: uc.workerInstance,
+					Cause:          causeProcessingToRetryByTimeout,
+				}
 			}
 
 			if err = uc.storageCrashed.Insert(ctx, items); err != nil {
@@ -86,11 +104,29 @@ func (uc *ProcessingToRetryChanger) Execute(ctx context.Context, limit int) (cou
 			}
 		}
 
+		if uc.notifier != nil {
+			itemsIDs := make([]uint64, count)
+
+			for i, row := range rows {
+				itemsIDs[i] = row.ID
+			}
+
+			transitions = observe.Transitions(itemsIDs, itemstatus.Processing, itemstatus.Retry, causeProcessingToRetryByTimeout)
+
+			if err = uc.notifier.NotifyInTx(ctx, transitions); err != nil {
+				return uc.errorWrapper.Wrap(err)
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
 		return 0, err
 	}
 
+	if len(transitions) > 0 {
+		uc.notifier.NotifyAfterCommit(ctx, transitions)
+	}
+
 	return count, nil
 }
@@ -1,6 +1,10 @@
 package toretry
 
-import "time"
+import (
+	"time"
+
+	"github.com/mondegor/go-components/mrqueue/observe"
+)
 
 type (
 	// Option - настройка объекта ProcessingToRetryChanger.
@@ -24,3 +28,17 @@ func WithStorageCrashed(value crashedItemStorage) Option {
 		o.changer.storageCrashed = value
 	}
 }
+
+// WithWorkerInstance - устанавливает опцию workerInstance для ProcessingToRetryChanger.
+func WithWorkerInstance(value string) Option {
+	return func(o *options) {
+		o.changer.workerInstance = value
+	}
+}
+
+// WithTransitionNotifier - устанавливает опцию notifier (оповещение наблюдателей о переходах элементов) для ProcessingToRetryChanger.
+func WithTransitionNotifier(value observe.Notifier) Option {
+	return func(o *options) {
+		o.changer.notifier = value
+	}
+}
@@ -5,6 +5,14 @@ import (
 
 	"github.com/mondegor/go-core/errors"
 	"github.com/mondegor/go-core/mrstorage"
+
+	"github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
+)
+
+const (
+	causeRetryAttemptsExhausted = "retry attempts are exhausted"
 )
 
 type (
@@ -13,6 +21,7 @@ type (
 		txManager      mrstorage.DBTxManager
 		storage        ItemStorage
 		afterCleanFunc func(ctx context.Context, itemsIDs []uint64) error
+		notifier       observe.Notifier // OPTIONAL
 		errorWrapper   errors.Wrapper
 	}
 
@@ -57,6 +66,8 @@ func (uc *QueueCleaner) Execute(ctx context.Context, limit int) (count int, err
 		return 0, errors.ErrInternalIncorrectInputData.WithDetails("limit is zero or negative")
 	}
 
+	var transitions []dto.Transition
+
 	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
 		itemsIDs, err := uc.storage.DeleteRetryWithoutAttempts(ctx, limit)
 		if err != nil {
@@ -71,11 +82,23 @@ func (uc *QueueCleaner) Execute(ctx context.Context, limit int) (count int, err
 			return uc.errorWrapper.Wrap(err)
 		}
 
+		if uc.notifier != nil {
+			transitions = observe.Transitions(itemsIDs, itemstatus.Retry, itemstatus.Removed, causeRetryAttemptsExhausted)
+
+			if err = uc.notifier.NotifyInTx(ctx, transitions); err != nil {
+				return uc.errorWrapper.Wrap(err)
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
 		return 0, err
 	}
 
+	if len(transitions) > 0 {
+		uc.notifier.NotifyAfterCommit(ctx, transitions)
+	}
+
 	return count, nil
 }
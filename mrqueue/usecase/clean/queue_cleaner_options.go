@@ -1,5 +1,7 @@
 package clean
 
+import "github.com/mondegor/go-components/mrqueue/observe"
+
 import "context"
 
 type (
@@ -17,3 +19,10 @@ func WithAfterClean(value func(ctx context.Context, itemsIDs []uint64) error) Op
 		o.cleaner.afterCleanFunc = value
 	}
 }
+
+// WithTransitionNotifier - устанавливает опцию notifier (оповещение наблюдателей о переходах элементов) для QueueCleaner.
+func WithTransitionNotifier(value observe.Notifier) Option {
+	return func(o *options) {
+		o.cleaner.notifier = value
+	}
+}
@@ -3,6 +3,8 @@ package clean
 import (
 	"context"
 	"time"
+
+	"github.com/mondegor/go-components/mrqueue/observe"
 )
 
 type (
@@ -27,3 +29,10 @@ func WithAfterClean(value func(ctx context.Context, itemsIDs []uint64) error) Op
 		o.cleaner.afterCleanFunc = value
 	}
 }
+
+// WithTransitionNotifier - устанавливает опцию notifier (оповещение наблюдателей о переходах элементов) для CompletedItemsCleaner.
+func WithTransitionNotifier(value observe.Notifier) Option {
+	return func(o *options) {
+		o.cleaner.notifier = value
+	}
+}
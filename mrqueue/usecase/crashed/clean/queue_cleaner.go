@@ -6,10 +6,15 @@ import (
 
 	"github.com/mondegor/go-core/errors"
 	"github.com/mondegor/go-core/mrstorage"
+
+	"github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
 )
 
 const (
 	defaultCrashedExpiry = 72 * time.Hour
+	causeCrashedExpired  = "error history of item has expired"
 )
 
 type (
@@ -18,6 +23,7 @@ type (
 		txManager      mrstorage.DBTxManager
 		storage        ItemStorage
 		afterCleanFunc func(ctx context.Context, itemsIDs []uint64) error
+		notifier       observe.Notifier // OPTIONAL
 		errorWrapper   errors.Wrapper
 		crashedExpiry  time.Duration
 	}
@@ -63,6 +69,8 @@ func (uc *CrashedItemsCleaner) Execute(ctx context.Context, limit int) (count in
 		return 0, errors.ErrInternalIncorrectInputData.WithDetails("limit is zero or negative")
 	}
 
+	var transitions []dto.Transition
+
 	err = uc.txManager.Do(ctx, func(ctx context.Context) error {
 		itemsIDs, err := uc.storage.Delete(ctx, uc.crashedExpiry, limit)
 		if err != nil {
@@ -77,11 +85,23 @@ func (uc *CrashedItemsCleaner) Execute(ctx context.Context, limit int) (count in
 			return uc.errorWrapper.Wrap(err)
 		}
 
+		if uc.notifier != nil {
+			transitions = observe.Transitions(itemsIDs, itemstatus.Crashed, itemstatus.Removed, causeCrashedExpired)
+
+			if err = uc.notifier.NotifyInTx(ctx, transitions); err != nil {
+				return uc.errorWrapper.Wrap(err)
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
 		return 0, err
 	}
 
+	if len(transitions) > 0 {
+		uc.notifier.NotifyAfterCommit(ctx, transitions)
+	}
+
 	return count, nil
 }
@@ -0,0 +1,46 @@
+package history
+
+import (
+	"context"
+
+	"github.com/mondegor/go-core/errors"
+
+	"github.com/mondegor/go-components/mrqueue/entity"
+)
+
+type (
+	// AttemptHistory - объект для получения истории неудачных попыток обработки элемента очереди
+	// (используется при разборе обращений в поддержку).
+	AttemptHistory struct {
+		storage      ItemStorage
+		errorWrapper errors.Wrapper
+	}
+
+	// ItemStorage - для получения записей журнала ошибок указанного элемента.
+	ItemStorage interface {
+		FetchByItemID(ctx context.Context, itemID uint64) ([]entity.CrashedItem, error)
+	}
+)
+
+// New - создаёт объект AttemptHistory.
+func New(storage ItemStorage) *AttemptHistory {
+	return &AttemptHistory{
+		storage:      storage,
+		errorWrapper: errors.NewServiceOperationFailedWrapper(),
+	}
+}
+
+// GetList - возвращает историю неудачных попыток обработки указанного элемента в порядке их возникновения.
+// Если элемент ни разу не завершался ошибкой (или его журнал уже очищен), то возвращается пустой список.
+func (uc *AttemptHistory) GetList(ctx context.Context, itemID uint64) ([]entity.CrashedItem, error) {
+	if itemID == 0 {
+		return nil, errors.ErrInternalIncorrectInputData.WithDetails("itemID is zero")
+	}
+
+	items, err := uc.storage.FetchByItemID(ctx, itemID)
+	if err != nil {
+		return nil, uc.errorWrapper.Wrap(err, "itemId", itemID)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,60 @@
+package history_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrqueue/entity"
+	"github.com/mondegor/go-components/mrqueue/enum/errorkind"
+	"github.com/mondegor/go-components/mrqueue/usecase/crashed/history"
+)
+
+type fakeStorage struct {
+	rows map[uint64][]entity.CrashedItem
+	err  error
+}
+
+func (s *fakeStorage) FetchByItemID(_ context.Context, itemID uint64) ([]entity.CrashedItem, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.rows[itemID], nil
+}
+
+func TestAttemptHistory_GetList(t *testing.T) {
+	t.Parallel()
+
+	rows := []entity.CrashedItem{
+		{ID: 5, Attempt: 1, ErrorKind: errorkind.System, Cause: "timeout"},
+		{ID: 5, Attempt: 2, ErrorKind: errorkind.User, ErrorCode: "Rejected", Cause: "rejected"},
+	}
+
+	uc := history.New(&fakeStorage{rows: map[uint64][]entity.CrashedItem{5: rows}})
+
+	got, err := uc.GetList(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, rows, got)
+
+	got, err = uc.GetList(context.Background(), 6)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestAttemptHistory_GetList_Errors(t *testing.T) {
+	t.Parallel()
+
+	storageErr := errors.New("connection lost")
+	uc := history.New(&fakeStorage{err: storageErr})
+
+	_, err := uc.GetList(context.Background(), 0)
+	require.Error(t, err)
+
+	_, err = uc.GetList(context.Background(), 5)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, storageErr)
+}
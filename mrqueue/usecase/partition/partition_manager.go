@@ -0,0 +1,236 @@
+package partition
+
+import (
+	"context"
+	"time"
+
+	"github.com/mondegor/go-core/errors"
+	"github.com/mondegor/go-core/mrstorage"
+
+	"github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/entity"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
+)
+
+const (
+	defaultPeriod    = 24 * time.Hour
+	defaultPremake   = 3
+	defaultRetention = 72 * time.Hour
+	defaultBatchSize = 1000
+
+	partitionNameSuffix = "_p"
+	layoutDailyName     = "20060102"
+	layoutHourlyName    = "2006010215"
+
+	causePartitionExpired = "partition of item has expired"
+)
+
+type (
+	// PartitionManager - объект управляющий секциями таблицы, секционированной по диапазону времени:
+	// заранее создаёт секции на будущие периоды и удаляет секции, срок хранения которых истёк.
+	// Используется вместо пакетного удаления записей для таблиц с большим потоком данных.
+	PartitionManager struct {
+		txManager      mrstorage.DBTxManager
+		storage        ItemStorage
+		afterCleanFunc func(ctx context.Context, itemsIDs []uint64) error
+		notifier       observe.Notifier // OPTIONAL
+		notifyFrom     itemstatus.Enum
+		errorWrapper   errors.Wrapper
+		period         time.Duration
+		premake        int
+		retention      time.Duration
+		batchSize      int
+	}
+
+	// ItemStorage - для управления секциями таблицы.
+	ItemStorage interface {
+		TableName() string
+		FetchPartitions(ctx context.Context) ([]entity.Partition, error)
+		Create(ctx context.Context, row entity.Partition) error
+		FetchItemsIDs(ctx context.Context, partitionName string, afterID uint64, limit int) (rowsIDs []uint64, err error)
+		Drop(ctx context.Context, partitionName string) error
+	}
+)
+
+// New - создаёт объект PartitionManager.
+func New(
+	txManager mrstorage.DBTxManager,
+	storage ItemStorage,
+	opts ...Option,
+) *PartitionManager {
+	o := options{
+		manager: &PartitionManager{
+			txManager:    txManager,
+			storage:      storage,
+			errorWrapper: errors.NewServiceOperationFailedWrapper(),
+			period:       defaultPeriod,
+			premake:      defaultPremake,
+			retention:    defaultRetention,
+			batchSize:    defaultBatchSize,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.manager.period < time.Hour {
+		o.manager.period = time.Hour
+	}
+
+	if o.manager.premake < 1 {
+		o.manager.premake = 1
+	}
+
+	if o.manager.batchSize < 1 {
+		o.manager.batchSize = defaultBatchSize
+	}
+
+	if o.manager.afterCleanFunc == nil {
+		o.manager.afterCleanFunc = func(_ context.Context, _ []uint64) error {
+			return nil
+		}
+	}
+
+	return o.manager
+}
+
+// Execute - создаёт недостающие секции для текущего и premake будущих периодов,
+// а затем удаляет секции, верхняя граница которых старше срока хранения retention.
+// Перед удалением секции для всех её элементов вызывается afterCleanFunc.
+// Ошибка создания одной из секций не мешает созданию остальных секций и удалению устаревших,
+// она возвращается после их обработки.
+func (uc *PartitionManager) Execute(ctx context.Context) error {
+	partitions, err := uc.storage.FetchPartitions(ctx)
+	if err != nil {
+		return uc.errorWrapper.Wrap(err, "table", uc.storage.TableName())
+	}
+
+	now := time.Now().UTC()
+
+	createErr := uc.createPartitions(ctx, now, partitions)
+
+	if err = uc.dropExpiredPartitions(ctx, now, partitions); err != nil {
+		return err
+	}
+
+	return createErr
+}
+
+// createPartitions - создаёт недостающие секции и возвращает первую из возникших ошибок.
+// Создание секции завершится ошибкой, если в секции DEFAULT уже есть записи из её диапазона
+// (например, когда задача долго не запускалась), такие записи остаются в секции DEFAULT,
+// а секции следующих периодов создаются как обычно.
+func (uc *PartitionManager) createPartitions(ctx context.Context, now time.Time, existing []entity.Partition) error {
+	var firstErr error
+
+	from := now.Truncate(uc.period)
+
+	for i := 0; i <= uc.premake; i++ {
+		row := entity.Partition{
+			Name: uc.partitionName(from),
+			From: from,
+			To:   from.Add(uc.period),
+		}
+
+		from = row.To
+
+		// если период секционирования был изменён, то пересекающиеся
+		// диапазоны пропускаются, иначе БД отклонит создание секции
+		if uc.isOverlapped(row, existing) {
+			continue
+		}
+
+		if err := uc.storage.Create(ctx, row); err != nil && firstErr == nil {
+			firstErr = uc.errorWrapper.Wrap(err, "partition", row.Name)
+		}
+	}
+
+	return firstErr
+}
+
+func (uc *PartitionManager) dropExpiredPartitions(ctx context.Context, now time.Time, existing []entity.Partition) error {
+	expiredBefore := now.Add(-uc.retention)
+
+	for _, row := range existing {
+		if row.To.After(expiredBefore) {
+			continue
+		}
+
+		if err := uc.cleanPartitionItems(ctx, row.Name); err != nil {
+			return err
+		}
+
+		if err := uc.storage.Drop(ctx, row.Name); err != nil {
+			return uc.errorWrapper.Wrap(err, "partition", row.Name)
+		}
+	}
+
+	return nil
+}
+
+// cleanPartitionItems - передаёт пачками ID элементов секции в afterCleanFunc и наблюдателям.
+// Повторный вызов для одной и той же секции безопасен, поэтому прерывание
+// процесса до удаления секции не приводит к потере связанных данных.
+func (uc *PartitionManager) cleanPartitionItems(ctx context.Context, partitionName string) error {
+	var afterID uint64
+
+	for {
+		itemsIDs, err := uc.storage.FetchItemsIDs(ctx, partitionName, afterID, uc.batchSize)
+		if err != nil {
+			return uc.errorWrapper.Wrap(err, "partition", partitionName)
+		}
+
+		if len(itemsIDs) == 0 {
+			return nil
+		}
+
+		var transitions []dto.Transition
+
+		err = uc.txManager.Do(ctx, func(ctx context.Context) error {
+			if err := uc.afterCleanFunc(ctx, itemsIDs); err != nil {
+				return err
+			}
+
+			if uc.notifier == nil {
+				return nil
+			}
+
+			transitions = observe.Transitions(itemsIDs, uc.notifyFrom, itemstatus.Removed, causePartitionExpired)
+
+			return uc.notifier.NotifyInTx(ctx, transitions)
+		})
+		if err != nil {
+			return uc.errorWrapper.Wrap(err, "partition", partitionName)
+		}
+
+		if len(transitions) > 0 {
+			uc.notifier.NotifyAfterCommit(ctx, transitions)
+		}
+
+		if len(itemsIDs) < uc.batchSize {
+			return nil
+		}
+
+		afterID = itemsIDs[len(itemsIDs)-1]
+	}
+}
+
+func (uc *PartitionManager) partitionName(from time.Time) string {
+	if uc.period%(24*time.Hour) == 0 {
+		return uc.storage.TableName() + partitionNameSuffix + from.Format(layoutDailyName)
+	}
+
+	return uc.storage.TableName() + partitionNameSuffix + from.Format(layoutHourlyName)
+}
+
+func (uc *PartitionManager) isOverlapped(row entity.Partition, existing []entity.Partition) bool {
+	for _, item := range existing {
+		if row.From.Before(item.To) && item.From.Before(row.To) {
+			return true
+		}
+	}
+
+	return false
+}
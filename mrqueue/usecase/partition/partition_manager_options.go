@@ -0,0 +1,63 @@
+package partition
+
+import (
+	"context"
+	"time"
+
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
+)
+
+type (
+	// Option - настройка объекта PartitionManager.
+	Option func(o *options)
+
+	options struct {
+		manager *PartitionManager
+	}
+)
+
+// WithPeriod - устанавливает опцию period (диапазон одной секции, но не менее часа) для PartitionManager.
+func WithPeriod(value time.Duration) Option {
+	return func(o *options) {
+		o.manager.period = value
+	}
+}
+
+// WithPremake - устанавливает опцию premake (кол-во заранее создаваемых будущих секций) для PartitionManager.
+func WithPremake(value int) Option {
+	return func(o *options) {
+		o.manager.premake = value
+	}
+}
+
+// WithRetention - устанавливает опцию retention (срок хранения секции) для PartitionManager.
+func WithRetention(value time.Duration) Option {
+	return func(o *options) {
+		o.manager.retention = value
+	}
+}
+
+// WithBatchSize - устанавливает опцию batchSize (размер пачки ID, передаваемых в afterCleanFunc) для PartitionManager.
+func WithBatchSize(value int) Option {
+	return func(o *options) {
+		o.manager.batchSize = value
+	}
+}
+
+// WithAfterClean - устанавливает опцию afterCleanFunc для PartitionManager.
+func WithAfterClean(value func(ctx context.Context, itemsIDs []uint64) error) Option {
+	return func(o *options) {
+		o.manager.afterCleanFunc = value
+	}
+}
+
+// WithTransitionNotifier - устанавливает опцию notifier (оповещение наблюдателей об удалении элементов) для PartitionManager.
+// Параметр from - статус, в котором находились элементы таблицы (itemstatus.Completed или itemstatus.Crashed).
+// При повторной обработке прерванного удаления секции наблюдатели могут быть оповещены повторно.
+func WithTransitionNotifier(value observe.Notifier, from itemstatus.Enum) Option {
+	return func(o *options) {
+		o.manager.notifier = value
+		o.manager.notifyFrom = from
+	}
+}
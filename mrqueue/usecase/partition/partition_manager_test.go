@@ -0,0 +1,231 @@
+package partition_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrqueue"
+	"github.com/mondegor/go-components/mrqueue/dto"
+	"github.com/mondegor/go-components/mrqueue/entity"
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
+	"github.com/mondegor/go-components/mrqueue/usecase/partition"
+)
+
+const testTableName = "mrqueue_completed"
+
+type (
+	fakeTxManager struct{}
+
+	fakeStorage struct {
+		partitions []entity.Partition
+		items      map[string][]uint64
+		createErr  map[string]error
+		created    []entity.Partition
+		dropped    []string
+	}
+)
+
+func (fakeTxManager) Do(ctx context.Context, job func(ctx context.Context) error) error {
+	return job(ctx)
+}
+
+func (s *fakeStorage) TableName() string {
+	return testTableName
+}
+
+func (s *fakeStorage) FetchPartitions(_ context.Context) ([]entity.Partition, error) {
+	return s.partitions, nil
+}
+
+func (s *fakeStorage) Create(_ context.Context, row entity.Partition) error {
+	if err := s.createErr[row.Name]; err != nil {
+		return err
+	}
+
+	s.created = append(s.created, row)
+
+	return nil
+}
+
+func (s *fakeStorage) FetchItemsIDs(_ context.Context, partitionName string, afterID uint64, limit int) ([]uint64, error) {
+	var rowsIDs []uint64
+
+	for _, itemID := range s.items[partitionName] {
+		if itemID > afterID && len(rowsIDs) < limit {
+			rowsIDs = append(rowsIDs, itemID)
+		}
+	}
+
+	return rowsIDs, nil
+}
+
+func (s *fakeStorage) Drop(_ context.Context, partitionName string) error {
+	s.dropped = append(s.dropped, partitionName)
+
+	return nil
+}
+
+func currentHour() time.Time {
+	return time.Now().UTC().Truncate(time.Hour)
+}
+
+func TestPartitionManager_Execute_CreatesPartitions(t *testing.T) {
+	t.Parallel()
+
+	from := currentHour()
+	storage := &fakeStorage{
+		// секция текущего периода уже существует
+		partitions: []entity.Partition{
+			{Name: "existing", From: from, To: from.Add(time.Hour)},
+		},
+	}
+
+	manager := partition.New(
+		fakeTxManager{},
+		storage,
+		partition.WithPeriod(time.Hour),
+		partition.WithPremake(2),
+		partition.WithRetention(24*time.Hour),
+	)
+
+	require.NoError(t, manager.Execute(context.Background()))
+	require.Len(t, storage.created, 2)
+	assert.Equal(t, from.Add(time.Hour), storage.created[0].From)
+	assert.Equal(t, from.Add(2*time.Hour), storage.created[0].To)
+	assert.Equal(t, testTableName+"_p"+from.Add(time.Hour).Format("2006010215"), storage.created[0].Name)
+	assert.Equal(t, from.Add(3*time.Hour), storage.created[1].To)
+	assert.Empty(t, storage.dropped)
+}
+
+func TestPartitionManager_Execute_DailyPartitionName(t *testing.T) {
+	t.Parallel()
+
+	storage := &fakeStorage{}
+
+	manager := partition.New(fakeTxManager{}, storage, partition.WithPeriod(24*time.Hour), partition.WithPremake(1))
+
+	require.NoError(t, manager.Execute(context.Background()))
+	require.Len(t, storage.created, 2)
+
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	assert.Equal(t, testTableName+"_p"+from.Format("20060102"), storage.created[0].Name)
+}
+
+func TestPartitionManager_Execute_CreateErrorDoesNotStopProcessing(t *testing.T) {
+	t.Parallel()
+
+	from := currentHour()
+	expired := entity.Partition{Name: "expired", From: from.Add(-50 * time.Hour), To: from.Add(-49 * time.Hour)}
+	createErr := errors.New("range of DEFAULT partition overlaps")
+
+	storage := &fakeStorage{
+		partitions: []entity.Partition{expired},
+		createErr: map[string]error{
+			testTableName + "_p" + from.Format("2006010215"): createErr,
+		},
+	}
+
+	manager := partition.New(
+		fakeTxManager{},
+		storage,
+		partition.WithPeriod(time.Hour),
+		partition.WithPremake(2),
+		partition.WithRetention(24*time.Hour),
+	)
+
+	err := manager.Execute(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, createErr)
+	assert.Len(t, storage.created, 2)
+	assert.Equal(t, []string{"expired"}, storage.dropped)
+}
+
+func TestPartitionManager_Execute_DropsExpiredPartitions(t *testing.T) {
+	t.Parallel()
+
+	from := currentHour()
+	storage := &fakeStorage{
+		partitions: []entity.Partition{
+			{Name: "expired", From: from.Add(-50 * time.Hour), To: from.Add(-49 * time.Hour)},
+			{Name: "actual", From: from.Add(-time.Hour), To: from},
+		},
+		items: map[string][]uint64{
+			"expired": {1, 2, 3, 4, 5},
+		},
+	}
+
+	var (
+		cleaned     [][]uint64
+		transitions []dto.Transition
+	)
+
+	notifier := observe.New(
+		observe.WithInTxObservers(
+			mrqueue.TransitionObserverFunc(func(_ context.Context, value []dto.Transition) error {
+				transitions = append(transitions, value...)
+
+				return nil
+			}),
+		),
+	)
+
+	manager := partition.New(
+		fakeTxManager{},
+		storage,
+		partition.WithPeriod(time.Hour),
+		partition.WithRetention(24*time.Hour),
+		partition.WithBatchSize(2),
+		partition.WithAfterClean(func(_ context.Context, itemsIDs []uint64) error {
+			cleaned = append(cleaned, itemsIDs)
+
+			return nil
+		}),
+		partition.WithTransitionNotifier(notifier, itemstatus.Completed),
+	)
+
+	require.NoError(t, manager.Execute(context.Background()))
+	assert.Equal(t, []string{"expired"}, storage.dropped)
+	assert.Equal(t, [][]uint64{{1, 2}, {3, 4}, {5}}, cleaned)
+	require.Len(t, transitions, 5)
+
+	for _, transition := range transitions {
+		assert.Equal(t, itemstatus.Completed, transition.From)
+		assert.Equal(t, itemstatus.Removed, transition.To)
+	}
+}
+
+func TestPartitionManager_Execute_AfterCleanErrorKeepsPartition(t *testing.T) {
+	t.Parallel()
+
+	from := currentHour()
+	cleanErr := errors.New("delete failed")
+	storage := &fakeStorage{
+		partitions: []entity.Partition{
+			{Name: "expired", From: from.Add(-50 * time.Hour), To: from.Add(-49 * time.Hour)},
+		},
+		items: map[string][]uint64{
+			"expired": {1},
+		},
+	}
+
+	manager := partition.New(
+		fakeTxManager{},
+		storage,
+		partition.WithPeriod(time.Hour),
+		partition.WithRetention(24*time.Hour),
+		partition.WithAfterClean(func(_ context.Context, _ []uint64) error {
+			return cleanErr
+		}),
+	)
+
+	err := manager.Execute(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, cleanErr)
+	assert.Empty(t, storage.dropped)
+}
@@ -0,0 +1,38 @@
+package mrqueue
+
+import (
+	"os"
+	"strconv"
+	"unicode/utf8"
+)
+
+// MaxWorkerInstanceLen - максимальная длина идентификатора экземпляра обработчика,
+// который фиксируется в журнале ошибок элементов очереди.
+const MaxWorkerInstanceLen = 128
+
+// DefaultWorkerInstance - возвращает идентификатор текущего экземпляра обработчика
+// в виде hostname:pid, который фиксируется в журнале ошибок элементов очереди.
+func DefaultWorkerInstance() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+
+	return TruncateWorkerInstance(hostname + ":" + strconv.Itoa(os.Getpid()))
+}
+
+// TruncateWorkerInstance - обрезает идентификатор экземпляра обработчика до MaxWorkerInstanceLen байт
+// не разрывая многобайтовые символы.
+func TruncateWorkerInstance(value string) string {
+	if len(value) <= MaxWorkerInstanceLen {
+		return value
+	}
+
+	n := MaxWorkerInstanceLen
+
+	for n > 0 && !utf8.RuneStart(value[n]) {
+		n--
+	}
+
+	return value[:n]
+}
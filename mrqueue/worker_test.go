@@ -0,0 +1,46 @@
+package mrqueue_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mondegor/go-components/mrqueue"
+)
+
+func TestTruncateWorkerInstance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "short value is unchanged",
+			value: "worker-1:100",
+			want:  "worker-1:100",
+		},
+		{
+			name:  "long value is truncated",
+			value: strings.Repeat("w", 200),
+			want:  strings.Repeat("w", 128),
+		},
+		{
+			name:  "multibyte rune is not split",
+			value: strings.Repeat("w", 127) + "ж",
+			want:  strings.Repeat("w", 127),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mrqueue.TruncateWorkerInstance(tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), mrqueue.MaxWorkerInstanceLen)
+		})
+	}
+}
@@ -19,5 +19,41 @@ type (
 		ChangeRetryTimeout   time.Duration               `yaml:"change_retry_timeout"`
 		ChangeRetryDelayed   time.Duration               `yaml:"change_retry_delayed"`
 		CleanQueueBatchSize  uint32                      `yaml:"clean_queue_batch_size"`
+		CompletedExpiry      time.Duration               `yaml:"completed_expiry"`
+		CrashedExpiry        time.Duration               `yaml:"crashed_expiry"`
+		QueuePartitioning    QueuePartitioning           `yaml:"queue_partitioning"`
+		SenderRouting        SenderRouting               `yaml:"sender_routing"`
+	}
+
+	// QueuePartitioning - настройки секционирования таблиц успешно обработанных элементов
+	// и журнала ошибок очереди (если Period не указан, то секционирование не используется).
+	QueuePartitioning struct {
+		ManagePartitions processcfg.SchedulerTask `yaml:"manage_partitions"`
+		Period           time.Duration            `yaml:"period"`
+		Premake          uint16                   `yaml:"premake"`
+	}
+
+	// SenderRouting - правила выбора именованного отправителя по каналу сообщения
+	// отдельно для каждого типа сообщений (если правила не указаны, то используется клиент этого типа).
+	SenderRouting struct {
+		Mail      ChannelRouting `yaml:"mail"`
+		Messenger ChannelRouting `yaml:"messenger"`
+		SMS       ChannelRouting `yaml:"sms"`
+		Webhook   ChannelRouting `yaml:"webhook"`
+		Push      ChannelRouting `yaml:"push"`
+	}
+
+	// ChannelRouting - правила выбора отправителя по каналу сообщения,
+	// используется первое подходящее правило, иначе отправитель DefaultSender.
+	ChannelRouting struct {
+		DefaultSender string        `yaml:"default_sender"`
+		Rules         []RoutingRule `yaml:"rules"`
+	}
+
+	// RoutingRule - правило выбора отправителя: точное значение канала, шаблон (*, ?, [...])
+	// или префикс канала, который оканчивается на ** (например: email/marketing/**).
+	RoutingRule struct {
+		Channel string `yaml:"channel"`
+		Sender  string `yaml:"sender"`
 	}
 )
@@ -32,6 +32,8 @@ func (f NoticeToMessageAdapterFunc) Send(ctx context.Context, notices []noticedt
 				ContentType: notice.Data.Mail.ContentType,
 				From:        notice.Data.Mail.From,
 				To:          notice.Data.Mail.To,
+				Cc:          notice.Data.Mail.Cc,
+				Bcc:         notice.Data.Mail.Bcc,
 				ReplyTo:     notice.Data.Mail.ReplyTo,
 				Subject:     notice.Data.Mail.Subject,
 				Content:     notice.Data.Mail.Content,
@@ -40,9 +42,14 @@ func (f NoticeToMessageAdapterFunc) Send(ctx context.Context, notices []noticedt
 
 		if notice.Data.Messenger != nil {
 			message.Data.Messenger = &dto.DataMessenger{
-				From:    notice.Data.Messenger.From,
-				ChatID:  notice.Data.Messenger.ChatID,
-				Content: notice.Data.Messenger.Content,
+				From:               notice.Data.Messenger.From,
+				ChatID:             notice.Data.Messenger.ChatID,
+				ThreadID:           notice.Data.Messenger.ThreadID,
+				Content:            notice.Data.Messenger.Content,
+				ParseMode:          notice.Data.Messenger.ParseMode,
+				Buttons:            messengerButtons(notice.Data.Messenger.Buttons),
+				DisableLinkPreview: notice.Data.Messenger.DisableLinkPreview,
+				Silent:             notice.Data.Messenger.Silent,
 			}
 		}
 
@@ -59,3 +66,21 @@ func (f NoticeToMessageAdapterFunc) Send(ctx context.Context, notices []noticedt
 
 	return f(ctx, messages...)
 }
+
+func messengerButtons(rows [][]noticedto.MessengerButton) [][]dto.MessengerButton {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	buttons := make([][]dto.MessengerButton, len(rows))
+
+	for i, row := range rows {
+		buttons[i] = make([]dto.MessengerButton, len(row))
+
+		for j, button := range row {
+			buttons[i][j] = dto.MessengerButton{Text: button.Text, URL: button.URL}
+		}
+	}
+
+	return buttons
+}
@@ -0,0 +1,85 @@
+package mrmailer_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mondegor/go-components/mrmailer/dto"
+	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/adapter"
+	"github.com/mondegor/go-components/mrnotifier/notifier/buildnotice"
+	templateentity "github.com/mondegor/go-components/mrnotifier/template/entity"
+	"github.com/mondegor/go-components/wire/mrmailer"
+)
+
+type (
+	// varsRenderer - подставляет значения переменных вида {{name}}.
+	varsRenderer struct{}
+
+	// textMessenger - клиент мессенджера, передающий только текст.
+	textMessenger struct {
+		chatID string
+		text   string
+	}
+)
+
+func (varsRenderer) Render(notice string, data map[string]string) (string, error) {
+	for name, value := range data {
+		notice = strings.ReplaceAll(notice, "{{"+name+"}}", value)
+	}
+
+	return notice, nil
+}
+
+func (m *textMessenger) SendToChat(_ context.Context, chatID, message string) error {
+	m.chatID, m.text = chatID, message
+
+	return nil
+}
+
+// TestNoticeToMessageAdapterFunc_LegacyMessengerTextClient - уведомление, собранное по шаблону
+// в прежнем формате (без режима разметки), отправляется клиентом, передающим только текст.
+func TestNoticeToMessageAdapterFunc_LegacyMessengerTextClient(t *testing.T) {
+	t.Parallel()
+
+	notices, err := buildnotice.NewBuildManager(varsRenderer{}).Build(
+		map[string]string{"orderId": "1001"},
+		templateentity.TemplateData{
+			Messenger: &templateentity.DataMessenger{
+				ChatID:  "100500",
+				Subject: "New_order",
+				Content: "Order {{orderId}} is created",
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	var messages []dto.Message
+
+	send := mrmailer.NoticeToMessageAdapterFunc(func(_ context.Context, message ...dto.Message) error {
+		messages = append(messages, message...)
+
+		return nil
+	})
+
+	require.NoError(t, send.Send(context.Background(), notices))
+	require.Len(t, messages, 1)
+
+	client := &textMessenger{}
+
+	err = adapter.NewMessengerSender(client).Send(
+		context.Background(),
+		entity.Message{
+			ID:      1,
+			Channel: messages[0].Channel,
+			Data:    messages[0].Data,
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "100500", client.chatID)
+	assert.Equal(t, "*New\\_order*\nOrder 1001 is created", client.text)
+}
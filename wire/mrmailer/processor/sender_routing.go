@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"github.com/mondegor/go-components/mrmailer"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/provider"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/routing"
+	"github.com/mondegor/go-components/wire/mrmailer/config"
+)
+
+// InitSenderRoutingOpts - создаёт опции провайдера отправки сообщений, в которых клиентами
+// каждого типа сообщений являются маршрутизаторы routing.Router, построенные по настройкам cfg.
+// В senders указываются именованные отправители, на которые ссылаются правила маршрутизации.
+// Результат передаётся в WithSenderProviderOpts, для типов без правил маршрутизации опции не создаются.
+func InitSenderRoutingOpts(cfg config.SenderRouting, senders map[string]mrmailer.MessageSender) ([]provider.Option, error) {
+	var opts []provider.Option
+
+	items := []struct {
+		routing   config.ChannelRouting
+		newOption func(value mrmailer.MessageSender) provider.Option
+	}{
+		{routing: cfg.Mail, newOption: provider.WithClientMail},
+		{routing: cfg.Messenger, newOption: provider.WithClientMessenger},
+		{routing: cfg.SMS, newOption: provider.WithClientSMS},
+		{routing: cfg.Webhook, newOption: provider.WithClientWebhook},
+		{routing: cfg.Push, newOption: provider.WithClientPush},
+	}
+
+	for _, item := range items {
+		if item.routing.DefaultSender == "" && len(item.routing.Rules) == 0 {
+			continue
+		}
+
+		rules := make([]routing.Rule, len(item.routing.Rules))
+
+		for i, rule := range item.routing.Rules {
+			rules[i] = routing.Rule{
+				Pattern: rule.Channel,
+				Sender:  rule.Sender,
+			}
+		}
+
+		router, err := routing.New(senders, rules, item.routing.DefaultSender)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, item.newOption(router))
+	}
+
+	return opts, nil
+}
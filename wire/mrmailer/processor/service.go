@@ -56,7 +56,7 @@ func InitService(
 		opt(&o)
 	}
 
-	storageMessage := repository.NewMessagePostgres(client, messageTable)
+	storageMessage := repository.NewMessagePostgres(client, messageTable, repository.WithMessageSealer(o.sealer))
 
 	storageQueue := queuerepository.NewQueuePostgres(client, queueTable)
 	storageQueueCompleted := queuerepository.NewCompletedPostgres(
@@ -74,21 +74,30 @@ func InitService(
 		},
 	)
 
+	consumerOpts := []queueconsume.Option{
+		queueconsume.WithStorageCompleted(storageQueueCompleted),
+		queueconsume.WithStorageCrashed(storageQueueCrashed),
+	}
+
+	if o.transitionNotifier != nil {
+		consumerOpts = append(consumerOpts, queueconsume.WithTransitionNotifier(o.transitionNotifier))
+	}
+
 	messageConsumer := queueconsume.NewMessageConsumer[entity.Message](
 		client,
 		storageMessage,
 		queueconsume.NewQueueConsumer(
 			client,
 			storageQueue,
-			queueconsume.WithStorageCompleted(storageQueueCompleted),
-			queueconsume.WithStorageCrashed(storageQueueCrashed),
+			consumerOpts...,
 		),
 	)
 
 	return consume.NewMessageProcessor[entity.Message](
 		messageConsumer,
 		handler.NewSendMessage(
-			provider.New(o.providerOpts...),
+			provider.New(o.captureProviderOpts()...),
+			o.handlerOpts...,
 		),
 		errorHandler,
 		logger,
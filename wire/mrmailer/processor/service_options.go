@@ -3,8 +3,14 @@ package processor
 import (
 	"github.com/mondegor/go-core/mrprocess/consume"
 
+	"github.com/mondegor/go-components/mrmailer"
 	"github.com/mondegor/go-components/mrmailer/entity"
+	"github.com/mondegor/go-components/mrmailer/infra/handler"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/capture"
 	"github.com/mondegor/go-components/mrmailer/sendmessage/provider"
+	"github.com/mondegor/go-components/mrmailer/sendmessage/smtppool"
+	"github.com/mondegor/go-components/mrqueue/observe"
+	"github.com/mondegor/go-components/mrqueue/sealdata"
 )
 
 type (
@@ -12,8 +18,12 @@ type (
 	Option func(o *options)
 
 	options struct {
-		processorOpts []consume.Option[entity.Message]
-		providerOpts  []provider.Option
+		processorOpts      []consume.Option[entity.Message]
+		handlerOpts        []handler.Option
+		providerOpts       []provider.Option
+		transitionNotifier *observe.TransitionNotifier
+		sealer             *sealdata.Sealer
+		captureSender      mrmailer.MessageSender
 	}
 )
 
@@ -24,9 +34,85 @@ func WithMessageProcessorOpts(value ...consume.Option[entity.Message]) Option {
 	}
 }
 
+// WithSendMessageOpts - устанавливает опцию handlerOpts для обработчика сообщений.
+func WithSendMessageOpts(value ...handler.Option) Option {
+	return func(o *options) {
+		o.handlerOpts = append(o.handlerOpts, value...)
+	}
+}
+
 // WithSenderProviderOpts - устанавливает опцию providerOpts для consume.MessageProcessor.
 func WithSenderProviderOpts(value ...provider.Option) Option {
 	return func(o *options) {
 		o.providerOpts = append(o.providerOpts, value...)
 	}
 }
+
+// WithBatchDelivery - настраивает передачу писем пулу SMTP соединений пачками: за одно чтение из очереди
+// извлекается до batchSize сообщений, которые отправляются параллельно обработчиками, кол-во которых
+// равно размеру пула (см. smtppool.Pool.Size). Поэтому каждый обработчик отправляет письма пачки одно
+// за другим через одно соединение пула, которое между письмами сбрасывается командой RSET.
+// Пул должен быть почтовым клиентом отправителя писем (см. adapter.NewMailSender).
+func WithBatchDelivery(pool *smtppool.Pool, batchSize int) Option {
+	return func(o *options) {
+		o.processorOpts = append(
+			o.processorOpts,
+			consume.WithQueueSize[entity.Message](batchSize),
+			consume.WithWorkersCount[entity.Message](pool.Size()),
+		)
+	}
+}
+
+// WithCaptureMailbox - устанавливает вместо клиентов всех типов сообщений отправителя,
+// который сохраняет сообщения в памяти (для разработки и тестов).
+func WithCaptureMailbox(value *capture.Mailbox) Option {
+	return withCaptureSender(value)
+}
+
+// WithCaptureDir - устанавливает вместо клиентов всех типов сообщений отправителя,
+// который записывает сообщения в файлы указанной директории: письма в .eml, остальные в .json (для разработки).
+func WithCaptureDir(dir string) Option {
+	return withCaptureSender(capture.NewFileSink(dir))
+}
+
+// withCaptureSender - устанавливает указанного отправителя клиентом всех типов сообщений.
+// Клиенты отправителя устанавливаются после всех опций провайдера (см. captureProviderOpts),
+// поэтому заменяют клиентов, указанных в WithSenderProviderOpts, независимо от порядка опций.
+func withCaptureSender(sender mrmailer.MessageSender) Option {
+	return func(o *options) {
+		o.captureSender = sender
+	}
+}
+
+// captureProviderOpts - возвращает опции провайдера, в конец которых добавлены клиенты
+// всех типов сообщений, заменённые отправителем captureSender (если он установлен).
+func (o *options) captureProviderOpts() []provider.Option {
+	if o.captureSender == nil {
+		return o.providerOpts
+	}
+
+	return append(
+		o.providerOpts[:len(o.providerOpts):len(o.providerOpts)],
+		provider.WithClientMail(o.captureSender),
+		provider.WithClientMessenger(o.captureSender),
+		provider.WithClientSMS(o.captureSender),
+		provider.WithClientWebhook(o.captureSender),
+		provider.WithClientPush(o.captureSender),
+	)
+}
+
+// WithSealer - устанавливает опцию sealer (открытие данных сообщений, хранимых в запечатанном виде)
+// для consume.MessageProcessor.
+func WithSealer(value *sealdata.Sealer) Option {
+	return func(o *options) {
+		o.sealer = value
+	}
+}
+
+// WithTransitionNotifier - устанавливает опцию transitionNotifier (оповещение наблюдателей
+// о фиксации, отклонении и отмене обработки элементов очереди) для consume.MessageProcessor.
+func WithTransitionNotifier(value *observe.TransitionNotifier) Option {
+	return func(o *options) {
+		o.transitionNotifier = value
+	}
+}
@@ -18,16 +18,22 @@ func InitService(
 	traceManager mrtrace.ContextManager,
 	messageTable mrsql.DBTableInfo,
 	queueTable mrsql.DBTableInfo,
-	opts ...produce.Option,
+	opts ...Option,
 ) *produce.MessageProducer {
+	o := options{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return produce.New(
 		client,
 		sequence.NewGenerator(client, mrsql.SequenceName(queueTable)),
-		repository.NewMessagePostgres(client, messageTable),
+		repository.NewMessagePostgres(client, messageTable, repository.WithMessageSealer(o.sealer)),
 		queueproduce.New(
 			queuerepository.NewQueuePostgres(client, queueTable),
 		),
 		traceManager,
-		opts...,
+		o.producerOpts...,
 	)
 }
@@ -0,0 +1,30 @@
+package producer
+
+import (
+	"github.com/mondegor/go-components/mrmailer/service/produce"
+	"github.com/mondegor/go-components/mrqueue/sealdata"
+)
+
+type (
+	// Option - настройка объекта produce.MessageProducer.
+	Option func(o *options)
+
+	options struct {
+		producerOpts []produce.Option
+		sealer       *sealdata.Sealer
+	}
+)
+
+// WithMessageProducerOpts - устанавливает опцию producerOpts для produce.MessageProducer.
+func WithMessageProducerOpts(value ...produce.Option) Option {
+	return func(o *options) {
+		o.producerOpts = append(o.producerOpts, value...)
+	}
+}
+
+// WithSealer - устанавливает опцию sealer (хранение данных сообщений в запечатанном виде) для produce.MessageProducer.
+func WithSealer(value *sealdata.Sealer) Option {
+	return func(o *options) {
+		o.sealer = value
+	}
+}
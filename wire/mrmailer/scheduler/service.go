@@ -16,13 +16,19 @@ import (
 
 	"github.com/mondegor/go-components/mrmailer/entity"
 	"github.com/mondegor/go-components/mrmailer/repository"
+	"github.com/mondegor/go-components/mrmailer/service/redact"
+	"github.com/mondegor/go-components/mrqueue/observe"
 	queuerepository "github.com/mondegor/go-components/mrqueue/repository"
 	queuetoreadychange "github.com/mondegor/go-components/mrqueue/usecase/change/toready"
 	queuetoretrychange "github.com/mondegor/go-components/mrqueue/usecase/change/toretry"
+	queueclean "github.com/mondegor/go-components/mrqueue/usecase/clean"
 	queuecompletedclean "github.com/mondegor/go-components/mrqueue/usecase/completed/clean"
 	queuecrashedclean "github.com/mondegor/go-components/mrqueue/usecase/crashed/clean"
+	queuepartition "github.com/mondegor/go-components/mrqueue/usecase/partition"
 	"github.com/mondegor/go-components/wire/mrqueue/change"
 	"github.com/mondegor/go-components/wire/mrqueue/clean"
+	"github.com/mondegor/go-components/wire/mrqueue/partition"
+	"github.com/mondegor/go-components/wire/mrqueue/reseal"
 )
 
 const (
@@ -31,6 +37,10 @@ const (
 	defaultChangeRetryTimeout = 60 * time.Second
 	defaultChangeRetryDelayed = 30 * time.Second
 	defaultCleanBatchSize     = 100
+	defaultCompletedExpiry    = 24 * time.Hour
+	defaultCrashedExpiry      = 72 * time.Hour
+	defaultPartitionPremake   = 3
+	defaultResealBatchSize    = 100
 
 	defaultChangeFromToRetryCaption = "Task/ChangeFromToRetry"
 	defaultChangeFromToRetryPeriod  = 90 * time.Second
@@ -39,6 +49,22 @@ const (
 	defaultCleanMessagesCaption = "Task/CleanQueue"
 	defaultCleanMessagesPeriod  = 45 * time.Minute
 	defaultCleanMessagesTimeout = 120 * time.Second
+
+	defaultManagePartitionsCaption = "Task/ManagePartitions"
+	defaultManagePartitionsPeriod  = 60 * time.Minute
+	defaultManagePartitionsTimeout = 300 * time.Second
+
+	defaultResealMessagesCaption = "Task/ResealMessages"
+	defaultResealMessagesPeriod  = 60 * time.Minute
+	defaultResealMessagesTimeout = 300 * time.Second
+
+	defaultRedactMessagesCaption = "Task/RedactMessages"
+	defaultRedactMessagesPeriod  = 15 * time.Minute
+	defaultRedactMessagesTimeout = 120 * time.Second
+
+	defaultRemoveRedactedMessagesCaption = "Task/RemoveRedactedMessages"
+	defaultRemoveRedactedMessagesPeriod  = 60 * time.Minute
+	defaultRemoveRedactedMessagesTimeout = 120 * time.Second
 )
 
 // InitService - создаёт сервис для обработки и отправки сообщений и связанных с ним задачи.
@@ -56,6 +82,9 @@ func InitService(
 		captionPrefix:      defaultCaptionPrefix,
 		changeRetryTimeout: defaultChangeRetryTimeout,
 		changeRetryDelayed: defaultChangeRetryDelayed,
+		completedExpiry:    defaultCompletedExpiry,
+		crashedExpiry:      defaultCrashedExpiry,
+		partitionPremake:   defaultPartitionPremake,
 		taskChangerOpts: []task.Option{
 			task.WithCaptionPrefix(defaultCaptionPrefix),
 			task.WithCaption(defaultChangeFromToRetryCaption),
@@ -68,6 +97,30 @@ func InitService(
 			task.WithPeriod(defaultCleanMessagesPeriod),
 			task.WithTimeout(defaultCleanMessagesTimeout),
 		},
+		taskPartitionOpts: []task.Option{
+			task.WithCaptionPrefix(defaultCaptionPrefix),
+			task.WithCaption(defaultManagePartitionsCaption),
+			task.WithPeriod(defaultManagePartitionsPeriod),
+			task.WithTimeout(defaultManagePartitionsTimeout),
+		},
+		taskResealOpts: []task.Option{
+			task.WithCaptionPrefix(defaultCaptionPrefix),
+			task.WithCaption(defaultResealMessagesCaption),
+			task.WithPeriod(defaultResealMessagesPeriod),
+			task.WithTimeout(defaultResealMessagesTimeout),
+		},
+		taskRedactOpts: []task.Option{
+			task.WithCaptionPrefix(defaultCaptionPrefix),
+			task.WithCaption(defaultRedactMessagesCaption),
+			task.WithPeriod(defaultRedactMessagesPeriod),
+			task.WithTimeout(defaultRedactMessagesTimeout),
+		},
+		taskRemoveOpts: []task.Option{
+			task.WithCaptionPrefix(defaultCaptionPrefix),
+			task.WithCaption(defaultRemoveRedactedMessagesCaption),
+			task.WithPeriod(defaultRemoveRedactedMessagesPeriod),
+			task.WithTimeout(defaultRemoveRedactedMessagesTimeout),
+		},
 	}
 
 	for _, opt := range opts {
@@ -82,23 +135,35 @@ func InitService(
 		o.cleanBatchSize = defaultCleanBatchSize
 	}
 
-	storageMessage := repository.NewMessagePostgres(client, messageTable)
+	if o.resealBatchSize < 1 {
+		o.resealBatchSize = defaultResealBatchSize
+	}
 
-	storageQueue := queuerepository.NewQueuePostgres(client, queueTable)
-	storageQueueCompleted := queuerepository.NewCompletedPostgres(
-		client,
-		mrsql.DBTableInfo{
-			Name:       queueTable.Name + "_completed",
-			PrimaryKey: queueTable.PrimaryKey,
-		},
-	)
-	storageQueueCrashed := queuerepository.NewCrashedPostgres(
+	completedTable := mrsql.DBTableInfo{
+		Name:       queueTable.Name + "_completed",
+		PrimaryKey: queueTable.PrimaryKey,
+	}
+
+	storageMessage := repository.NewMessagePostgres(
 		client,
-		mrsql.DBTableInfo{
-			Name:       queueTable.Name + "_errors",
-			PrimaryKey: queueTable.PrimaryKey,
-		},
+		messageTable,
+		repository.WithMessageSealer(o.sealer),
+		repository.WithMessageCompletedTable(completedTable),
 	)
+	crashedTable := mrsql.DBTableInfo{
+		Name:       queueTable.Name + "_errors",
+		PrimaryKey: queueTable.PrimaryKey,
+	}
+
+	storageQueue := queuerepository.NewQueuePostgres(client, queueTable)
+	storageQueueCompleted := queuerepository.NewCompletedPostgres(client, completedTable)
+	storageQueueCrashed := queuerepository.NewCrashedPostgres(client, crashedTable)
+
+	// если наблюдатели не указаны, то используется оповещатель без наблюдателей
+	transitionNotifier := o.transitionNotifier
+	if transitionNotifier == nil {
+		transitionNotifier = observe.New()
+	}
 
 	queueEventEmitter := mrevent.EmitterWithSource(eventEmitter, entity.ModelNameMessage)
 
@@ -106,30 +171,42 @@ func InitService(
 		client,
 		storageQueue,
 		queueEventEmitter,
+		queueclean.WithTransitionNotifier(transitionNotifier),
 	)
 
+	deleteMessages := func(ctx context.Context, itemsIDs []uint64) error {
+		// сообщения с удалённым содержимым хранятся до истечения своего срока хранения
+		if o.redactPolicy.IsEnabled() {
+			return storageMessage.DeleteNotRedactedByIDs(ctx, itemsIDs)
+		}
+
+		return storageMessage.DeleteByIDs(ctx, itemsIDs)
+	}
+
 	completedMessageCleaner := clean.InitCompletedItemsCleaner(
 		client,
 		storageQueueCompleted,
 		queueEventEmitter,
-		queuecompletedclean.WithAfterClean(func(ctx context.Context, itemsIDs []uint64) error {
-			return storageMessage.DeleteByIDs(ctx, itemsIDs)
-		}),
+		queuecompletedclean.WithExpiry(o.completedExpiry),
+		queuecompletedclean.WithAfterClean(deleteMessages),
+		queuecompletedclean.WithTransitionNotifier(transitionNotifier),
 	)
 
 	crashedMessageCleaner := clean.InitCrashedItemsCleaner(
 		client,
 		storageQueueCrashed,
 		queueEventEmitter,
-		queuecrashedclean.WithAfterClean(func(ctx context.Context, itemsIDs []uint64) error {
-			return storageMessage.DeleteByIDs(ctx, itemsIDs)
-		}),
+		queuecrashedclean.WithExpiry(o.crashedExpiry),
+		queuecrashedclean.WithAfterClean(deleteMessages),
+		queuecrashedclean.WithTransitionNotifier(transitionNotifier),
 	)
 
 	messageStatusToReadyChanger := change.InitRetryToReadyChanger(
+		client,
 		storageQueue,
 		queueEventEmitter,
 		queuetoreadychange.WithRetryDelayed(o.changeRetryDelayed),
+		queuetoreadychange.WithTransitionNotifier(transitionNotifier),
 	)
 
 	messageStatusToRetryChanger := change.InitProcessingToRetryChanger(
@@ -138,6 +215,7 @@ func InitService(
 		queueEventEmitter,
 		queuetoretrychange.WithStorageCrashed(storageQueueCrashed),
 		queuetoretrychange.WithRetryTimeout(o.changeRetryTimeout),
+		queuetoretrychange.WithTransitionNotifier(transitionNotifier),
 	)
 
 	changerTask := task.NewJobWrapper(
@@ -151,6 +229,78 @@ func InitService(
 		o.taskChangerOpts...,
 	)
 
+	tasks := []mrprocess.Task{changerTask}
+
+	if o.sealer != nil {
+		tasks = append(
+			tasks,
+			task.NewJobWrapper(
+				reseal.InitResealJob[entity.Message](client, storageMessage, o.resealBatchSize),
+				o.taskResealOpts...,
+			),
+		)
+	}
+
+	if o.redactPolicy.IsEnabled() {
+		messageRedactor := redact.New(
+			client,
+			storageMessage,
+			redact.WithPeriod(o.redactPolicy.Period()),
+			redact.WithHashKey(o.redactPolicy.HashKey()),
+		)
+		messageRemover := redact.NewMessageRemover(storageMessage, o.redactPolicy.Retention())
+
+		tasks = append(
+			tasks,
+			task.NewJobWrapper(
+				mrprocess.JobFunc(func(ctx context.Context) error {
+					return messageRedactor.Execute(ctx, o.cleanBatchSize)
+				}),
+				o.taskRedactOpts...,
+			),
+			task.NewJobWrapper(
+				mrprocess.JobFunc(func(ctx context.Context) error {
+					return messageRemover.Execute(ctx, o.cleanBatchSize)
+				}),
+				o.taskRemoveOpts...,
+			),
+		)
+	}
+
+	if o.partitionPeriod > 0 {
+		partitionTask := task.NewJobWrapper(
+			partition.InitPartitionsJob(
+				client,
+				completedTable,
+				crashedTable,
+				o.completedExpiry,
+				o.crashedExpiry,
+				transitionNotifier,
+				queuepartition.WithPeriod(o.partitionPeriod),
+				queuepartition.WithPremake(o.partitionPremake),
+				queuepartition.WithAfterClean(deleteMessages),
+			),
+			o.taskPartitionOpts...,
+		)
+
+		// записи секционированных таблиц удаляются вместе с их секциями,
+		// поэтому пакетная очистка выполняется только для самой очереди
+		cleanerTask := task.NewJobWrapper(
+			mrprocess.JobFunc(func(ctx context.Context) error {
+				return forgottenMessageCleaner.Execute(ctx, o.cleanBatchSize)
+			}),
+			o.taskCleanerOpts...,
+		)
+
+		return schedule.NewTaskScheduler(
+			errorHandler,
+			logger,
+			traceManager,
+			schedule.WithCaptionPrefix(o.captionPrefix),
+			schedule.WithTasks(append(tasks, cleanerTask, partitionTask)...),
+		)
+	}
+
 	cleanerTask := task.NewJobWrapper(
 		mrprocess.JobFunc(func(ctx context.Context) error {
 			if err := forgottenMessageCleaner.Execute(ctx, o.cleanBatchSize); err != nil {
@@ -171,6 +321,6 @@ func InitService(
 		logger,
 		traceManager,
 		schedule.WithCaptionPrefix(o.captionPrefix),
-		schedule.WithTasks(changerTask, cleanerTask),
+		schedule.WithTasks(append(tasks, cleanerTask)...),
 	)
 }
@@ -18,5 +18,16 @@ type (
 		ChangeRetryTimeout   time.Duration               `yaml:"change_retry_timeout"`
 		ChangeRetryDelayed   time.Duration               `yaml:"change_retry_delayed"`
 		CleanQueueBatchSize  uint32                      `yaml:"clean_queue_batch_size"`
+		CompletedExpiry      time.Duration               `yaml:"completed_expiry"`
+		CrashedExpiry        time.Duration               `yaml:"crashed_expiry"`
+		QueuePartitioning    QueuePartitioning           `yaml:"queue_partitioning"`
+	}
+
+	// QueuePartitioning - настройки секционирования таблиц успешно обработанных элементов
+	// и журнала ошибок очереди (если Period не указан, то секционирование не используется).
+	QueuePartitioning struct {
+		ManagePartitions processcfg.SchedulerTask `yaml:"manage_partitions"`
+		Period           time.Duration            `yaml:"period"`
+		Premake          uint16                   `yaml:"premake"`
 	}
 )
@@ -64,7 +64,7 @@ func InitService(
 		opt(&o)
 	}
 
-	storageNotice := repository.NewNotePostgres(client, noticeTable)
+	storageNotice := repository.NewNotePostgres(client, noticeTable, repository.WithNoteSealer(o.sealer))
 
 	storageTemplate := templaterepository.NewTemplatePostgres(
 		client,
@@ -92,14 +92,22 @@ func InitService(
 		},
 	)
 
+	consumerOpts := []queueconsume.Option{
+		queueconsume.WithStorageCompleted(storageQueueCompleted),
+		queueconsume.WithStorageCrashed(storageQueueCrashed),
+	}
+
+	if o.transitionNotifier != nil {
+		consumerOpts = append(consumerOpts, queueconsume.WithTransitionNotifier(o.transitionNotifier))
+	}
+
 	noticeConsumer := queueconsume.NewMessageConsumer[entity.Note](
 		client,
 		storageNotice,
 		queueconsume.NewQueueConsumer(
 			client,
 			storageQueue,
-			queueconsume.WithStorageCompleted(storageQueueCompleted),
-			queueconsume.WithStorageCrashed(storageQueueCrashed),
+			consumerOpts...,
 		),
 	)
 
@@ -113,8 +121,10 @@ func InitService(
 					logger,
 					o.defaultLang,
 				),
+				o.buildNoticeOpts...,
 			),
 			noticeProvider,
+			o.handlerOpts...,
 		),
 		errorHandler,
 		logger,
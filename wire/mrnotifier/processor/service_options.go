@@ -4,6 +4,10 @@ import (
 	"github.com/mondegor/go-core/mrprocess/consume"
 
 	"github.com/mondegor/go-components/mrnotifier/notifier/entity"
+	"github.com/mondegor/go-components/mrnotifier/notifier/infra/handler"
+	"github.com/mondegor/go-components/mrnotifier/notifier/usecase"
+	"github.com/mondegor/go-components/mrqueue/observe"
+	"github.com/mondegor/go-components/mrqueue/sealdata"
 )
 
 type (
@@ -11,8 +15,12 @@ type (
 	Option func(o *options)
 
 	options struct {
-		defaultLang   string
-		processorOpts []consume.Option[entity.Note]
+		defaultLang        string
+		processorOpts      []consume.Option[entity.Note]
+		handlerOpts        []handler.Option
+		buildNoticeOpts    []usecase.Option
+		transitionNotifier *observe.TransitionNotifier
+		sealer             *sealdata.Sealer
 	}
 )
 
@@ -29,3 +37,34 @@ func WithNoticeProcessorOpts(value ...consume.Option[entity.Note]) Option {
 		o.processorOpts = append(o.processorOpts, value...)
 	}
 }
+
+// WithSendNoticeOpts - устанавливает опцию handlerOpts для обработчика уведомлений.
+func WithSendNoticeOpts(value ...handler.Option) Option {
+	return func(o *options) {
+		o.handlerOpts = append(o.handlerOpts, value...)
+	}
+}
+
+// WithBuildNoticeOpts - устанавливает опцию buildNoticeOpts для сборщика уведомлений
+// (например, отправка копий наблюдателям через Cc/Bcc: usecase.WithBuildManagerOpts(buildnotice.WithObserversAsCc())).
+func WithBuildNoticeOpts(value ...usecase.Option) Option {
+	return func(o *options) {
+		o.buildNoticeOpts = append(o.buildNoticeOpts, value...)
+	}
+}
+
+// WithSealer - устанавливает опцию sealer (открытие данных уведомлений, хранимых в запечатанном виде)
+// для consume.MessageProcessor.
+func WithSealer(value *sealdata.Sealer) Option {
+	return func(o *options) {
+		o.sealer = value
+	}
+}
+
+// WithTransitionNotifier - устанавливает опцию transitionNotifier (оповещение наблюдателей
+// о фиксации, отклонении и отмене обработки элементов очереди) для consume.MessageProcessor.
+func WithTransitionNotifier(value *observe.TransitionNotifier) Option {
+	return func(o *options) {
+		o.transitionNotifier = value
+	}
+}
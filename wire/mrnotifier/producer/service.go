@@ -18,16 +18,22 @@ func InitService(
 	traceManager mrtrace.ContextManager,
 	noticeTable mrsql.DBTableInfo,
 	queueTable mrsql.DBTableInfo,
-	opts ...produce.Option,
+	opts ...Option,
 ) *produce.NoteProducer {
+	o := options{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return produce.New(
 		client,
 		sequence.NewGenerator(client, mrsql.SequenceName(queueTable)),
-		repository.NewNotePostgres(client, noticeTable),
+		repository.NewNotePostgres(client, noticeTable, repository.WithNoteSealer(o.sealer)),
 		queueproduce.New(
 			queuerepository.NewQueuePostgres(client, queueTable),
 		),
 		traceManager,
-		opts...,
+		o.producerOpts...,
 	)
 }
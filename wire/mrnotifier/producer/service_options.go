@@ -0,0 +1,30 @@
+package producer
+
+import (
+	"github.com/mondegor/go-components/mrnotifier/notifier/service/produce"
+	"github.com/mondegor/go-components/mrqueue/sealdata"
+)
+
+type (
+	// Option - настройка объекта produce.NoteProducer.
+	Option func(o *options)
+
+	options struct {
+		producerOpts []produce.Option
+		sealer       *sealdata.Sealer
+	}
+)
+
+// WithNoteProducerOpts - устанавливает опцию producerOpts для produce.NoteProducer.
+func WithNoteProducerOpts(value ...produce.Option) Option {
+	return func(o *options) {
+		o.producerOpts = append(o.producerOpts, value...)
+	}
+}
+
+// WithSealer - устанавливает опцию sealer (хранение данных уведомлений в запечатанном виде) для produce.NoteProducer.
+func WithSealer(value *sealdata.Sealer) Option {
+	return func(o *options) {
+		o.sealer = value
+	}
+}
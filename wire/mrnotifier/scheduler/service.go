@@ -16,13 +16,18 @@ import (
 
 	"github.com/mondegor/go-components/mrnotifier/notifier/entity"
 	"github.com/mondegor/go-components/mrnotifier/notifier/repository"
+	"github.com/mondegor/go-components/mrqueue/observe"
 	queuerepository "github.com/mondegor/go-components/mrqueue/repository"
 	queuetoreadychange "github.com/mondegor/go-components/mrqueue/usecase/change/toready"
 	queuetoretrychange "github.com/mondegor/go-components/mrqueue/usecase/change/toretry"
+	queueclean "github.com/mondegor/go-components/mrqueue/usecase/clean"
 	queuecompletedclean "github.com/mondegor/go-components/mrqueue/usecase/completed/clean"
 	queuecrashedclean "github.com/mondegor/go-components/mrqueue/usecase/crashed/clean"
+	queuepartition "github.com/mondegor/go-components/mrqueue/usecase/partition"
 	"github.com/mondegor/go-components/wire/mrqueue/change"
 	"github.com/mondegor/go-components/wire/mrqueue/clean"
+	"github.com/mondegor/go-components/wire/mrqueue/partition"
+	"github.com/mondegor/go-components/wire/mrqueue/reseal"
 )
 
 const (
@@ -31,6 +36,10 @@ const (
 	defaultChangeRetryTimeout = 60 * time.Second
 	defaultChangeRetryDelayed = 30 * time.Second
 	defaultCleanBatchSize     = 100
+	defaultCompletedExpiry    = 24 * time.Hour
+	defaultCrashedExpiry      = 72 * time.Hour
+	defaultPartitionPremake   = 3
+	defaultResealBatchSize    = 100
 
 	defaultChangeFromToRetryCaption = "Task/ChangeFromToRetry"
 	defaultChangeFromToRetryPeriod  = 90 * time.Second
@@ -39,6 +48,14 @@ const (
 	defaultCleanNoticesCaption = "Task/CleanNotices"
 	defaultCleanNoticesPeriod  = 45 * time.Minute
 	defaultCleanNoticesTimeout = 120 * time.Second
+
+	defaultManagePartitionsCaption = "Task/ManagePartitions"
+	defaultManagePartitionsPeriod  = 60 * time.Minute
+	defaultManagePartitionsTimeout = 300 * time.Second
+
+	defaultResealNoticesCaption = "Task/ResealNotices"
+	defaultResealNoticesPeriod  = 60 * time.Minute
+	defaultResealNoticesTimeout = 300 * time.Second
 )
 
 // InitService - создаёт сервис для обработки уведомлений и связанных с ним задачи.
@@ -56,6 +73,9 @@ func InitService(
 		captionPrefix:      defaultCaptionPrefix,
 		changeRetryTimeout: defaultChangeRetryTimeout,
 		changeRetryDelayed: defaultChangeRetryDelayed,
+		completedExpiry:    defaultCompletedExpiry,
+		crashedExpiry:      defaultCrashedExpiry,
+		partitionPremake:   defaultPartitionPremake,
 		taskChangerOpts: []task.Option{
 			task.WithCaptionPrefix(defaultCaptionPrefix),
 			task.WithCaption(defaultChangeFromToRetryCaption),
@@ -68,6 +88,18 @@ func InitService(
 			task.WithPeriod(defaultCleanNoticesPeriod),
 			task.WithTimeout(defaultCleanNoticesTimeout),
 		},
+		taskPartitionOpts: []task.Option{
+			task.WithCaptionPrefix(defaultCaptionPrefix),
+			task.WithCaption(defaultManagePartitionsCaption),
+			task.WithPeriod(defaultManagePartitionsPeriod),
+			task.WithTimeout(defaultManagePartitionsTimeout),
+		},
+		taskResealOpts: []task.Option{
+			task.WithCaptionPrefix(defaultCaptionPrefix),
+			task.WithCaption(defaultResealNoticesCaption),
+			task.WithPeriod(defaultResealNoticesPeriod),
+			task.WithTimeout(defaultResealNoticesTimeout),
+		},
 	}
 
 	for _, opt := range opts {
@@ -82,23 +114,30 @@ func InitService(
 		o.cleanBatchSize = defaultCleanBatchSize
 	}
 
-	storageNotice := repository.NewNotePostgres(client, noticeTable)
+	if o.resealBatchSize < 1 {
+		o.resealBatchSize = defaultResealBatchSize
+	}
+
+	storageNotice := repository.NewNotePostgres(client, noticeTable, repository.WithNoteSealer(o.sealer))
+
+	completedTable := mrsql.DBTableInfo{
+		Name:       queueTable.Name + "_completed",
+		PrimaryKey: queueTable.PrimaryKey,
+	}
+	crashedTable := mrsql.DBTableInfo{
+		Name:       queueTable.Name + "_errors",
+		PrimaryKey: queueTable.PrimaryKey,
+	}
 
 	storageQueue := queuerepository.NewQueuePostgres(client, queueTable)
-	storageQueueCompleted := queuerepository.NewCompletedPostgres(
-		client,
-		mrsql.DBTableInfo{
-			Name:       queueTable.Name + "_completed",
-			PrimaryKey: queueTable.PrimaryKey,
-		},
-	)
-	storageQueueCrashed := queuerepository.NewCrashedPostgres(
-		client,
-		mrsql.DBTableInfo{
-			Name:       queueTable.Name + "_errors",
-			PrimaryKey: queueTable.PrimaryKey,
-		},
-	)
+	storageQueueCompleted := queuerepository.NewCompletedPostgres(client, completedTable)
+	storageQueueCrashed := queuerepository.NewCrashedPostgres(client, crashedTable)
+
+	// если наблюдатели не указаны, то используется оповещатель без наблюдателей
+	transitionNotifier := o.transitionNotifier
+	if transitionNotifier == nil {
+		transitionNotifier = observe.New()
+	}
 
 	queueEventEmitter := mrevent.EmitterWithSource(eventEmitter, entity.ModelNameNotice)
 
@@ -106,30 +145,37 @@ func InitService(
 		client,
 		storageQueue,
 		queueEventEmitter,
+		queueclean.WithTransitionNotifier(transitionNotifier),
 	)
 
+	deleteNotices := func(ctx context.Context, itemsIDs []uint64) error {
+		return storageNotice.DeleteByIDs(ctx, itemsIDs)
+	}
+
 	completedNoticeCleaner := clean.InitCompletedItemsCleaner(
 		client,
 		storageQueueCompleted,
 		queueEventEmitter,
-		queuecompletedclean.WithAfterClean(func(ctx context.Context, itemsIDs []uint64) error {
-			return storageNotice.DeleteByIDs(ctx, itemsIDs)
-		}),
+		queuecompletedclean.WithExpiry(o.completedExpiry),
+		queuecompletedclean.WithAfterClean(deleteNotices),
+		queuecompletedclean.WithTransitionNotifier(transitionNotifier),
 	)
 
 	crashedNoticeCleaner := clean.InitCrashedItemsCleaner(
 		client,
 		storageQueueCrashed,
 		queueEventEmitter,
-		queuecrashedclean.WithAfterClean(func(ctx context.Context, itemsIDs []uint64) error {
-			return storageNotice.DeleteByIDs(ctx, itemsIDs)
-		}),
+		queuecrashedclean.WithExpiry(o.crashedExpiry),
+		queuecrashedclean.WithAfterClean(deleteNotices),
+		queuecrashedclean.WithTransitionNotifier(transitionNotifier),
 	)
 
 	noticeStatusToReadyChanger := change.InitRetryToReadyChanger(
+		client,
 		storageQueue,
 		queueEventEmitter,
 		queuetoreadychange.WithRetryDelayed(o.changeRetryDelayed),
+		queuetoreadychange.WithTransitionNotifier(transitionNotifier),
 	)
 
 	noticeStatusToRetryChanger := change.InitProcessingToRetryChanger(
@@ -138,6 +184,7 @@ func InitService(
 		queueEventEmitter,
 		queuetoretrychange.WithStorageCrashed(storageQueueCrashed),
 		queuetoretrychange.WithRetryTimeout(o.changeRetryTimeout),
+		queuetoretrychange.WithTransitionNotifier(transitionNotifier),
 	)
 
 	changerTask := task.NewJobWrapper(
@@ -151,6 +198,52 @@ func InitService(
 		o.taskChangerOpts...,
 	)
 
+	tasks := []mrprocess.Task{changerTask}
+
+	if o.sealer != nil {
+		tasks = append(
+			tasks,
+			task.NewJobWrapper(
+				reseal.InitResealJob[entity.Note](client, storageNotice, o.resealBatchSize),
+				o.taskResealOpts...,
+			),
+		)
+	}
+
+	if o.partitionPeriod > 0 {
+		partitionTask := task.NewJobWrapper(
+			partition.InitPartitionsJob(
+				client,
+				completedTable,
+				crashedTable,
+				o.completedExpiry,
+				o.crashedExpiry,
+				transitionNotifier,
+				queuepartition.WithPeriod(o.partitionPeriod),
+				queuepartition.WithPremake(o.partitionPremake),
+				queuepartition.WithAfterClean(deleteNotices),
+			),
+			o.taskPartitionOpts...,
+		)
+
+		// записи секционированных таблиц удаляются вместе с их секциями,
+		// поэтому пакетная очистка выполняется только для самой очереди
+		cleanerTask := task.NewJobWrapper(
+			mrprocess.JobFunc(func(ctx context.Context) error {
+				return forgottenNoticeCleaner.Execute(ctx, o.cleanBatchSize)
+			}),
+			o.taskCleanerOpts...,
+		)
+
+		return schedule.NewTaskScheduler(
+			errorHandler,
+			logger,
+			traceManager,
+			schedule.WithCaptionPrefix(o.captionPrefix),
+			schedule.WithTasks(append(tasks, cleanerTask, partitionTask)...),
+		)
+	}
+
 	cleanerTask := task.NewJobWrapper(
 		mrprocess.JobFunc(func(ctx context.Context) error {
 			if err := forgottenNoticeCleaner.Execute(ctx, o.cleanBatchSize); err != nil {
@@ -171,6 +264,6 @@ func InitService(
 		logger,
 		traceManager,
 		schedule.WithCaptionPrefix(o.captionPrefix),
-		schedule.WithTasks(changerTask, cleanerTask),
+		schedule.WithTasks(append(tasks, cleanerTask)...),
 	)
 }
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"github.com/mondegor/go-core/mrprocess/job/task"
+
+	"github.com/mondegor/go-components/mrqueue/observe"
+	"github.com/mondegor/go-components/mrqueue/sealdata"
 )
 
 type (
@@ -16,8 +19,17 @@ type (
 		changeRetryTimeout time.Duration
 		changeRetryDelayed time.Duration
 		cleanBatchSize     int
+		completedExpiry    time.Duration
+		crashedExpiry      time.Duration
+		partitionPeriod    time.Duration
+		partitionPremake   int
+		resealBatchSize    int
+		sealer             *sealdata.Sealer
 		taskChangerOpts    []task.Option
 		taskCleanerOpts    []task.Option
+		taskPartitionOpts  []task.Option
+		taskResealOpts     []task.Option
+		transitionNotifier *observe.TransitionNotifier
 	}
 )
 
@@ -56,6 +68,50 @@ func WithCleanBatchSize(value int) Option {
 	}
 }
 
+// WithCompletedExpiry - устанавливает опцию completedExpiry (срок хранения успешно обработанных элементов) для schedule.TaskScheduler.
+func WithCompletedExpiry(value time.Duration) Option {
+	return func(o *options) {
+		o.completedExpiry = value
+	}
+}
+
+// WithCrashedExpiry - устанавливает опцию crashedExpiry (срок хранения журнала ошибок) для schedule.TaskScheduler.
+func WithCrashedExpiry(value time.Duration) Option {
+	return func(o *options) {
+		o.crashedExpiry = value
+	}
+}
+
+// WithPartitionedQueueTables - включает режим, при котором таблицы успешно обработанных элементов
+// и журнала ошибок секционированы по диапазону времени с указанным периодом, а premake - кол-во
+// заранее создаваемых будущих секций. Вместо пакетного удаления записей из этих таблиц
+// удаляются целые секции, срок хранения которых истёк.
+// Срок хранения должен превышать максимальное время нахождения элемента в очереди.
+func WithPartitionedQueueTables(period time.Duration, premake int) Option {
+	return func(o *options) {
+		o.partitionPeriod = period
+		o.partitionPremake = premake
+	}
+}
+
+// WithSealer - включает хранение данных уведомлений в запечатанном виде и задачу, которая
+// запечатывает заново текущим ключом данные, запечатанные прежними ключами или хранимые в открытом виде,
+// пакетами по resealBatchSize записей. Должен совпадать с sealdata.Sealer обработчика и отправителя уведомлений.
+func WithSealer(value *sealdata.Sealer, resealBatchSize int) Option {
+	return func(o *options) {
+		o.sealer = value
+		o.resealBatchSize = resealBatchSize
+	}
+}
+
+// WithTransitionNotifier - устанавливает опцию transitionNotifier (оповещение наблюдателей
+// о переходах элементов очереди при смене статусов и очистке) для schedule.TaskScheduler.
+func WithTransitionNotifier(value *observe.TransitionNotifier) Option {
+	return func(o *options) {
+		o.transitionNotifier = value
+	}
+}
+
 // WithTaskChangeFromToRetryOpts - устанавливает опцию taskChangerOpts для schedule.TaskScheduler.
 func WithTaskChangeFromToRetryOpts(value ...task.Option) Option {
 	return func(o *options) {
@@ -69,3 +125,17 @@ func WithTaskCleanNoticesOpts(value ...task.Option) Option {
 		o.taskCleanerOpts = append(o.taskCleanerOpts, value...)
 	}
 }
+
+// WithTaskManagePartitionsOpts - устанавливает опцию taskPartitionOpts для schedule.TaskScheduler.
+func WithTaskManagePartitionsOpts(value ...task.Option) Option {
+	return func(o *options) {
+		o.taskPartitionOpts = append(o.taskPartitionOpts, value...)
+	}
+}
+
+// WithTaskResealNoticesOpts - устанавливает опцию taskResealOpts для schedule.TaskScheduler.
+func WithTaskResealNoticesOpts(value ...task.Option) Option {
+	return func(o *options) {
+		o.taskResealOpts = append(o.taskResealOpts, value...)
+	}
+}
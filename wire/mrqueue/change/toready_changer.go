@@ -5,6 +5,7 @@ import (
 
 	"github.com/mondegor/go-core/mrevent"
 	"github.com/mondegor/go-core/mrprocess/helper"
+	"github.com/mondegor/go-core/mrstorage"
 
 	"github.com/mondegor/go-components/mrqueue/usecase/change/toready"
 )
@@ -15,12 +16,14 @@ const (
 
 // InitRetryToReadyChanger - создаёт объект RetryToReadyChanger.
 func InitRetryToReadyChanger(
+	txManager mrstorage.DBTxManager,
 	storage toready.ItemStorage,
 	eventEmitter mrevent.Emitter,
 	opts ...toready.Option,
 ) *helper.ItemBatchPlayer {
 	return helper.NewItemBatchPlayerWithDurationLimit(
 		toready.New(
+			txManager,
 			storage,
 			opts...,
 		),
@@ -0,0 +1,56 @@
+package partition
+
+import (
+	"context"
+	"time"
+
+	"github.com/mondegor/go-core/mrprocess"
+	"github.com/mondegor/go-core/mrstorage"
+	"github.com/mondegor/go-core/mrstorage/mrsql"
+
+	"github.com/mondegor/go-components/mrqueue/enum/itemstatus"
+	"github.com/mondegor/go-components/mrqueue/observe"
+	"github.com/mondegor/go-components/mrqueue/repository"
+	"github.com/mondegor/go-components/mrqueue/usecase/partition"
+)
+
+// InitPartitionsJob - создаёт задание, которое управляет секциями таблицы успешно обработанных
+// элементов очереди (completedTable) и таблицы её ошибок (crashedTable) со сроками хранения
+// completedExpiry и crashedExpiry соответственно. Опции opts применяются к обеим таблицам.
+func InitPartitionsJob(
+	client mrstorage.DBConnManager,
+	completedTable mrsql.DBTableInfo,
+	crashedTable mrsql.DBTableInfo,
+	completedExpiry time.Duration,
+	crashedExpiry time.Duration,
+	transitionNotifier *observe.TransitionNotifier,
+	opts ...partition.Option,
+) mrprocess.JobFunc {
+	completedManager := partition.New(
+		client,
+		repository.NewPartitionPostgres(client, completedTable),
+		append(
+			opts[:len(opts):len(opts)],
+			partition.WithRetention(completedExpiry),
+			partition.WithTransitionNotifier(transitionNotifier, itemstatus.Completed),
+		)...,
+	)
+
+	crashedManager := partition.New(
+		client,
+		repository.NewPartitionPostgres(client, crashedTable),
+		append(
+			opts[:len(opts):len(opts)],
+			partition.WithRetention(crashedExpiry),
+			partition.WithTransitionNotifier(transitionNotifier, itemstatus.Crashed),
+		)...,
+	)
+
+	return func(ctx context.Context) error {
+		if err := completedManager.Execute(ctx); err != nil {
+			return err
+		}
+
+		return crashedManager.Execute(ctx)
+	}
+}
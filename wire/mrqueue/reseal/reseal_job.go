@@ -0,0 +1,24 @@
+package reseal
+
+import (
+	"context"
+
+	"github.com/mondegor/go-core/mrprocess"
+	"github.com/mondegor/go-core/mrstorage"
+
+	"github.com/mondegor/go-components/mrqueue/sealdata"
+)
+
+// InitResealJob - создаёт задание, которое запечатывает заново текущим ключом данные хранилища storage,
+// запечатанные прежними ключами или хранимые в открытом виде, пакетами по batchSize записей.
+func InitResealJob[T any](
+	txManager mrstorage.DBTxManager,
+	storage sealdata.ResealStorage[T],
+	batchSize int,
+) mrprocess.JobFunc {
+	resealer := sealdata.NewResealer[T](txManager, storage)
+
+	return func(ctx context.Context) error {
+		return resealer.Execute(ctx, batchSize)
+	}
+}